@@ -0,0 +1,294 @@
+package poly
+
+import "math"
+
+// Approximates a function on the interval [a, b] by a polynomial of degree at
+// most deg.
+// The function is sampled at the deg+1 Chebyshev nodes mapped onto [a, b] and
+// the result is the polynomial interpolating those samples, computed through
+// its Chebyshev series, dropping terms of the series that are negligible
+// relative to the largest. For smooth functions this is within a small factor
+// of the best possible uniform approximation of the same degree, and it is
+// exact when f is itself a polynomial of degree at most deg.
+// Panics if deg is negative or if a >= b.
+func Approximate(f func(float64) float64, a, b float64, deg int) Poly {
+	if deg < 0 {
+		panic("poly: negative degree")
+	}
+	if !(a < b) {
+		panic("poly: invalid interval")
+	}
+	n := deg + 1
+	fs := make([]float64, n)
+	for k := range fs {
+		t := math.Cos(math.Pi * (float64(k) + 0.5) / float64(n))
+		fs[k] = f((a+b)/2 + (b-a)/2*t)
+	}
+	c := make([]float64, n)
+	for j := range c {
+		var s float64
+		for k, fk := range fs {
+			s += fk * math.Cos(math.Pi*float64(j)*(float64(k)+0.5)/float64(n))
+		}
+		c[j] = 2 * s / float64(n)
+	}
+	c[0] /= 2
+	// Coefficients at the level of rounding error are dropped so that
+	// polynomials of lower degree than deg come out with their own degree.
+	var cmax float64
+	for _, cj := range c {
+		cmax = math.Max(cmax, math.Abs(cj))
+	}
+	for j, cj := range c {
+		if math.Abs(cj) <= 4*float64(n)*epsilon*cmax {
+			c[j] = 0
+		}
+	}
+	return FromChebyshev(c, a, b)
+}
+
+// Computes the minimax polynomial approximation of a function on the
+// interval [a, b], the polynomial of degree at most deg whose largest absolute
+// error on [a, b] is as small as possible. Returns the polynomial along with
+// that largest error.
+// The approximation is computed with the Remez exchange algorithm, starting
+// from the Chebyshev extrema. Each iteration solves for the polynomial whose
+// error equioscillates on the current set of deg+2 reference points, and then
+// replaces the whole reference by the largest alternating extrema of its
+// error. The iteration stops once the error at the reference points is level
+// to within a small relative tolerance. The polynomial is held in the
+// Chebyshev basis throughout, so the systems stay well conditioned at high
+// degree. If the reference points nevertheless become too close together to
+// determine the level, as can happen for a function that is not smooth, the
+// exchange stops with the approximation found so far. f must be continuous on
+// [a, b], and convergence is fastest when it is smooth.
+// Panics if deg is negative or if a >= b.
+func Remez(f func(float64) float64, a, b float64, deg int) (Poly, float64) {
+	if deg < 0 {
+		panic("poly: negative degree")
+	}
+	if !(a < b) {
+		panic("poly: invalid interval")
+	}
+	// Work in t on [-1, 1], where x = m + h*t.
+	m, h := (a+b)/2, (b-a)/2
+	g := func(t float64) float64 {
+		return f(m + h*t)
+	}
+	n := deg + 2
+	ref := chebExtrema(n)
+	grid := chebExtrema(32 * n)
+	// The Chebyshev coefficients of the current approximation in t.
+	var c []float64
+	e := func(t float64) float64 {
+		return g(t) - chebEval(c, t)
+	}
+	var maxErr float64
+	restarted := false
+	for iter := 0; iter < 100; iter++ {
+		fit, _, err := levelFit(g, ref)
+		if err != nil {
+			if c == nil {
+				c = Approximate(g, -1, 1, deg).ToChebyshev(-1, 1)
+			}
+			maxErr = gmax(e, grid)
+			break
+		}
+		c = fit
+		// The reference points are searched along with the grid, since
+		// the error alternates on them but may do so between grid points
+		// where f is not smooth.
+		next := alternatingExtrema(e, mergeBreaks(grid, ref), n)
+		if next == nil && !restarted {
+			// For an even or odd f the symmetric starting reference can
+			// give a level of zero and too few extrema to exchange. The
+			// minimax error then equioscillates on deg+3 points, so start
+			// again from deg+2 of the deg+3 Chebyshev extrema.
+			ref = chebExtrema(n + 1)[1:]
+			restarted = true
+			continue
+		}
+		if next == nil {
+			maxErr = gmax(e, grid)
+			break
+		}
+		minErr := math.Inf(1)
+		maxErr = 0
+		for _, t := range next {
+			ei := math.Abs(e(t))
+			maxErr = math.Max(maxErr, ei)
+			minErr = math.Min(minErr, ei)
+		}
+		ref = next
+		if maxErr-minErr <= 1e-10*maxErr || maxErr <= 4*float64(n)*epsilon*gmax(g, grid) {
+			break
+		}
+	}
+	return FromChebyshev(c, a, b), maxErr
+}
+
+// Evaluates the Chebyshev series sum of c[j] T_j(t) with Clenshaw's
+// recurrence.
+func chebEval(c []float64, t float64) float64 {
+	if len(c) == 0 {
+		return 0
+	}
+	var b1, b2 float64
+	for j := len(c) - 1; j > 0; j-- {
+		b1, b2 = 2*t*b1-b2+c[j], b1
+	}
+	return t*b1 - b2 + c[0]
+}
+
+// Returns the n extrema of the Chebyshev polynomial T_(n-1) on [-1, 1], in
+// ascending order.
+func chebExtrema(n int) []float64 {
+	ts := make([]float64, n)
+	for i := range ts {
+		ts[i] = -math.Cos(math.Pi * float64(i) / float64(n-1))
+	}
+	ts[0], ts[n-1] = -1, 1
+	return ts
+}
+
+// Finds n points at which the error function e has local extrema of
+// alternating sign, preferring the largest. The extrema are located on the
+// ascending grid and then refined. Returns nil if e has fewer than n
+// alternating extrema on the grid.
+func alternatingExtrema(e func(float64) float64, grid []float64, n int) []float64 {
+	// Find the largest |e| in each run of the grid on which e keeps the
+	// same sign.
+	var idx []int
+	var vals []float64
+	for k, t := range grid {
+		v := e(t)
+		if v == 0 {
+			continue
+		}
+		last := len(vals) - 1
+		switch {
+		case last < 0 || (v < 0) != (vals[last] < 0):
+			idx = append(idx, k)
+			vals = append(vals, v)
+		case math.Abs(v) > math.Abs(vals[last]):
+			idx[last], vals[last] = k, v
+		}
+	}
+	if len(idx) < n {
+		return nil
+	}
+	// Drop the smallest extrema while keeping the signs alternating: an
+	// extremum at either end can be dropped alone, and one in the middle
+	// together with its smaller neighbour.
+	for len(idx) > n {
+		k := 0
+		for j := range vals {
+			if math.Abs(vals[j]) < math.Abs(vals[k]) {
+				k = j
+			}
+		}
+		switch {
+		case k == 0 || k == len(idx)-1 || len(idx)-n == 1:
+			if len(idx)-n == 1 && k != 0 && k != len(idx)-1 {
+				// Dropping two would leave too few; drop the smaller end.
+				k = 0
+				if math.Abs(vals[len(vals)-1]) < math.Abs(vals[0]) {
+					k = len(vals) - 1
+				}
+			}
+			idx = append(idx[:k], idx[k+1:]...)
+			vals = append(vals[:k], vals[k+1:]...)
+		default:
+			if math.Abs(vals[k+1]) < math.Abs(vals[k-1]) {
+				k++
+			}
+			idx = append(idx[:k-1], idx[k+1:]...)
+			vals = append(vals[:k-1], vals[k+1:]...)
+		}
+	}
+	ts := make([]float64, n)
+	for i, k := range idx {
+		s := 1.0
+		if vals[i] < 0 {
+			s = -1
+		}
+		lo, hi := grid[max(k-1, 0)], grid[min(k+1, len(grid)-1)]
+		ts[i] = maximize(func(t float64) float64 {
+			return s * e(t)
+		}, lo, hi)
+	}
+	return ts
+}
+
+// Computes the Chebyshev coefficients of the polynomial q of degree
+// len(ref)-2 and the level E such that g(t) - q(t) = (-1)^i E at each
+// reference point ref[i]. Returns an error if the reference points are too
+// close together for the system to be solved.
+func levelFit(g func(float64) float64, ref []float64) ([]float64, float64, error) {
+	n := len(ref)
+	cols := make([][]float64, n)
+	for j := range cols {
+		cols[j] = make([]float64, n)
+	}
+	y := make([]float64, n)
+	for i, t := range ref {
+		// T_0 = 1, T_1 = t and T_(j+1) = 2t T_j - T_(j-1).
+		prev, cur := 1.0, t
+		for j := 0; j < n-1; j++ {
+			cols[j][i] = prev
+			prev, cur = cur, 2*t*cur-prev
+		}
+		cols[n-1][i] = 1 - 2*float64(i%2)
+		y[i] = g(t)
+	}
+	c, err := lstsq(cols, y)
+	if err != nil {
+		return nil, 0, err
+	}
+	return c[:n-1], c[n-1], nil
+}
+
+// Returns the largest absolute value of g at the points ts.
+func gmax(g func(float64) float64, ts []float64) float64 {
+	var m float64
+	for _, t := range ts {
+		m = math.Max(m, math.Abs(g(t)))
+	}
+	return m
+}
+
+// Finds the point of [lo, hi] at which f is largest, by sampling and then
+// refining around the best sample with golden section search.
+func maximize(f func(float64) float64, lo, hi float64) float64 {
+	const samples = 32
+	best, fbest := lo, f(lo)
+	step := (hi - lo) / samples
+	for k := 1; k <= samples; k++ {
+		x := lo + step*float64(k)
+		if k == samples {
+			x = hi
+		}
+		if fx := f(x); fx > fbest {
+			best, fbest = x, fx
+		}
+	}
+	l, r := math.Max(lo, best-step), math.Min(hi, best+step)
+	const invPhi = 0.6180339887498949
+	x1, x2 := r-invPhi*(r-l), l+invPhi*(r-l)
+	f1, f2 := f(x1), f(x2)
+	for i := 0; i < 100 && r-l > 4*epsilon*math.Max(1, math.Abs(l)); i++ {
+		if f1 < f2 {
+			l, x1, f1 = x1, x2, f2
+			x2 = l + invPhi*(r-l)
+			f2 = f(x2)
+		} else {
+			r, x2, f2 = x2, x1, f1
+			x1 = r - invPhi*(r-l)
+			f1 = f(x1)
+		}
+	}
+	if x := (l + r) / 2; f(x) > fbest {
+		return x
+	}
+	return best
+}
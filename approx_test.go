@@ -0,0 +1,163 @@
+package poly
+
+import (
+	"math"
+	"testing"
+)
+
+// Tests that polynomials are reproduced exactly by approximation.
+func TestApproximatePoly(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		a, b float64
+		deg  int
+	}{
+		{New(3), -1, 1, 0},
+		{New(3), 2, 5, 4},
+		{New(1, 2), -1, 1, 1},
+		{New(1, -2, 3), 0, 1, 2},
+		{New(0.5, 0, -1, 2, 0.25), -3, 2, 4},
+		{New(0.5, 0, -1, 2, 0.25), 1, 2, 6},
+	}
+	for i, c := range cases {
+		if got := Approximate(c.p.Eval, c.a, c.b, c.deg); !comparePoly(got, c.p) {
+			t.Errorf("case %d: Approximate(%q, %.3f, %.3f, %d) == %q, want %q", i, c.p, c.a, c.b, c.deg, got, c.p)
+		}
+	}
+}
+
+// Tests that smooth functions are approximated accurately.
+func TestApproximate(t *testing.T) {
+	cases := []struct {
+		f    func(float64) float64
+		a, b float64
+		deg  int
+		tol  float64
+	}{
+		{math.Exp, 0, 1, 10, 1e-10},
+		{math.Sin, -math.Pi, math.Pi, 15, 1e-6},
+		{math.Log, 1, 2, 12, 1e-9},
+		{math.Sqrt, 1, 4, 8, 1e-5},
+	}
+	for i, c := range cases {
+		p := Approximate(c.f, c.a, c.b, c.deg)
+		if p.Deg() > c.deg {
+			t.Errorf("case %d: Approximate() degree %d, want at most %d", i, p.Deg(), c.deg)
+		}
+		for k := 0; k <= 100; k++ {
+			x := c.a + (c.b-c.a)*float64(k)/100
+			if got, want := p.Eval(x), c.f(x); math.Abs(got-want) > c.tol {
+				t.Errorf("case %d: Approximate().Eval(%f) == %g, want %g", i, x, got, want)
+				break
+			}
+		}
+	}
+}
+
+// Tests that Approximate panics on invalid arguments.
+func TestApproximatePanic(t *testing.T) {
+	cases := []struct {
+		a, b float64
+		deg  int
+	}{
+		{0, 1, -1},
+		{1, 1, 2},
+		{2, 1, 2},
+	}
+	for i, c := range cases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("case %d: Approximate(f, %.3f, %.3f, %d) did not panic", i, c.a, c.b, c.deg)
+				}
+			}()
+			Approximate(math.Exp, c.a, c.b, c.deg)
+		}()
+	}
+}
+
+// Tests that minimax approximations are computed correctly.
+func TestRemez(t *testing.T) {
+	// The best linear approximation to the convex function exp on [0, 1]
+	// touches the error bound at 0, ln(e-1) and 1.
+	s := math.E - 1
+	expErr := (2 - math.E + s*math.Log(s)) / 2
+	cases := []struct {
+		f       func(float64) float64
+		a, b    float64
+		deg     int
+		want    Poly
+		wantErr float64
+	}{
+		{math.Exp, 0, 1, 0, New((1 + math.E) / 2), (math.E - 1) / 2},
+		{math.Exp, 0, 1, 1, New(1-expErr, s), expErr},
+		{math.Abs, -1, 1, 2, New(0.125, 0, 1), 0.125},
+		{math.Abs, -1, 1, 3, New(0.125, 0, 1), 0.125},
+		{New(1, -2, 0.5).Eval, -2, 3, 2, New(1, -2, 0.5), 0},
+		// x^3 on [-1, 1] is best approximated by 3x/4, the error being T_3/4.
+		{New(0, 0, 0, 1).Eval, -1, 1, 2, New(0, 0.75), 0.25},
+	}
+	for i, c := range cases {
+		got, gotErr := Remez(c.f, c.a, c.b, c.deg)
+		// Coefficients beyond the degree of the answer vanish only up to
+		// rounding error.
+		if !got.Sub(c.want).chop(0.00001).isZero() || math.Abs(gotErr-c.wantErr) > 0.00001 {
+			t.Errorf("case %d: Remez(f, %.3f, %.3f, %d) == %q, %f, want %q, %f", i, c.a, c.b, c.deg, got, gotErr, c.want, c.wantErr)
+		}
+	}
+}
+
+// Tests minimax approximation of a function that is not smooth at high
+// degree, where the monomial basis is too ill conditioned to solve for the
+// level.
+func TestRemezHighDegree(t *testing.T) {
+	for _, deg := range []int{20, 30, 40} {
+		p, pErr := Remez(math.Abs, -1, 1, deg)
+		// The minimax error for |x| tends to 0.2801694990/deg, Bernstein's
+		// constant.
+		if want := 0.2801694990 / float64(deg); math.Abs(pErr-want) > 0.02*want {
+			t.Errorf("Remez(abs, -1, 1, %d) error == %g, want %g", deg, pErr, want)
+		}
+		var maxP float64
+		for k := 0; k <= 10000; k++ {
+			x := -1 + 2*float64(k)/10000
+			maxP = math.Max(maxP, math.Abs(math.Abs(x)-p.Eval(x)))
+		}
+		// Evaluating in the monomial basis adds some rounding error.
+		if maxP > 1.05*pErr {
+			t.Errorf("Remez(abs, -1, 1, %d) has error %g, reported %g", deg, maxP, pErr)
+		}
+	}
+}
+
+// Tests that minimax approximations improve on Chebyshev interpolation and
+// that the reported error is the largest error.
+func TestRemezError(t *testing.T) {
+	cases := []struct {
+		f    func(float64) float64
+		a, b float64
+		deg  int
+	}{
+		{math.Exp, -1, 1, 6},
+		{math.Sin, 0, math.Pi / 2, 5},
+		{math.Atan, -1, 1, 9},
+		{math.Log1p, 0, 1, 7},
+		{math.Sqrt, 1, 100, 4},
+	}
+	for i, c := range cases {
+		p, pErr := Remez(c.f, c.a, c.b, c.deg)
+		q := Approximate(c.f, c.a, c.b, c.deg)
+		var maxP, maxQ float64
+		for k := 0; k <= 10000; k++ {
+			x := c.a + (c.b-c.a)*float64(k)/10000
+			maxP = math.Max(maxP, math.Abs(c.f(x)-p.Eval(x)))
+			maxQ = math.Max(maxQ, math.Abs(c.f(x)-q.Eval(x)))
+		}
+		if math.Abs(maxP-pErr) > 1e-6*pErr {
+			t.Errorf("case %d: Remez() error == %g, want %g", i, pErr, maxP)
+		}
+		if maxP > maxQ {
+			t.Errorf("case %d: Remez() error %g exceeds Approximate() error %g", i, maxP, maxQ)
+		}
+	}
+}
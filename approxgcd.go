@@ -0,0 +1,129 @@
+package poly
+
+import "math"
+
+// Computes an approximate greatest common divisor of two polynomials whose
+// coefficients are known only to within a relative tolerance tol.
+// Returns the monic polynomial g of highest degree such that p and q are
+// within about tol of polynomials having g as an exact common factor, with
+// distances measured in the 2-norm relative to the norms of p and q. Unlike
+// GCD, which relies on the Euclidean algorithm, this does not break down
+// when noise turns a common factor into a nearly common one.
+// The degree k of g is the largest for which the subresultant Sylvester
+// matrix [C_{n-k}(p) C_{m-k}(q)], whose columns hold shifted copies of p and
+// q, has a singular value below the tolerance. Its singular vector gives
+// cofactors u and v with p*v ~ q*u, and g is then the least squares solution
+// of u*g ~ p and v*g ~ q. If either polynomial is zero, the result is the
+// other made monic, and if no common factor is found the result is 1.
+func (p Poly) ApproxGCD(q Poly, tol float64) Poly {
+	if p.isZero() {
+		return q.monic()
+	}
+	if q.isZero() {
+		return p.monic()
+	}
+	p, q = p.Scale(1/norm(p.co())), q.Scale(1/norm(q.co()))
+	m, n := p.Deg(), q.Deg()
+	for k := min(m, n); k > 0; k-- {
+		// Columns of p shifted up to n-k places followed by columns of
+		// q shifted up to m-k places, so that the null vector is the
+		// coefficients of v followed by those of -u.
+		rows := m + n - k + 1
+		a := make([][]float64, 0, m+n-2*k+2)
+		a = appendShifted(a, p, n-k, rows)
+		a = appendShifted(a, q, m-k, rows)
+		sigma, vs := jacobiSVD(a)
+		j := 0
+		for i, s := range sigma {
+			if s < sigma[j] {
+				j = i
+			}
+		}
+		if sigma[j] > tol*math.Sqrt(float64(len(sigma))) {
+			continue
+		}
+		v := New(vs[j][:n-k+1]...)
+		u := New(vs[j][n-k+1:]...).Neg()
+		if u.Deg() != m-k || v.Deg() != n-k {
+			continue
+		}
+		// Solve u*g = p and v*g = q for the k+1 coefficients of g in
+		// the least squares sense.
+		b := append(append([]float64(nil), p.co()...), q.co()...)
+		c := make([][]float64, k+1)
+		for i := range c {
+			c[i] = make([]float64, len(b))
+			copy(c[i][i:], u.co())
+			copy(c[i][m+1+i:], v.co())
+		}
+		g, err := lstsq(c, b)
+		if err != nil {
+			continue
+		}
+		return New(g...).monic()
+	}
+	return New(1)
+}
+
+// Appends to a the columns of x^i*p for i from 0 to s, each of length rows.
+func appendShifted(a [][]float64, p Poly, s, rows int) [][]float64 {
+	for i := 0; i <= s; i++ {
+		col := make([]float64, rows)
+		copy(col[i:], p.co())
+		a = append(a, col)
+	}
+	return a
+}
+
+// Computes the singular value decomposition of a matrix with at least as
+// many rows as columns using one-sided Jacobi rotations, where a holds the
+// columns of the matrix and is overwritten.
+// Returns the singular values and, for each, the corresponding right
+// singular vector, in no particular order. The Jacobi method computes even
+// small singular values to high relative accuracy.
+func jacobiSVD(a [][]float64) (sigma []float64, v [][]float64) {
+	n := len(a)
+	v = make([][]float64, n)
+	for i := range v {
+		v[i] = make([]float64, n)
+		v[i][i] = 1
+	}
+	const eps = 1e-15
+	for sweep := 0; sweep < 60; sweep++ {
+		rotated := false
+		for i := 0; i < n-1; i++ {
+			for j := i + 1; j < n; j++ {
+				var alpha, beta, gamma float64
+				for k := range a[i] {
+					alpha += a[i][k] * a[i][k]
+					beta += a[j][k] * a[j][k]
+					gamma += a[i][k] * a[j][k]
+				}
+				if gamma == 0 || math.Abs(gamma) <= eps*math.Sqrt(alpha*beta) {
+					continue
+				}
+				rotated = true
+				// Rotate columns i and j to make them orthogonal.
+				zeta := (beta - alpha) / (2 * gamma)
+				t := math.Copysign(1, zeta) / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				c := 1 / math.Sqrt(1+t*t)
+				s := c * t
+				for _, m := range [][][]float64{a, v} {
+					for k := range m[i] {
+						x, y := m[i][k], m[j][k]
+						m[i][k] = c*x - s*y
+						m[j][k] = s*x + c*y
+					}
+				}
+			}
+		}
+		if !rotated {
+			break
+		}
+	}
+	sigma = make([]float64, n)
+	for i := range a {
+		sigma[i] = norm(a[i])
+	}
+	return sigma, v
+}
@@ -0,0 +1,73 @@
+package poly
+
+import (
+	"math"
+	"testing"
+)
+
+// Tests approximate GCDs of exact and perturbed polynomials.
+func TestApproxGCD(t *testing.T) {
+	cases := []struct {
+		p, q Poly
+		tol  float64
+		want Poly
+	}{
+		{FromRoots(1, 2), FromRoots(1, 3), 1e-9, FromRoots(1)},
+		{FromRoots(1, 2, 3), FromRoots(2, 3, 4, 5), 1e-9, FromRoots(2, 3)},
+		{FromRoots(1, 2), FromRoots(3, 4), 1e-9, New(1)},
+		{FromRoots(1, 2).Scale(5), FromRoots(1, 2).Scale(-3), 1e-9, FromRoots(1, 2)},
+		{Poly{}, New(2, 4), 1e-9, New(0.5, 1)},
+		{New(2, 4), Poly{}, 1e-9, New(0.5, 1)},
+		{New(3), FromRoots(1, 2), 1e-9, New(1)},
+		// Roots perturbed by about 1e-6.
+		{FromRoots(1, 2.000001, -3), FromRoots(0.999999, 4, -2.9999995), 1e-5, FromRoots(1, -3)},
+		{FromRoots(1, 2.000001, -3), FromRoots(0.999999, 4, -2.9999995), 1e-9, New(1)},
+		// Coefficients perturbed by about 1e-8.
+		{New(-6+1e-8, 11, -6, 1), New(2, -3-2e-8, 1), 1e-6, FromRoots(1, 2)},
+	}
+	for i, c := range cases {
+		got := c.p.ApproxGCD(c.q, c.tol)
+		if got.Deg() != c.want.Deg() {
+			t.Errorf("case %d: ApproxGCD(%q, %v) on %q == %q, want %q", i, c.q, c.tol, c.p, got, c.want)
+			continue
+		}
+		for j := 0; j <= got.Deg(); j++ {
+			if math.Abs(got.Coeff(j)-c.want.Coeff(j)) > 1e-5 {
+				t.Errorf("case %d: ApproxGCD(%q, %v) on %q == %q, want %q", i, c.q, c.tol, c.p, got, c.want)
+				break
+			}
+		}
+	}
+}
+
+// Tests that ApproxGCD finds a common factor where GCD does not.
+func TestApproxGCDNoise(t *testing.T) {
+	g := FromRoots(0.5, -1.5, 2.25)
+	p := g.Mul(New(1, 3, -1))
+	q := g.Mul(New(-2, 0, 1, 1))
+	p = p.Add(New(1e-7, -2e-7, 0, 3e-7))
+	q = q.Add(New(0, -1e-7, 2e-7))
+	if got := p.GCD(q); got.Deg() != 0 {
+		t.Errorf("GCD(%q) on %q == %q, want 1", q, p, got)
+	}
+	got := p.ApproxGCD(q, 1e-5)
+	if got.Deg() != g.Deg() || got.Sub(g).maxAbs() > 1e-5 {
+		t.Errorf("ApproxGCD(%q) on %q == %q, want %q", q, p, got, g)
+	}
+}
+
+// Tests that the Jacobi SVD finds the singular values of a small matrix.
+func TestJacobiSVD(t *testing.T) {
+	// Columns of [[3, 2, 2], [2, 3, -2]]^T, with singular values 5 and 3.
+	sigma, v := jacobiSVD([][]float64{{3, 2, 2}, {2, 3, -2}})
+	if sigma[0] < sigma[1] {
+		sigma[0], sigma[1] = sigma[1], sigma[0]
+		v[0], v[1] = v[1], v[0]
+	}
+	if math.Abs(sigma[0]-5) > 1e-12 || math.Abs(sigma[1]-3) > 1e-12 {
+		t.Errorf("jacobiSVD() singular values == %v, want [5 3]", sigma)
+	}
+	if math.Abs(math.Abs(v[0][0])-math.Sqrt(0.5)) > 1e-12 || math.Abs(v[0][0]-v[0][1]) > 1e-12 {
+		t.Errorf("jacobiSVD() first right singular vector == %v, want [0.707 0.707]", v[0])
+	}
+}
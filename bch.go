@@ -0,0 +1,55 @@
+package poly
+
+// Computes the minimal polynomial over GF(2) of a field element a, which is
+// the monic polynomial of least degree with binary coefficients having a as
+// a root. It is the product of (x - c) over the distinct conjugates c = a,
+// a^2, a^4, ... of a, and is irreducible.
+func (gf *GF2m) MinimalPoly(a uint16) GF2Poly {
+	if a == 0 {
+		return NewGF2(1)
+	}
+	p := NewGF2mPoly(gf, 1)
+	c := a
+	for {
+		p = p.Mul(NewGF2mPoly(gf, c, 1))
+		c = gf.Mul(c, c)
+		if c == a {
+			break
+		}
+	}
+	// The coefficients are fixed by squaring, so they are all 0 or 1.
+	var e []int
+	for i, pc := range p.co() {
+		if pc == 1 {
+			e = append(e, i)
+		}
+	}
+	return NewGF2(e...)
+}
+
+// Computes the generator polynomial of a narrow-sense binary BCH code of
+// length 2^m - 1 over the field gf, able to correct t errors. The generator
+// is the least common multiple of the minimal polynomials of alpha, alpha^2,
+// ..., alpha^(2t), where alpha is the primitive element of the field.
+// Panics if t is negative.
+func BCHGenerator(gf *GF2m, t int) GF2Poly {
+	if t < 0 {
+		panic("poly: negative number of errors")
+	}
+	g := NewGF2(0)
+	// Conjugate elements share a minimal polynomial, and distinct minimal
+	// polynomials are coprime, so the least common multiple is the product
+	// of one minimal polynomial for each class of conjugates.
+	seen := make(map[int]bool)
+	for i := 1; i <= 2*t; i++ {
+		e := i % gf.order()
+		if seen[e] {
+			continue
+		}
+		for c := e; !seen[c]; c = 2 * c % gf.order() {
+			seen[c] = true
+		}
+		g = g.Mul(gf.MinimalPoly(gf.Exp(e)))
+	}
+	return g
+}
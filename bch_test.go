@@ -0,0 +1,59 @@
+package poly
+
+import "testing"
+
+// Tests minimal polynomials of elements of GF(2^4).
+func TestMinimalPoly(t *testing.T) {
+	gf, _ := NewGF2m(NewGF2(4, 1, 0))
+	cases := []struct {
+		a    uint16
+		want GF2Poly
+	}{
+		{0, NewGF2(1)},
+		{1, NewGF2(1, 0)},
+		{gf.Exp(1), NewGF2(4, 1, 0)},
+		{gf.Exp(2), NewGF2(4, 1, 0)},
+		{gf.Exp(3), NewGF2(4, 3, 2, 1, 0)},
+		{gf.Exp(5), NewGF2(2, 1, 0)},
+		{gf.Exp(7), NewGF2(4, 3, 0)},
+	}
+	for i, c := range cases {
+		got := gf.MinimalPoly(c.a)
+		if !got.Equal(c.want) {
+			t.Errorf("case %d: MinimalPoly(%d) == %q, want %q", i, c.a, got, c.want)
+		}
+		if !got.IsIrreducible() {
+			t.Errorf("case %d: MinimalPoly(%d) == %q is not irreducible", i, c.a, got)
+		}
+	}
+}
+
+// Tests BCH generator polynomials.
+func TestBCHGenerator(t *testing.T) {
+	gf4, _ := NewGF2m(NewGF2(4, 1, 0))
+	gf5, _ := NewGF2m(NewGF2(5, 2, 0))
+	cases := []struct {
+		gf   *GF2m
+		t    int
+		want GF2Poly
+	}{
+		{gf4, 0, NewGF2(0)},
+		// BCH(15, 11) is the Hamming code.
+		{gf4, 1, NewGF2(4, 1, 0)},
+		{gf4, 2, NewGF2(8, 7, 6, 4, 0)},
+		{gf4, 3, NewGF2(10, 8, 5, 4, 2, 1, 0)},
+		// BCH(31, 21) corrects 2 errors.
+		{gf5, 2, NewGF2(10, 9, 8, 6, 5, 3, 0)},
+	}
+	for i, c := range cases {
+		got := BCHGenerator(c.gf, c.t)
+		if !got.Equal(c.want) {
+			t.Errorf("case %d: BCHGenerator(%d) == %q, want %q", i, c.t, got, c.want)
+		}
+		// The generator divides x^n - 1.
+		n := c.gf.order()
+		if !NewGF2(n, 0).Mod(got).isZero() {
+			t.Errorf("case %d: BCHGenerator(%d) does not divide x^%d - 1", i, c.t, n)
+		}
+	}
+}
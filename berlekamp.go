@@ -0,0 +1,68 @@
+package poly
+
+// Finds the shortest linear feedback shift register generating a sequence
+// over GF(p), using the Berlekamp-Massey algorithm.
+// Returns the connection polynomial c = 1 + c[1]x + ... + c[l]x^l and the
+// linear complexity l, such that s[n] + c[1]s[n-1] + ... + c[l]s[n-l] = 0 for
+// every n from l to len(s)-1. The degree of c may be less than l. The
+// characteristic polynomial of the recurrence is x^l c(1/x).
+// The elements of s are reduced modulo p. Panics if p is not prime.
+func BerlekampMassey(p uint64, s []uint64) (c GFPoly, l int) {
+	c = NewGF(p, 1)
+	b := c
+	// last is the discrepancy when b was last updated, and m is the number of
+	// steps since.
+	last, m := uint64(1), 1
+	for n := range s {
+		d := s[n] % p
+		for i := 1; i <= l; i++ {
+			d = addMod(d, mulMod(c.Coeff(i), s[n-i]%p, p), p)
+		}
+		if d == 0 {
+			m++
+			continue
+		}
+		shift := make([]uint64, m+1)
+		shift[m] = mulMod(d, invMod(last, p), p)
+		t := c
+		c = c.Sub(b.Mul(normalizedGF(p, shift)))
+		if 2*l <= n {
+			l = n + 1 - l
+			b, last, m = t, d, 1
+		} else {
+			m++
+		}
+	}
+	return c, l
+}
+
+// Finds the shortest linear feedback shift register generating a binary
+// sequence, using the Berlekamp-Massey algorithm over GF(2).
+// Each element of s is a bit; only its lowest bit is used.
+// Returns the connection polynomial c = 1 + c[1]x + ... + c[l]x^l and the
+// linear complexity l, such that s[n] = c[1]s[n-1] + ... + c[l]s[n-l] modulo 2
+// for every n from l to len(s)-1.
+func BerlekampMasseyGF2(s []byte) (c GF2Poly, l int) {
+	c = NewGF2(0)
+	b := c
+	m := 1
+	for n := range s {
+		d := s[n] & 1
+		for i := 1; i <= l; i++ {
+			d ^= byte(c.Coeff(i)) & s[n-i]
+		}
+		if d == 0 {
+			m++
+			continue
+		}
+		t := c
+		c = c.Add(b.Mul(NewGF2(m)))
+		if 2*l <= n {
+			l = n + 1 - l
+			b, m = t, 1
+		} else {
+			m++
+		}
+	}
+	return c, l
+}
@@ -0,0 +1,75 @@
+package poly
+
+import "testing"
+
+// Tests the Berlekamp-Massey algorithm over GF(p).
+func TestBerlekampMassey(t *testing.T) {
+	cases := []struct {
+		p    uint64
+		s    []uint64
+		want string
+		l    int
+	}{
+		{7, nil, "1", 0},
+		{7, []uint64{0, 0, 0}, "1", 0},
+		// A nonzero constant satisfies s[n] = s[n-1].
+		{7, []uint64{3, 3, 3, 3}, "6x + 1", 1},
+		// Fibonacci numbers satisfy s[n] = s[n-1] + s[n-2].
+		{101, []uint64{1, 1, 2, 3, 5, 8, 13, 21, 34, 55}, "100x^2 + 100x + 1", 2},
+		// Powers of 3 modulo 11.
+		{11, []uint64{1, 3, 9, 5, 4, 1, 3}, "8x + 1", 1},
+		// A single nonzero value at the end needs a register as long as the
+		// sequence.
+		{5, []uint64{0, 0, 0, 1}, "4x^4 + 1", 4},
+	}
+	for i, c := range cases {
+		got, l := BerlekampMassey(c.p, c.s)
+		if got.String() != c.want || l != c.l {
+			t.Errorf("case %d: BerlekampMassey(%d, %v) == %q, %d, want %q, %d", i, c.p, c.s, got, l, c.want, c.l)
+		}
+	}
+}
+
+// Tests that Berlekamp-Massey recovers the recurrence of a generated
+// sequence over GF(p).
+func TestBerlekampMasseyRecurrence(t *testing.T) {
+	const p = 1000003
+	// s[n] = 5s[n-1] + 7s[n-3] - s[n-4].
+	s := []uint64{1, 2, 3, 4}
+	for n := 4; n < 20; n++ {
+		s = append(s, (5*s[n-1]+7*s[n-3]+p-s[n-4])%p)
+	}
+	c, l := BerlekampMassey(p, s)
+	if want := NewGF(p, 1, p-5, 0, p-7, 1); l != 4 || c.String() != want.String() {
+		t.Errorf("BerlekampMassey() == %q, %d, want %q, 4", c, l, want)
+	}
+}
+
+// Tests the Berlekamp-Massey algorithm over GF(2).
+func TestBerlekampMasseyGF2(t *testing.T) {
+	cases := []struct {
+		s    []byte
+		want GF2Poly
+		l    int
+	}{
+		{nil, NewGF2(0), 0},
+		{[]byte{1, 1, 1, 1}, NewGF2(1, 0), 1},
+		{[]byte{1, 0, 1, 0, 1, 0}, NewGF2(2, 0), 2},
+		// The output of the LFSR with connection polynomial x^4 + x^3 + 1.
+		{[]byte{1, 0, 0, 0, 1, 0, 0, 1, 1, 0, 1, 0, 1, 1, 1, 1}, NewGF2(4, 3, 0), 4},
+	}
+	for i, c := range cases {
+		got, l := BerlekampMasseyGF2(c.s)
+		if !got.Equal(c.want) || l != c.l {
+			t.Errorf("case %d: BerlekampMasseyGF2(%v) == %q, %d, want %q, %d", i, c.s, got, l, c.want, c.l)
+		}
+		// The GF(p) version agrees for p = 2.
+		s := make([]uint64, len(c.s))
+		for j, b := range c.s {
+			s[j] = uint64(b)
+		}
+		if g, l2 := BerlekampMassey(2, s); g.String() != c.want.String() || l2 != l {
+			t.Errorf("case %d: BerlekampMassey(2, %v) == %q, %d, want %q, %d", i, s, g, l2, c.want, l)
+		}
+	}
+}
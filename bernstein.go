@@ -0,0 +1,66 @@
+package poly
+
+// Computes the coefficients of a polynomial in the Bernstein basis of its
+// degree on the interval [a, b].
+// The result c satisfies p(x) = sum of c[k] * C(n,k) * t^k * (1-t)^(n-k) with
+// t = (x-a)/(b-a) and n the degree of p, so c holds the control points of p as
+// a Bézier curve over [a, b]. In particular c[0] is p(a) and c[n] is p(b).
+// Panics if a >= b.
+func (p Poly) ToBernstein(a, b float64) []float64 {
+	if !(a < b) {
+		panic("poly: invalid interval")
+	}
+	q := p.Shift(a).ScaleVar(b - a).co()
+	n := len(q) - 1
+	binom := pascal(n)
+	c := make([]float64, n+1)
+	for k := range c {
+		for i := 0; i <= k; i++ {
+			c[k] += binom[k][i] / binom[n][i] * q[i]
+		}
+	}
+	return c
+}
+
+// Creates a polynomial from its coefficients in the Bernstein basis on the
+// interval [a, b], the inverse of ToBernstein.
+// The degree of the Bernstein basis is len(c)-1, and the result may have
+// lower degree. No coefficients creates the zero polynomial.
+// Panics if a >= b.
+func FromBernstein(c []float64, a, b float64) Poly {
+	if !(a < b) {
+		panic("poly: invalid interval")
+	}
+	if len(c) == 0 {
+		return Poly{}
+	}
+	n := len(c) - 1
+	binom := pascal(n)
+	q := make([]float64, n+1)
+	for i := range q {
+		var s float64
+		for k := 0; k <= i; k++ {
+			t := binom[i][k] * c[k]
+			if (i-k)%2 == 1 {
+				t = -t
+			}
+			s += t
+		}
+		q[i] = binom[n][i] * s
+	}
+	return normalized(q).Shift(-a / (b - a)).ScaleVar(1 / (b - a))
+}
+
+// Returns the rows 0 through n of Pascal's triangle, so that the result r
+// has r[i][k] equal to the binomial coefficient C(i,k).
+func pascal(n int) [][]float64 {
+	r := make([][]float64, n+1)
+	for i := range r {
+		r[i] = make([]float64, i+1)
+		r[i][0], r[i][i] = 1, 1
+		for k := 1; k < i; k++ {
+			r[i][k] = r[i-1][k-1] + r[i-1][k]
+		}
+	}
+	return r
+}
@@ -0,0 +1,76 @@
+package poly
+
+import (
+	"math"
+	"testing"
+)
+
+// Tests that Bernstein coefficients are computed correctly.
+func TestToBernstein(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		a, b float64
+		want []float64
+	}{
+		{Poly{}, 0, 1, []float64{0}},
+		{New(3), 0, 1, []float64{3}},
+		{New(0, 1), 0, 1, []float64{0, 1}},
+		{New(0, 0, 1), 0, 1, []float64{0, 0, 1}},
+		{New(1, -2, 1), 0, 1, []float64{1, 0, 0}},
+		{New(0, 2, -2), 0, 1, []float64{0, 1, 0}},
+		{New(0, 0, 0, 1), 0, 1, []float64{0, 0, 0, 1}},
+		{New(1, 1, 1, 1), 0, 1, []float64{1, 4.0 / 3, 2, 4}},
+		{New(0, 1), 1, 3, []float64{1, 3}},
+		{New(0, 0, 1), -1, 1, []float64{1, -1, 1}},
+	}
+	for i, c := range cases {
+		got := c.p.ToBernstein(c.a, c.b)
+		ok := len(got) == len(c.want)
+		for k := 0; ok && k < len(got); k++ {
+			ok = math.Abs(got[k]-c.want[k]) <= 0.00001
+		}
+		if !ok {
+			t.Errorf("case %d: ToBernstein(%.3f, %.3f) on %q == %v, want %v", i, c.a, c.b, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests that polynomials are created from Bernstein coefficients.
+func TestFromBernstein(t *testing.T) {
+	cases := []struct {
+		c    []float64
+		a, b float64
+		want Poly
+	}{
+		{nil, 0, 1, Poly{}},
+		{[]float64{3}, 0, 1, New(3)},
+		{[]float64{2, 2, 2}, 0, 1, New(2)},
+		{[]float64{1, 0, 0}, 0, 1, New(1, -2, 1)},
+		{[]float64{0, 1, 0}, 0, 1, New(0, 2, -2)},
+		{[]float64{1, 4.0 / 3, 2, 4}, 0, 1, New(1, 1, 1, 1)},
+		{[]float64{1, 3}, 1, 3, New(0, 1)},
+		{[]float64{1, -1, 1}, -1, 1, New(0, 0, 1)},
+	}
+	for i, c := range cases {
+		if got := FromBernstein(c.c, c.a, c.b); !comparePoly(got, c.want) {
+			t.Errorf("case %d: FromBernstein(%v, %.3f, %.3f) == %q, want %q", i, c.c, c.a, c.b, got, c.want)
+		}
+	}
+}
+
+// Tests that converting to Bernstein coefficients and back is the identity.
+func TestBernsteinRoundTrip(t *testing.T) {
+	p := New(0.5, -3, 2, 0.25, -1, 4)
+	for _, iv := range []Interval{{0, 1}, {-2, 3}, {1, 1.5}} {
+		c := p.ToBernstein(iv.Lo, iv.Hi)
+		if got, want := c[0], p.Eval(iv.Lo); math.Abs(got-want) > 0.00001 {
+			t.Errorf("ToBernstein(%.3f, %.3f)[0] == %f, want %f", iv.Lo, iv.Hi, got, want)
+		}
+		if got, want := c[len(c)-1], p.Eval(iv.Hi); math.Abs(got-want) > 0.00001 {
+			t.Errorf("ToBernstein(%.3f, %.3f)[%d] == %f, want %f", iv.Lo, iv.Hi, len(c)-1, got, want)
+		}
+		if got := FromBernstein(c, iv.Lo, iv.Hi); !comparePoly(got, p) {
+			t.Errorf("FromBernstein(ToBernstein(%.3f, %.3f)) == %q, want %q", iv.Lo, iv.Hi, got, p)
+		}
+	}
+}
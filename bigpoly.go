@@ -0,0 +1,310 @@
+package poly
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// BigPoly represents a polynomial with arbitrary precision floating point
+// coefficients. It is intended for ill-conditioned computations, such as
+// evaluating high degree polynomials or refining clustered roots, where the
+// rounding error of float64 arithmetic dominates the result.
+// Every coefficient has the same precision, in bits. The result of an
+// operation on two BigPolys has the larger of their precisions.
+// BigPoly values are immutable; no method modifies its receiver or arguments.
+// A zero valued BigPoly is equivalent to 0 with precision 53.
+type BigPoly struct {
+	prec  uint
+	coeff []*big.Float
+}
+
+// Creates a new BigPoly with the given precision in bits.
+// The ith coefficient represents the coefficient of x^i. The coefficients are
+// rounded to prec bits, and nil is treated as zero.
+// A prec of 0 is treated as 53, the precision of a float64.
+func NewBig(prec uint, c ...*big.Float) BigPoly {
+	if prec == 0 {
+		prec = 53
+	}
+	a := make([]*big.Float, len(c))
+	for i, ci := range c {
+		a[i] = new(big.Float).SetPrec(prec)
+		if ci != nil {
+			a[i].Set(ci)
+		}
+	}
+	return normalizedBig(prec, a)
+}
+
+// Converts a polynomial to one with arbitrary precision coefficients.
+// Each coefficient is converted exactly, provided prec is at least 53, and
+// infinite coefficients remain infinite. Returns an error if any coefficient
+// is NaN, which a big.Float cannot represent.
+func (p Poly) Big(prec uint) (BigPoly, error) {
+	pco := p.co()
+	c := make([]*big.Float, len(pco))
+	for i, pc := range pco {
+		if math.IsNaN(pc) {
+			return BigPoly{}, fmt.Errorf("poly: coefficient %v of x^%d is NaN", pc, i)
+		}
+		c[i] = big.NewFloat(pc)
+	}
+	return NewBig(prec, c...), nil
+}
+
+// Converts a polynomial to one with float64 coefficients, rounding each
+// coefficient to the nearest float64.
+func (p BigPoly) Poly() Poly {
+	pco := p.co()
+	c := make([]float64, len(pco))
+	for i, pc := range pco {
+		c[i], _ = pc.Float64()
+	}
+	return normalized(c)
+}
+
+// Returns the precision of the coefficients in bits.
+func (p BigPoly) Prec() uint {
+	if p.prec == 0 {
+		return 53
+	}
+	return p.prec
+}
+
+// Returns the coefficient array, which is {0} for the zero value.
+// The elements must not be modified.
+func (p BigPoly) co() []*big.Float {
+	if len(p.coeff) == 0 {
+		return []*big.Float{new(big.Float).SetPrec(p.Prec())}
+	}
+	return p.coeff
+}
+
+// Returns a polynomial with the given coefficients, removing zero
+// coefficients of terms with degree greater than 0.
+func normalizedBig(prec uint, c []*big.Float) BigPoly {
+	i := len(c) - 1
+	for i > 0 && c[i].Sign() == 0 {
+		i--
+	}
+	if i < 0 {
+		return BigPoly{prec: prec}
+	}
+	return BigPoly{prec, c[0 : i+1]}
+}
+
+// Returns a new zero with the given precision.
+func bigZero(prec uint) *big.Float {
+	return new(big.Float).SetPrec(prec)
+}
+
+// Returns the highest degree of the polynomial's highest order term.
+func (p BigPoly) Deg() int {
+	return len(p.co()) - 1
+}
+
+// Returns a copy of the coefficient of the ith order term.
+func (p BigPoly) Coeff(i int) *big.Float {
+	if i < 0 || i > p.Deg() {
+		return bigZero(p.Prec())
+	}
+	return bigZero(p.Prec()).Set(p.co()[i])
+}
+
+// Evaluates a polynomial at the given point x using Horner's method.
+// The result has the precision of p.
+func (p BigPoly) Eval(x *big.Float) *big.Float {
+	pco := p.co()
+	n := bigZero(p.Prec())
+	for i := len(pco) - 1; i >= 0; i-- {
+		n.Mul(n, x)
+		n.Add(n, pco[i])
+	}
+	return n
+}
+
+// Adds a polynomial to another polynomial.
+// Returns p+q.
+func (p BigPoly) Add(q BigPoly) BigPoly {
+	prec := max(p.Prec(), q.Prec())
+	pco, qco := p.co(), q.co()
+	if len(pco) < len(qco) {
+		pco, qco = qco, pco
+	}
+	c := make([]*big.Float, len(pco))
+	for i, pc := range pco {
+		c[i] = bigZero(prec).Set(pc)
+		if i < len(qco) {
+			c[i].Add(c[i], qco[i])
+		}
+	}
+	return normalizedBig(prec, c)
+}
+
+// Subtracts a polynomial from another polynomial.
+// Returns p-q.
+func (p BigPoly) Sub(q BigPoly) BigPoly {
+	return p.Add(q.Neg())
+}
+
+// Multiplies a polynomial by a scalar.
+// Returns k*p.
+func (p BigPoly) Scale(k *big.Float) BigPoly {
+	pco := p.co()
+	c := make([]*big.Float, len(pco))
+	for i, pc := range pco {
+		c[i] = bigZero(p.Prec()).Mul(k, pc)
+	}
+	return normalizedBig(p.Prec(), c)
+}
+
+// Negates a polynomial.
+// Returns -p.
+func (p BigPoly) Neg() BigPoly {
+	pco := p.co()
+	c := make([]*big.Float, len(pco))
+	for i, pc := range pco {
+		c[i] = bigZero(p.Prec()).Neg(pc)
+	}
+	return normalizedBig(p.Prec(), c)
+}
+
+// Multiplies a polynomial by another polynomial.
+// Returns p*q.
+func (p BigPoly) Mul(q BigPoly) BigPoly {
+	prec := max(p.Prec(), q.Prec())
+	pco, qco := p.co(), q.co()
+	c := make([]*big.Float, len(pco)+len(qco)-1)
+	for i := range c {
+		c[i] = bigZero(prec)
+	}
+	t := bigZero(prec)
+	for i, pc := range pco {
+		for j, qc := range qco {
+			c[i+j].Add(c[i+j], t.Mul(pc, qc))
+		}
+	}
+	return normalizedBig(prec, c)
+}
+
+// Divides a polynomial by another polynomial using Euclidean long division.
+// Returns the quotient and remainder of p/q, such that p = quo*q + rem and the
+// degree of rem is less than the degree of q.
+// If q is zero, the quotient is zero and the remainder is p. In every case
+// both have the larger of the precisions of p and q.
+func (p BigPoly) DivMod(q BigPoly) (quo, rem BigPoly) {
+	prec := max(p.Prec(), q.Prec())
+	qco := q.co()
+	d := len(qco) - 1
+	lead := qco[d]
+	pco := p.co()
+	r := make([]*big.Float, len(pco))
+	for i, pc := range pco {
+		r[i] = bigZero(prec).Set(pc)
+	}
+	if lead.Sign() == 0 || len(pco) <= d {
+		return BigPoly{prec: prec}, normalizedBig(prec, r)
+	}
+	c := make([]*big.Float, len(pco)-d)
+	t := bigZero(prec)
+	for i := len(r) - 1; i >= d; i-- {
+		k := bigZero(prec).Quo(r[i], lead)
+		c[i-d] = k
+		for j := 0; j < d; j++ {
+			r[i-d+j].Sub(r[i-d+j], t.Mul(k, qco[j]))
+		}
+	}
+	return normalizedBig(prec, c), normalizedBig(prec, r[:d])
+}
+
+// Divides a polynomial by another polynomial.
+// Returns the quotient of p/q, discarding any remainder.
+func (p BigPoly) Div(q BigPoly) BigPoly {
+	quo, _ := p.DivMod(q)
+	return quo
+}
+
+// Computes the remainder of dividing a polynomial by another polynomial.
+// Returns p mod q.
+func (p BigPoly) Mod(q BigPoly) BigPoly {
+	_, rem := p.DivMod(q)
+	return rem
+}
+
+// Computes the derivative of a polynomial.
+func (p BigPoly) Der() BigPoly {
+	pco := p.co()
+	c := make([]*big.Float, len(pco)-1)
+	for i := range c {
+		c[i] = bigZero(p.Prec()).Mul(pco[i+1], big.NewFloat(float64(i+1)))
+	}
+	return normalizedBig(p.Prec(), c)
+}
+
+// Computes the definite integral of a polynomial.
+// The provided constant k will be used as the 0th order term of the result.
+func (p BigPoly) Int(k *big.Float) BigPoly {
+	pco := p.co()
+	c := make([]*big.Float, len(pco)+1)
+	c[0] = bigZero(p.Prec()).Set(k)
+	for i, pc := range pco {
+		c[i+1] = bigZero(p.Prec()).Quo(pc, big.NewFloat(float64(i+1)))
+	}
+	return normalizedBig(p.Prec(), c)
+}
+
+// Refines an approximate real root x of the polynomial using Newton's method
+// at the precision of p. Iteration stops when the step no longer changes x
+// or after an iteration limit, so the result is only as good as the
+// starting point allows. For a root of multiplicity m, convergence is linear
+// rather than quadratic.
+func (p BigPoly) Newton(x *big.Float) *big.Float {
+	dp := p.Der()
+	z := bigZero(p.Prec()).Set(x)
+	step := bigZero(p.Prec())
+	for i := 0; i < int(p.Prec())+100; i++ {
+		d := dp.Eval(z)
+		if d.Sign() == 0 {
+			break
+		}
+		step.Quo(p.Eval(z), d)
+		next := bigZero(p.Prec()).Sub(z, step)
+		if next.Cmp(z) == 0 {
+			break
+		}
+		z = next
+	}
+	return z
+}
+
+// Returns a printable string representing the polynomial, with each
+// coefficient formatted with the shortest decimal representation that
+// uniquely identifies it at the polynomial's precision.
+func (p BigPoly) String() string {
+	var b strings.Builder
+	pco := p.co()
+	for i := len(pco) - 1; i >= 0; i-- {
+		if pco[i].Sign() == 0 && (i > 0 || b.Len() > 0) {
+			continue
+		}
+		s := pco[i].Text('g', -1)
+		switch {
+		case b.Len() == 0:
+		case strings.HasPrefix(s, "-"):
+			b.WriteString(" - ")
+			s = s[1:]
+		default:
+			b.WriteString(" + ")
+		}
+		b.WriteString(s)
+		switch {
+		case i == 1:
+			b.WriteString("x")
+		case i > 1:
+			fmt.Fprintf(&b, "x^%d", i)
+		}
+	}
+	return b.String()
+}
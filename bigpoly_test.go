@@ -0,0 +1,108 @@
+package poly
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// Converts a polynomial with finite coefficients to a BigPoly.
+func mustBig(p Poly, prec uint) BigPoly {
+	b, err := p.Big(prec)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Tests conversion of polynomials with non-finite coefficients.
+func TestPolyBig(t *testing.T) {
+	if got, err := New(1, math.NaN()).Big(100); err == nil {
+		t.Errorf("Big() with NaN coefficient == %q, want error", got)
+	}
+	got, err := New(1, math.Inf(-1)).Big(100)
+	if err != nil {
+		t.Fatalf("Big() with infinite coefficient returned error %v", err)
+	}
+	if c := got.Poly().Coeff(1); !math.IsInf(c, -1) {
+		t.Errorf("Big() with infinite coefficient has coefficient %v, want -Inf", c)
+	}
+}
+
+// Tests arithmetic on arbitrary precision polynomials.
+func TestBigPoly(t *testing.T) {
+	p := mustBig(New(1, -2, 3), 100)
+	q := mustBig(New(0.5, 1), 200)
+	cases := []struct {
+		name string
+		got  BigPoly
+		want Poly
+		prec uint
+	}{
+		{"Add", p.Add(q), New(1.5, -1, 3), 200},
+		{"Sub", p.Sub(p), Poly{}, 100},
+		{"Scale", p.Scale(big.NewFloat(2)), New(2, -4, 6), 100},
+		{"Mul", p.Mul(q), New(0.5, 0, -0.5, 3), 200},
+		{"Div", p.Mul(q).Div(q), New(1, -2, 3), 200},
+		{"Mod", p.Mod(q), New(2.75), 200},
+		{"Mod low degree", p.Mod(q.Mul(q).Mul(q)), New(1, -2, 3), 200},
+		{"Div low degree", p.Div(q.Mul(q).Mul(q)), Poly{}, 200},
+		{"Mod zero", p.Mod(BigPoly{}), New(1, -2, 3), 100},
+		{"Der", p.Der(), New(-2, 6), 100},
+		{"Int", p.Int(big.NewFloat(4)), New(4, 1, -1, 1), 100},
+		{"zero", BigPoly{}.Add(BigPoly{}), Poly{}, 53},
+	}
+	for _, c := range cases {
+		if got := c.got.Poly(); !equalCoeffs(got, c.want) {
+			t.Errorf("%s == %q, want %q", c.name, c.got, c.want)
+		}
+		if got := c.got.Prec(); got != c.prec {
+			t.Errorf("%s has precision %d, want %d", c.name, got, c.prec)
+		}
+	}
+	if got, want := p.String(), "3x^2 - 2x + 1"; got != want {
+		t.Errorf("String() == %q, want %q", got, want)
+	}
+}
+
+// Tests that evaluation near a root of high multiplicity is accurate at high
+// precision, where float64 evaluation is dominated by cancellation.
+func TestBigPolyEval(t *testing.T) {
+	// (x - 1)^20 at x = 1.01 is 1e-40.
+	p := Binomial(-1, 20)
+	x := 1.01
+	if got := p.Eval(x); math.Abs(got-1e-40) < 1e-41 {
+		t.Errorf("float64 Eval(%v) == %v, expected cancellation error", x, got)
+	}
+	want := new(big.Float).SetPrec(256).SetFloat64(x)
+	want.Sub(want, big.NewFloat(1))
+	pow := new(big.Float).SetPrec(256).SetFloat64(1)
+	for i := 0; i < 20; i++ {
+		pow.Mul(pow, want)
+	}
+	got := mustBig(p, 256).Eval(big.NewFloat(x))
+	got.Sub(got, pow)
+	if rel, _ := got.Quo(got, pow).Float64(); math.Abs(rel) > 1e-20 {
+		t.Errorf("Eval(%v) has relative error %v", x, rel)
+	}
+}
+
+// Tests that Newton's method refines roots at high precision.
+func TestBigPolyNewton(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		x    float64
+		want string
+	}{
+		// x^2 - 2 has the root sqrt(2).
+		{New(-2, 0, 1), 1.5, "1.41421356237309504880168872420969807856967187537695"},
+		// Roots 1 and 1 + 1e-8 are clustered.
+		{FromRoots(1, 1.00000001), 1.00000002, "1.00000001"},
+	}
+	for i, c := range cases {
+		x := mustBig(c.p, 200).Newton(big.NewFloat(c.x))
+		if got := x.Text('f', len(c.want)-2); got != c.want {
+			t.Errorf("case %d: Newton(%v) on %q == %s, want %s", i, c.x, c.p, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package poly
+
+// Builder constructs a polynomial term by term in a mutable buffer, avoiding
+// the new Poly that each call to Add or AddScalar would allocate.
+// The zero value is an empty builder representing 0.
+type Builder struct {
+	coeff []float64
+}
+
+// Creates a new Builder with room for terms up to degree n without
+// reallocating.
+func NewBuilder(n int) *Builder {
+	return &Builder{make([]float64, 0, max(n+1, 0))}
+}
+
+// Makes sure the buffer has a coefficient for x^n.
+func (b *Builder) extend(n int) {
+	if n < 0 {
+		panic("poly: negative exponent")
+	}
+	if n < len(b.coeff) {
+		return
+	}
+	if n < cap(b.coeff) {
+		// The reused capacity may hold terms from before a Reset.
+		old := len(b.coeff)
+		b.coeff = b.coeff[:n+1]
+		clear(b.coeff[old:])
+		return
+	}
+	b.coeff = append(b.coeff, make([]float64, n+1-len(b.coeff))...)
+}
+
+// Returns the coefficient of x^n built so far.
+func (b *Builder) Coeff(n int) float64 {
+	if n < 0 || n >= len(b.coeff) {
+		return 0
+	}
+	return b.coeff[n]
+}
+
+// Sets the coefficient of x^n to c. Panics if n is negative.
+func (b *Builder) SetCoeff(n int, c float64) {
+	b.extend(n)
+	b.coeff[n] = c
+}
+
+// Adds the term c*x^n. Panics if n is negative.
+func (b *Builder) AddTerm(c float64, n int) {
+	b.extend(n)
+	b.coeff[n] += c
+}
+
+// Adds a polynomial to the one being built.
+func (b *Builder) Add(p Poly) {
+	pco := p.co()
+	b.extend(len(pco) - 1)
+	for i, pc := range pco {
+		b.coeff[i] += pc
+	}
+}
+
+// Clears the builder back to 0, keeping its buffer for reuse.
+func (b *Builder) Reset() {
+	b.coeff = b.coeff[:0]
+}
+
+// Returns the polynomial built so far.
+// The coefficients are copied, so the builder may continue to be used
+// without affecting the result.
+func (b *Builder) Build() Poly {
+	return New(b.coeff...)
+}
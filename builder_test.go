@@ -0,0 +1,70 @@
+package poly
+
+import "testing"
+
+// Tests building polynomials term by term.
+func TestBuilder(t *testing.T) {
+	var b Builder
+	if got := b.Build(); !equalCoeffs(got, Poly{}) {
+		t.Errorf("empty Build() == %q, want 0", got)
+	}
+	b.AddTerm(3, 2)
+	b.AddTerm(1, 0)
+	b.AddTerm(2, 2)
+	b.SetCoeff(1, -4)
+	if got, want := b.Build(), New(1, -4, 5); !equalCoeffs(got, want) {
+		t.Errorf("Build() == %q, want %q", got, want)
+	}
+	if got := b.Coeff(2); got != 5 {
+		t.Errorf("Coeff(2) == %v, want 5", got)
+	}
+	if got := b.Coeff(7); got != 0 {
+		t.Errorf("Coeff(7) == %v, want 0", got)
+	}
+	p := b.Build()
+	b.Add(New(0, 4, -5, 0, 1))
+	if got, want := b.Build(), New(1, 0, 0, 0, 1); !equalCoeffs(got, want) {
+		t.Errorf("Build() after Add == %q, want %q", got, want)
+	}
+	if want := New(1, -4, 5); !equalCoeffs(p, want) {
+		t.Errorf("earlier Build() changed to %q, want %q", p, want)
+	}
+	// Cancelled leading terms are removed.
+	b.SetCoeff(4, 0)
+	if got, want := b.Build(), New(1); !equalCoeffs(got, want) {
+		t.Errorf("Build() after cancelling == %q, want %q", got, want)
+	}
+	b.Reset()
+	b.AddTerm(2, 1)
+	if got, want := b.Build(), New(0, 2); !equalCoeffs(got, want) {
+		t.Errorf("Build() after Reset == %q, want %q", got, want)
+	}
+}
+
+// Tests that a presized builder does not allocate until Build.
+func TestBuilderAllocs(t *testing.T) {
+	b := NewBuilder(100)
+	allocs := testing.AllocsPerRun(100, func() {
+		b.Reset()
+		for i := 0; i <= 100; i++ {
+			b.AddTerm(float64(i), i)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("building made %v allocations, want 0", allocs)
+	}
+	if got := b.Build().Deg(); got != 100 {
+		t.Errorf("Build().Deg() == %d, want 100", got)
+	}
+}
+
+// Tests that negative exponents panic.
+func TestBuilderNegative(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("AddTerm(1, -1) did not panic")
+		}
+	}()
+	var b Builder
+	b.AddTerm(1, -1)
+}
@@ -0,0 +1,26 @@
+//go:build gonum
+
+package poly
+
+import "gonum.org/v1/gonum/mat"
+
+// Computes the characteristic polynomial det(xI - A) of a square gonum
+// matrix, as for CharPoly.
+// Panics if the matrix is not square.
+//
+// This function is only available when building with the gonum tag, so that
+// the package has no dependencies by default.
+func CharPolyMat(a mat.Matrix) Poly {
+	r, c := a.Dims()
+	if r != c {
+		panic("poly: characteristic polynomial of non-square matrix")
+	}
+	rows := make([][]float64, r)
+	for i := range rows {
+		rows[i] = make([]float64, c)
+		for j := range rows[i] {
+			rows[i][j] = a.At(i, j)
+		}
+	}
+	return CharPoly(rows)
+}
@@ -0,0 +1,21 @@
+//go:build gonum
+
+package poly
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Tests the characteristic polynomial of a gonum matrix.
+func TestCharPolyMat(t *testing.T) {
+	a := mat.NewDense(2, 2, []float64{0, 1, -2, -3})
+	if got, want := CharPolyMat(a), New(2, 3, 1); !comparePoly(got, want) {
+		t.Errorf("CharPolyMat() == %q, want %q", got, want)
+	}
+	// The transpose view is accepted as any mat.Matrix.
+	if got, want := CharPolyMat(a.T()), New(2, 3, 1); !comparePoly(got, want) {
+		t.Errorf("CharPolyMat(T) == %q, want %q", got, want)
+	}
+}
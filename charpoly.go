@@ -0,0 +1,97 @@
+package poly
+
+import (
+	"fmt"
+	"math"
+)
+
+// Computes the characteristic polynomial det(xI - A) of a square matrix,
+// given as a slice of rows.
+// The matrix is first reduced to upper Hessenberg form by an orthogonal
+// similarity transformation using Householder reflections, which preserves
+// the characteristic polynomial and is numerically stable, and the
+// polynomial is then built from the Hessenberg form by a recurrence on its
+// leading principal submatrices. This is more accurate than the
+// Faddeev-LeVerrier algorithm, whose error grows rapidly with the dimension.
+// The result is monic of degree n. Panics if the matrix is not square.
+func CharPoly(a [][]float64) Poly {
+	n := len(a)
+	h := make([][]float64, n)
+	for i, r := range a {
+		if len(r) != n {
+			panic(fmt.Sprintf("poly: characteristic polynomial of non-square matrix with row of length %d", len(r)))
+		}
+		h[i] = append([]float64(nil), r...)
+	}
+	hessenberg(h)
+	// p[k] is the characteristic polynomial of the leading k by k
+	// submatrix of h.
+	p := make([]Poly, n+1)
+	p[0] = New(1)
+	for k := 1; k <= n; k++ {
+		p[k] = p[k-1].Mul(New(-h[k-1][k-1], 1))
+		// prod is the product of the subdiagonal entries h[i][i-1] for i
+		// from j+1 to k-1.
+		prod := 1.0
+		for j := k - 2; j >= 0; j-- {
+			prod *= h[j+1][j]
+			if prod == 0 {
+				break
+			}
+			p[k] = p[k].Sub(p[j].Scale(prod * h[j][k-1]))
+		}
+	}
+	return p[n]
+}
+
+// Reduces a square matrix in place to upper Hessenberg form, with zeros
+// below the first subdiagonal, by a similarity transformation with
+// Householder reflections.
+func hessenberg(h [][]float64) {
+	n := len(h)
+	v := make([]float64, n)
+	for k := 0; k < n-2; k++ {
+		// Build the reflection I - 2vv^T/(v^T v) that zeros h[k+2:][k].
+		var s float64
+		for i := k + 1; i < n; i++ {
+			s += h[i][k] * h[i][k]
+		}
+		if s == 0 {
+			continue
+		}
+		alpha := -math.Copysign(math.Sqrt(s), h[k+1][k])
+		for i := range v {
+			v[i] = 0
+		}
+		for i := k + 1; i < n; i++ {
+			v[i] = h[i][k]
+		}
+		v[k+1] -= alpha
+		var vv float64
+		for i := k + 1; i < n; i++ {
+			vv += v[i] * v[i]
+		}
+		// Apply from the left: h = (I - 2vv^T/vv) h.
+		for j := 0; j < n; j++ {
+			var d float64
+			for i := k + 1; i < n; i++ {
+				d += v[i] * h[i][j]
+			}
+			d *= 2 / vv
+			for i := k + 1; i < n; i++ {
+				h[i][j] -= d * v[i]
+			}
+		}
+		// Apply from the right: h = h (I - 2vv^T/vv).
+		for i := 0; i < n; i++ {
+			var d float64
+			for j := k + 1; j < n; j++ {
+				d += h[i][j] * v[j]
+			}
+			d *= 2 / vv
+			for j := k + 1; j < n; j++ {
+				h[i][j] -= d * v[j]
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package poly
+
+import (
+	"math"
+	"testing"
+)
+
+// Tests characteristic polynomials of numeric matrices.
+func TestCharPoly(t *testing.T) {
+	cases := []struct {
+		a    [][]float64
+		want Poly
+	}{
+		{nil, New(1)},
+		{[][]float64{{5}}, New(-5, 1)},
+		{[][]float64{{0, 1}, {-2, -3}}, New(2, 3, 1)},
+		// A rotation has eigenvalues +-i.
+		{[][]float64{{0, -1}, {1, 0}}, New(1, 0, 1)},
+		{[][]float64{{2, 0, 0}, {0, 3, 4}, {0, 4, 9}}, New(-22, 35, -14, 1)},
+		// An already triangular matrix.
+		{[][]float64{{1, 2, 3}, {0, 4, 5}, {0, 0, 6}}, FromRoots(1, 4, 6)},
+		{[][]float64{{1, 2, 3, 4}, {5, 6, 7, 8}, {2, 6, 4, 8}, {3, 1, 1, 2}}, New(72, -12, -30, -13, 1)},
+	}
+	for i, c := range cases {
+		if got := CharPoly(c.a); !comparePoly(got, c.want) {
+			t.Errorf("case %d: CharPoly(%v) == %q, want %q", i, c.a, got, c.want)
+		}
+	}
+}
+
+// Tests that CharPoly agrees with the determinant of xI - A, and that the
+// roots of the characteristic polynomial of a companion matrix are those of
+// the polynomial.
+func TestCharPolyDet(t *testing.T) {
+	a := [][]float64{
+		{4, -1, 2, 0.5, 3},
+		{1, 0, -2, 1, 1},
+		{0.5, 3, 1, -1, 2},
+		{2, 2, 0, 5, -3},
+		{-1, 1, 4, 2, 0},
+	}
+	det := IdentityMatrix(5).Scale(New(0, 1)).Sub(ConstMatrix(a)).Det()
+	if got := CharPoly(a); !got.Sub(det).chop(0.00001).isZero() {
+		t.Errorf("CharPoly() == %q, want %q", got, det)
+	}
+	p := FromRoots(-3, -1, 0.5, 2, 7, 10)
+	n := p.Deg()
+	c := make([][]float64, n)
+	for i := range c {
+		c[i] = make([]float64, n)
+		if i > 0 {
+			c[i][i-1] = 1
+		}
+		c[i][n-1] = -p.Coeff(i)
+	}
+	got := CharPoly(c)
+	for i := 0; i <= n; i++ {
+		if math.Abs(got.Coeff(i)-p.Coeff(i)) > 1e-9*math.Max(1, math.Abs(p.Coeff(i))) {
+			t.Errorf("CharPoly() of companion matrix == %q, want %q", got, p)
+			break
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package poly
+
+// Computes the coefficients of a polynomial in the Chebyshev basis on the
+// interval [a, b].
+// The result c satisfies p(x) = sum of c[k] * T_k(t) with
+// t = (2x - a - b)/(b - a), which maps [a, b] onto [-1, 1], and has one
+// coefficient per coefficient of p. Unlike monomial coefficients, the size of
+// the Chebyshev coefficients reflects the size of p on [a, b], so they are
+// well suited to truncation and to high degree work.
+// Panics if a >= b.
+func (p Poly) ToChebyshev(a, b float64) []float64 {
+	if !(a < b) {
+		panic("poly: invalid interval")
+	}
+	q := p.Shift((a + b) / 2).ScaleVar((b - a) / 2).co()
+	n := len(q) - 1
+	// Horner's method in the Chebyshev basis, using x T_0 = T_1 and
+	// x T_j = (T_(j+1) + T_(j-1))/2.
+	c := make([]float64, n+1)
+	tmp := make([]float64, n+1)
+	c[0] = q[n]
+	for k := n - 1; k >= 0; k-- {
+		m := n - k
+		for j := range tmp[:m+1] {
+			tmp[j] = 0
+		}
+		tmp[1] += c[0]
+		for j := 1; j < m; j++ {
+			tmp[j+1] += c[j] / 2
+			tmp[j-1] += c[j] / 2
+		}
+		tmp[0] += q[k]
+		c, tmp = tmp, c
+	}
+	return c
+}
+
+// Creates a polynomial from its coefficients in the Chebyshev basis on the
+// interval [a, b], the inverse of ToChebyshev.
+// The result may have lower degree than len(c)-1. No coefficients creates the
+// zero polynomial.
+// Panics if a >= b.
+func FromChebyshev(c []float64, a, b float64) Poly {
+	if !(a < b) {
+		panic("poly: invalid interval")
+	}
+	if len(c) == 0 {
+		return Poly{}
+	}
+	return fromChebyshev(c).Shift(-(a + b) / (b - a)).ScaleVar(2 / (b - a))
+}
+
+// Converts the coefficients of a nonempty Chebyshev series, sum of
+// c[k] T_k(x), to a polynomial.
+func fromChebyshev(c []float64) Poly {
+	// Clenshaw's recurrence b_k = c_k + 2x b_(k+1) - b_(k+2), with the sum
+	// given by c_0 + x b_1 - b_2.
+	var b1, b2 Poly
+	x2 := New(0, 2)
+	for k := len(c) - 1; k >= 1; k-- {
+		b1, b2 = x2.Mul(b1).Sub(b2).AddScalar(c[k]), b1
+	}
+	return New(0, 1).Mul(b1).Sub(b2).AddScalar(c[0])
+}
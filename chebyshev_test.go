@@ -0,0 +1,66 @@
+package poly
+
+import (
+	"math"
+	"testing"
+)
+
+// Tests that Chebyshev coefficients are computed correctly.
+func TestToChebyshev(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		a, b float64
+		want []float64
+	}{
+		{Poly{}, -1, 1, []float64{0}},
+		{New(3), -1, 1, []float64{3}},
+		{New(0, 1), -1, 1, []float64{0, 1}},
+		{New(0, 0, 1), -1, 1, []float64{0.5, 0, 0.5}},
+		{New(0, 0, 0, 1), -1, 1, []float64{0, 0.75, 0, 0.25}},
+		{ChebyshevT(5), -1, 1, []float64{0, 0, 0, 0, 0, 1}},
+		{New(0, 1), 0, 2, []float64{1, 1}},
+		{New(0, 0, 1), 0, 1, []float64{0.375, 0.5, 0.125}},
+	}
+	for i, c := range cases {
+		got := c.p.ToChebyshev(c.a, c.b)
+		ok := len(got) == len(c.want)
+		for k := 0; ok && k < len(got); k++ {
+			ok = math.Abs(got[k]-c.want[k]) <= 0.00001
+		}
+		if !ok {
+			t.Errorf("case %d: ToChebyshev(%.3f, %.3f) on %q == %v, want %v", i, c.a, c.b, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests that polynomials are created from Chebyshev coefficients.
+func TestFromChebyshev(t *testing.T) {
+	cases := []struct {
+		c    []float64
+		a, b float64
+		want Poly
+	}{
+		{nil, -1, 1, Poly{}},
+		{[]float64{3}, -1, 1, New(3)},
+		{[]float64{0, 0, 1}, -1, 1, ChebyshevT(2)},
+		{[]float64{1, 2, 3, 4}, -1, 1, ChebyshevT(0).Add(ChebyshevT(1).Scale(2)).Add(ChebyshevT(2).Scale(3)).Add(ChebyshevT(3).Scale(4))},
+		{[]float64{1, 1}, 0, 2, New(0, 1)},
+		{[]float64{0.375, 0.5, 0.125}, 0, 1, New(0, 0, 1)},
+	}
+	for i, c := range cases {
+		if got := FromChebyshev(c.c, c.a, c.b); !comparePoly(got, c.want) {
+			t.Errorf("case %d: FromChebyshev(%v, %.3f, %.3f) == %q, want %q", i, c.c, c.a, c.b, got, c.want)
+		}
+	}
+}
+
+// Tests that converting to Chebyshev coefficients and back is the identity.
+func TestChebyshevRoundTrip(t *testing.T) {
+	p := New(0.5, -3, 2, 0.25, -1, 4, 0.5)
+	for _, iv := range []Interval{{-1, 1}, {-2, 3}, {1, 1.5}} {
+		c := p.ToChebyshev(iv.Lo, iv.Hi)
+		if got := FromChebyshev(c, iv.Lo, iv.Hi); !comparePoly(got, p) {
+			t.Errorf("FromChebyshev(ToChebyshev(%.3f, %.3f)) == %q, want %q", iv.Lo, iv.Hi, got, p)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+//go:build gonum
+
+package poly
+
+import "gonum.org/v1/gonum/mat"
+
+// Computes all complex roots of a polynomial, repeated according to their
+// multiplicity, as the eigenvalues of its companion matrix.
+// The eigenvalues are computed with gonum, which balances the matrix and
+// applies the shifted QR algorithm. This is the same approach as NumPy's roots
+// and is a robust alternative to Roots. Roots at zero are split off exactly,
+// roots whose imaginary part is negligible are returned as real, and the
+// result is sorted by real then imaginary part. Constant polynomials,
+// including zero, have no reported roots.
+//
+// This method is only available when building with the gonum tag, so that the
+// package has no dependencies by default.
+func (p Poly) CompanionRoots() []complex128 {
+	n := p.Deg()
+	if n < 1 {
+		return nil
+	}
+	k, q := p.splitZeroRoots()
+	roots := make([]complex128, k, n)
+	if m := q.Deg(); m > 0 {
+		// Ones on the subdiagonal and the negated monic coefficients in
+		// the last column.
+		c := mat.NewDense(m, m, nil)
+		lead := q.Coeff(m)
+		for i := 0; i < m; i++ {
+			if i > 0 {
+				c.Set(i, i-1, 1)
+			}
+			c.Set(i, m-1, -q.Coeff(i)/lead)
+		}
+		var eig mat.Eigen
+		if ok := eig.Factorize(c, mat.EigenNone); !ok {
+			// The QR algorithm failed to converge, fall back on the
+			// simultaneous iteration.
+			roots = append(roots, q.aberth()...)
+		} else {
+			roots = append(roots, eig.Values(nil)...)
+		}
+	}
+	snapReal(roots)
+	sortComplex(roots)
+	return roots
+}
@@ -0,0 +1,53 @@
+//go:build gonum
+
+package poly
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// Tests that companion matrix eigenvalues give the roots.
+func TestCompanionRoots(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		want []complex128
+		tol  float64
+	}{
+		{Poly{}, nil, 0},
+		{New(3), nil, 0},
+		{New(0, 1), []complex128{0}, 1e-12},
+		{New(0, 0, 2), []complex128{0, 0}, 1e-12},
+		{New(-2, 1), []complex128{2}, 1e-12},
+		{New(1, 0, 1), []complex128{-1i, 1i}, 1e-12},
+		{New(5, -2, 1), []complex128{1 - 2i, 1 + 2i}, 1e-12},
+		{FromRoots(3, -2, 0.5), []complex128{-2, 0.5, 3}, 1e-12},
+		{New(0, 0, 1, 0, 1), []complex128{-1i, 0, 0, 1i}, 1e-12},
+		{FromRoots(1, 2, 3, 4, 5, 6, 7, 8, 9, 10), []complex128{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 1e-8},
+	}
+	for i, c := range cases {
+		got := c.p.CompanionRoots()
+		ok := len(got) == len(c.want)
+		for j := 0; ok && j < len(got); j++ {
+			ok = cmplx.Abs(got[j]-c.want[j]) <= c.tol*math.Max(1, cmplx.Abs(c.want[j]))
+		}
+		if !ok {
+			t.Errorf("case %d: CompanionRoots() on %q == %v, want %v", i, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests that the companion matrix and Aberth root finders agree.
+func TestCompanionRootsMatchRoots(t *testing.T) {
+	p := New(1, -3, 0.5, 2, -7, 0, 4, 1, -0.25, 3, 1, 0, 2, -1, 0.5, 1)
+	got, want := p.CompanionRoots(), p.Roots()
+	if len(got) != len(want) {
+		t.Fatalf("CompanionRoots() returned %d roots, want %d", len(got), len(want))
+	}
+	for i := range got {
+		if cmplx.Abs(got[i]-want[i]) > 1e-8 {
+			t.Errorf("CompanionRoots()[%d] == %v, want %v", i, got[i], want[i])
+		}
+	}
+}
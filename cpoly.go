@@ -0,0 +1,57 @@
+package poly
+
+// CPoly represents a polynomial with complex128 coefficients.
+// It supports the same arithmetic as Poly, including Add, Sub, Mul, Div,
+// Mod, Eval, Der and Int, through the generic Of type.
+// A zero valued CPoly is equivalent to 0.
+type CPoly = Of[complex128]
+
+// Creates a new polynomial with complex coefficients.
+// The ith parameter represents the coefficient of x^i.
+func NewC(c ...complex128) CPoly {
+	return NewOf(c...)
+}
+
+// Creates a new monic CPoly with the given roots.
+// Returns the expansion of (x - r[0])*(x - r[1])*...*(x - r[n-1]). With no
+// roots the result is the constant 1.
+func FromRootsC(r ...complex128) CPoly {
+	c := make([]complex128, len(r)+1)
+	c[0] = 1
+	for i, z := range r {
+		// Multiply the degree i polynomial in c by (x - z) in place.
+		for j := i + 1; j > 0; j-- {
+			c[j] = c[j-1] - z*c[j]
+		}
+		c[0] *= -z
+	}
+	return normalizedOf(c)
+}
+
+// Converts a polynomial to one with complex coefficients.
+func (p Poly) Complex() CPoly {
+	return normalizedOf(complexCoeffs(p))
+}
+
+// Returns the polynomial whose coefficients are the complex conjugates of
+// those of p. Its roots are the conjugates of the roots of p.
+func Conj(p CPoly) CPoly {
+	pco := p.co()
+	c := make([]complex128, len(pco))
+	for i, pc := range pco {
+		c[i] = complex(real(pc), -imag(pc))
+	}
+	return normalizedOf(c)
+}
+
+// Splits a polynomial with complex coefficients into real polynomials re and
+// im, such that p = re + i*im.
+func SplitC(p CPoly) (re, im Poly) {
+	pco := p.co()
+	r := make([]float64, len(pco))
+	m := make([]float64, len(pco))
+	for i, pc := range pco {
+		r[i], m[i] = real(pc), imag(pc)
+	}
+	return normalized(r), normalized(m)
+}
@@ -0,0 +1,56 @@
+package poly
+
+import (
+	"math/cmplx"
+	"testing"
+)
+
+// Tests that complex polynomials are created from their roots.
+func TestFromRootsC(t *testing.T) {
+	cases := []struct {
+		r    []complex128
+		want CPoly
+	}{
+		{nil, NewC(1)},
+		{[]complex128{2}, NewC(-2, 1)},
+		{[]complex128{1i, -1i}, NewC(1, 0, 1)},
+		{[]complex128{1i, 2}, NewC(2i, -2-1i, 1)},
+	}
+	for i, c := range cases {
+		got := FromRootsC(c.r...)
+		if !compareOf(got, c.want) {
+			t.Errorf("case %d: FromRootsC(%v) == %q, want %q", i, c.r, got, c.want)
+		}
+		for _, z := range c.r {
+			if v := got.Eval(z); cmplx.Abs(v) > 0.00001 {
+				t.Errorf("case %d: Eval(%v) == %v, want 0", i, z, v)
+			}
+		}
+	}
+}
+
+// Tests conversion between real and complex polynomials.
+func TestComplex(t *testing.T) {
+	p := New(1, -2, 3)
+	if got, want := p.Complex(), NewC(1, -2, 3); !compareOf(got, want) {
+		t.Errorf("Complex() on %q == %q, want %q", p, got, want)
+	}
+	if got, want := (Poly{}).Complex(), (CPoly{}); !compareOf(got, want) {
+		t.Errorf("Complex() on 0 == %q, want %q", got, want)
+	}
+	q := NewC(1+2i, 3, -1i)
+	re, im := SplitC(q)
+	if want := New(1, 3); !comparePoly(re, want) {
+		t.Errorf("SplitC(%q) re == %q, want %q", q, re, want)
+	}
+	if want := New(2, 0, -1); !comparePoly(im, want) {
+		t.Errorf("SplitC(%q) im == %q, want %q", q, im, want)
+	}
+	if got, want := Conj(q), NewC(1-2i, 3, 1i); !compareOf(got, want) {
+		t.Errorf("Conj(%q) == %q, want %q", q, got, want)
+	}
+	// The product of a polynomial and its conjugate has real coefficients.
+	if _, im := SplitC(q.Mul(Conj(q))); !comparePoly(im, Poly{}) {
+		t.Errorf("imaginary part of q*Conj(q) == %q, want 0", im)
+	}
+}
@@ -0,0 +1,141 @@
+package poly
+
+import "math/bits"
+
+// Creates the generator polynomial of a CRC from its normal notation, in
+// which bit i of v is the coefficient of x^i and the x^width term is implied.
+// For example, CRC-32 is 0x04c11db7 in normal notation.
+// Panics if width is not between 1 and 64.
+func FromCRCNormal(width int, v uint64) GF2Poly {
+	checkCRCWidth(width)
+	return NewGF2(width).Add(GF2FromUint(v & crcMask(width)))
+}
+
+// Creates the generator polynomial of a CRC from its reversed notation, in
+// which the bits of the normal notation are reversed, so that the most
+// significant bit is the coefficient of x^0. This is the form used by
+// least significant bit first implementations; CRC-32 is 0xedb88320.
+// Panics if width is not between 1 and 64.
+func FromCRCReversed(width int, v uint64) GF2Poly {
+	checkCRCWidth(width)
+	return FromCRCNormal(width, reverseBits(v, width))
+}
+
+// Creates the generator polynomial of a CRC from Koopman's notation, in which
+// bit i of v is the coefficient of x^(i+1) and the x^0 term is implied.
+// CRC-32 is 0x82608edb.
+// Panics if width is not between 1 and 64.
+func FromCRCKoopman(width int, v uint64) GF2Poly {
+	checkCRCWidth(width)
+	return FromCRCNormal(width, v<<1|1)
+}
+
+// Returns the normal notation of a CRC generator polynomial, which omits the
+// x^n term where n is the degree.
+// Panics if the degree is not between 1 and 64.
+func (g GF2Poly) CRCNormal() uint64 {
+	n := g.deg()
+	checkCRCWidth(n)
+	return g.w[0] & crcMask(n)
+}
+
+// Returns the reversed notation of a CRC generator polynomial.
+// Panics if the degree is not between 1 and 64.
+func (g GF2Poly) CRCReversed() uint64 {
+	return reverseBits(g.CRCNormal(), g.deg())
+}
+
+// Returns Koopman's notation of a CRC generator polynomial, which omits the
+// x^0 term.
+// Panics if the degree is not between 1 and 64.
+func (g GF2Poly) CRCKoopman() uint64 {
+	n := g.deg()
+	checkCRCWidth(n)
+	return g.CRCNormal()>>1 | 1<<(n-1)
+}
+
+// Panics unless width is a supported CRC width.
+func checkCRCWidth(width int) {
+	if width < 1 || width > 64 {
+		panic("poly: CRC width out of range")
+	}
+}
+
+// Returns a mask of the low width bits.
+func crcMask(width int) uint64 {
+	return ^uint64(0) >> (64 - width)
+}
+
+// Returns the low width bits of v in reverse order.
+func reverseBits(v uint64, width int) uint64 {
+	return bits.Reverse64(v) >> (64 - width)
+}
+
+// CRC describes a cyclic redundancy check in the parameterized model used by
+// most CRC catalogues. The width of the CRC is the degree of Poly.
+type CRC struct {
+	// The generator polynomial, of degree between 1 and 64.
+	Poly GF2Poly
+
+	// The initial value of the register.
+	Init uint64
+
+	// Whether the bits of each input byte are processed least significant
+	// bit first.
+	RefIn bool
+
+	// Whether the final register value is bit reversed.
+	RefOut bool
+
+	// The value xored into the result.
+	XorOut uint64
+}
+
+// Standard CRCs.
+var (
+	// CRC-32 as used by Ethernet, zlib and PNG.
+	CRC32 = CRC{Poly: FromCRCNormal(32, 0x04c11db7), Init: 0xffffffff, RefIn: true, RefOut: true, XorOut: 0xffffffff}
+
+	// CRC-32C (Castagnoli) as used by iSCSI and ext4.
+	CRC32C = CRC{Poly: FromCRCNormal(32, 0x1edc6f41), Init: 0xffffffff, RefIn: true, RefOut: true, XorOut: 0xffffffff}
+
+	// CRC-16/CCITT-FALSE, the CCITT polynomial x^16 + x^12 + x^5 + 1 with an
+	// initial value of 0xffff.
+	CRC16CCITT = CRC{Poly: FromCRCNormal(16, 0x1021), Init: 0xffff}
+
+	// CRC-16/XMODEM, the CCITT polynomial with an initial value of 0.
+	CRC16XMODEM = CRC{Poly: FromCRCNormal(16, 0x1021)}
+)
+
+// Computes the CRC of data one bit at a time, by polynomial division of the
+// message by the generator.
+// Panics if the degree of the generator is not between 1 and 64.
+func (c CRC) Checksum(data []byte) uint64 {
+	width := c.Poly.deg()
+	g := c.Poly.CRCNormal()
+	mask := crcMask(width)
+	crc := c.Init & mask
+	for _, b := range data {
+		if c.RefIn {
+			b = bits.Reverse8(b)
+		}
+		for i := 7; i >= 0; i-- {
+			top := crc>>(width-1)&1 ^ uint64(b>>i)&1
+			crc = crc << 1 & mask
+			if top == 1 {
+				crc ^= g
+			}
+		}
+	}
+	if c.RefOut {
+		crc = reverseBits(crc, width)
+	}
+	return (crc ^ c.XorOut) & mask
+}
+
+// Returns the check value of the CRC, which is the checksum of the ASCII
+// string "123456789". Catalogues list it alongside each CRC's parameters, so
+// comparing against it verifies that the parameters were entered correctly.
+func (c CRC) Check() uint64 {
+	return c.Checksum([]byte("123456789"))
+}
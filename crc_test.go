@@ -0,0 +1,88 @@
+package poly
+
+import (
+	"hash/crc32"
+	"testing"
+)
+
+// Tests conversion between CRC notations and polynomials.
+func TestCRCNotation(t *testing.T) {
+	cases := []struct {
+		width                     int
+		normal, reversed, koopman uint64
+		want                      GF2Poly
+	}{
+		{32, 0x04c11db7, 0xedb88320, 0x82608edb, NewGF2(32, 26, 23, 22, 16, 12, 11, 10, 8, 7, 5, 4, 2, 1, 0)},
+		{16, 0x1021, 0x8408, 0x8810, NewGF2(16, 12, 5, 0)},
+		{8, 0x07, 0xe0, 0x83, NewGF2(8, 2, 1, 0)},
+		{64, 0x42f0e1eba9ea3693, 0xc96c5795d7870f42, 0xa17870f5d4f51b49, NewGF2(64, 62, 57, 55, 54, 53, 52, 47, 46, 45, 40, 39, 38, 37, 35, 33, 32, 31, 29, 27, 24, 23, 22, 21, 19, 17, 13, 12, 10, 9, 7, 4, 1, 0)},
+	}
+	for i, c := range cases {
+		for _, g := range []GF2Poly{
+			FromCRCNormal(c.width, c.normal),
+			FromCRCReversed(c.width, c.reversed),
+			FromCRCKoopman(c.width, c.koopman),
+		} {
+			if !g.Equal(c.want) {
+				t.Errorf("case %d: polynomial == %q, want %q", i, g, c.want)
+			}
+		}
+		g := c.want
+		if got := g.CRCNormal(); got != c.normal {
+			t.Errorf("case %d: CRCNormal() == %#x, want %#x", i, got, c.normal)
+		}
+		if got := g.CRCReversed(); got != c.reversed {
+			t.Errorf("case %d: CRCReversed() == %#x, want %#x", i, got, c.reversed)
+		}
+		if got := g.CRCKoopman(); got != c.koopman {
+			t.Errorf("case %d: CRCKoopman() == %#x, want %#x", i, got, c.koopman)
+		}
+	}
+}
+
+// Tests the check values of standard CRCs.
+func TestCRCCheck(t *testing.T) {
+	cases := []struct {
+		name string
+		crc  CRC
+		want uint64
+	}{
+		{"CRC-32", CRC32, 0xcbf43926},
+		{"CRC-32C", CRC32C, 0xe3069283},
+		{"CRC-16/CCITT-FALSE", CRC16CCITT, 0x29b1},
+		{"CRC-16/XMODEM", CRC16XMODEM, 0x31c3},
+		{"CRC-8", CRC{Poly: FromCRCNormal(8, 0x07)}, 0xf4},
+		{"CRC-64/XZ", CRC{FromCRCNormal(64, 0x42f0e1eba9ea3693), 1<<64 - 1, true, true, 1<<64 - 1}, 0x995dc9bbdf1939fa},
+		{"CRC-5/USB", CRC{FromCRCNormal(5, 0x05), 0x1f, true, true, 0x1f}, 0x19},
+	}
+	for _, c := range cases {
+		if got := c.crc.Check(); got != c.want {
+			t.Errorf("%s: Check() == %#x, want %#x", c.name, got, c.want)
+		}
+	}
+}
+
+// Tests that checksums agree with the standard library.
+func TestCRCChecksum(t *testing.T) {
+	data := [][]byte{
+		nil,
+		[]byte("a"),
+		[]byte("The quick brown fox jumps over the lazy dog"),
+	}
+	for _, d := range data {
+		if got, want := CRC32.Checksum(d), uint64(crc32.ChecksumIEEE(d)); got != want {
+			t.Errorf("CRC32.Checksum(%q) == %#x, want %#x", d, got, want)
+		}
+		if got, want := CRC32C.Checksum(d), uint64(crc32.Checksum(d, crc32.MakeTable(crc32.Castagnoli))); got != want {
+			t.Errorf("CRC32C.Checksum(%q) == %#x, want %#x", d, got, want)
+		}
+	}
+	// Appending the CRC of a message without reflection or final xor leaves a
+	// remainder of zero, since the message is then a multiple of the
+	// generator.
+	msg := []byte("123456789")
+	crc := CRC16XMODEM.Checksum(msg)
+	if got := CRC16XMODEM.Checksum(append(msg, byte(crc>>8), byte(crc))); got != 0 {
+		t.Errorf("Checksum of message with CRC appended == %#x, want 0", got)
+	}
+}
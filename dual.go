@@ -0,0 +1,46 @@
+package poly
+
+// Dual represents a dual number a + b*e, where e*e = 0.
+// Evaluating a function at Dual{x, 1} yields the function value in Re and its
+// exact derivative at x in Eps, which makes Dual a minimal carrier for forward
+// mode automatic differentiation.
+type Dual struct {
+	Re  float64
+	Eps float64
+}
+
+// Adds a dual number to another dual number.
+// Returns a+b.
+func (a Dual) Add(b Dual) Dual {
+	return Dual{a.Re + b.Re, a.Eps + b.Eps}
+}
+
+// Subtracts a dual number from another dual number.
+// Returns a-b.
+func (a Dual) Sub(b Dual) Dual {
+	return Dual{a.Re - b.Re, a.Eps - b.Eps}
+}
+
+// Multiplies a dual number by another dual number.
+// Returns a*b.
+func (a Dual) Mul(b Dual) Dual {
+	return Dual{a.Re * b.Re, a.Re*b.Eps + a.Eps*b.Re}
+}
+
+// Divides a dual number by another dual number.
+// Returns a/b.
+func (a Dual) Div(b Dual) Dual {
+	return Dual{a.Re / b.Re, (a.Eps*b.Re - a.Re*b.Eps) / (b.Re * b.Re)}
+}
+
+// Evaluates a polynomial at the given dual number x.
+// If x is the result of an earlier computation carrying a derivative in Eps,
+// the result carries the derivative of the composition by the chain rule.
+func (p Poly) EvalDual(x Dual) Dual {
+	pco := p.co()
+	var n Dual
+	for i := len(pco) - 1; i >= 0; i-- {
+		n = n.Mul(x).Add(Dual{pco[i], 0})
+	}
+	return n
+}
@@ -0,0 +1,64 @@
+package poly
+
+import (
+	"math"
+	"testing"
+)
+
+func compareDual(a, b Dual) bool {
+	return math.Abs(a.Re-b.Re) <= 0.00001 && math.Abs(a.Eps-b.Eps) <= 0.00001
+}
+
+// Tests dual number arithmetic.
+func TestDualArith(t *testing.T) {
+	a := Dual{3, 1}
+	b := Dual{2, 5}
+	cases := []struct {
+		name string
+		got  Dual
+		want Dual
+	}{
+		{"Add", a.Add(b), Dual{5, 6}},
+		{"Sub", a.Sub(b), Dual{1, -4}},
+		{"Mul", a.Mul(b), Dual{6, 17}},
+		{"Div", a.Div(b), Dual{1.5, -13.0 / 4}},
+	}
+	for _, c := range cases {
+		if !compareDual(c.got, c.want) {
+			t.Errorf("%s(%v) on %v == %v, want %v", c.name, b, a, c.got, c.want)
+		}
+	}
+}
+
+// Tests that dual evaluation produces values and derivatives.
+func TestEvalDual(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		x    float64
+		want Dual
+	}{
+		{Poly{}, 2.0, Dual{0, 0}},
+		{New(5), 2.0, Dual{5, 0}},
+		{New(1, 2, 3), 0.0, Dual{1, 2}},
+		{New(-1, 2, -3), 2.5, Dual{-14.75, -13}},
+	}
+	for i, c := range cases {
+		if got := c.p.EvalDual(Dual{c.x, 1}); !compareDual(got, c.want) {
+			t.Errorf("case %d: EvalDual(%f) on %q == %v, want %v", i, c.x, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests that nested evaluation applies the chain rule.
+func TestEvalDualCompose(t *testing.T) {
+	p := New(1, 0, 1) // 1 + x^2
+	q := New(0, 3, 1) // 3x + x^2
+	x := 2.0
+	got := p.EvalDual(q.EvalDual(Dual{x, 1}))
+	// p(q(x)) = 1 + q^2, d/dx = 2*q(x)*q'(x).
+	qx, dqx := q.Eval(x), q.Der().Eval(x)
+	want := Dual{1 + qx*qx, 2 * qx * dqx}
+	if !compareDual(got, want) {
+		t.Errorf("EvalDual composition == %v, want %v", got, want)
+	}
+}
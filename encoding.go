@@ -0,0 +1,102 @@
+package poly
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Implements json.Marshaler.
+// A Poly is encoded as its array of coefficients, lowest order term first,
+// e.g. 1.5 + 2x^2 is encoded as [1.5,0,2].
+func (p Poly) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.co())
+}
+
+// Implements json.Unmarshaler.
+// It accepts the array of coefficients produced by MarshalJSON.
+func (p *Poly) UnmarshalJSON(data []byte) error {
+	var c []float64
+	if err := json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+	*p = New(c...)
+	return nil
+}
+
+// Implements encoding.TextMarshaler.
+// A Poly is encoded in the same human readable form as String, but with every
+// nonzero term present and coefficients written at full precision, so that
+// UnmarshalText recovers the exact value.
+func (p Poly) MarshalText() ([]byte, error) {
+	for _, c := range p.co() {
+		if math.IsNaN(c) || math.IsInf(c, 0) {
+			return nil, errors.New("poly: cannot marshal non-finite coefficient")
+		}
+	}
+	s := termFormat{
+		coeff: func(c float64) string {
+			return strconv.FormatFloat(c, 'g', -1, 64)
+		},
+	}.format(p)
+	return []byte(s), nil
+}
+
+// Implements encoding.TextUnmarshaler.
+// It accepts any string accepted by Parse.
+func (p *Poly) UnmarshalText(text []byte) error {
+	q, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*p = q
+	return nil
+}
+
+// Version of the binary encoding produced by MarshalBinary.
+const binaryVersion = 1
+
+// Implements encoding.BinaryMarshaler.
+// The encoding is a version byte, followed by the number of coefficients as
+// an unsigned varint, followed by each coefficient lowest order first as a
+// little endian IEEE 754 double. Because Poly implements this interface it can
+// also be transmitted with encoding/gob.
+func (p Poly) MarshalBinary() ([]byte, error) {
+	pco := p.co()
+	data := make([]byte, 1, 1+binary.MaxVarintLen64+8*len(pco))
+	data[0] = binaryVersion
+	data = binary.AppendUvarint(data, uint64(len(pco)))
+	for _, c := range pco {
+		data = binary.LittleEndian.AppendUint64(data, math.Float64bits(c))
+	}
+	return data, nil
+}
+
+// Implements encoding.BinaryUnmarshaler.
+// It accepts the encoding produced by MarshalBinary.
+func (p *Poly) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("poly: empty binary encoding")
+	}
+	if data[0] != binaryVersion {
+		return fmt.Errorf("poly: unsupported binary encoding version %d", data[0])
+	}
+	data = data[1:]
+	n, k := binary.Uvarint(data)
+	if k <= 0 {
+		return errors.New("poly: invalid binary encoding length")
+	}
+	data = data[k:]
+	if n > uint64(len(data))/8 || uint64(len(data)) != 8*n {
+		return fmt.Errorf("poly: binary encoding has %d bytes of coefficients, want %d", len(data), 8*n)
+	}
+	c := make([]float64, n)
+	for i := range c {
+		c[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:]))
+	}
+	*p = New(c...)
+	return nil
+}
@@ -0,0 +1,239 @@
+package poly
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+// Tests that polynomials are encoded as JSON coefficient arrays.
+func TestMarshalJSON(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		want string
+	}{
+		{Poly{}, "[0]"},
+		{New(), "[0]"},
+		{New(1.5), "[1.5]"},
+		{New(1.5, 0, 2), "[1.5,0,2]"},
+		{New(-1, 2, 0, 0), "[-1,2]"},
+	}
+	for i, c := range cases {
+		got, err := json.Marshal(c.p)
+		if err != nil {
+			t.Errorf("case %d: Marshal(%q) returned error %v", i, c.p, err)
+			continue
+		}
+		if string(got) != c.want {
+			t.Errorf("case %d: Marshal(%q) == %s, want %s", i, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests that polynomials are decoded from JSON coefficient arrays.
+func TestUnmarshalJSON(t *testing.T) {
+	cases := []struct {
+		s    string
+		want Poly
+	}{
+		{"[]", Poly{}},
+		{"[0]", Poly{}},
+		{"[1.5,0,2]", New(1.5, 0, 2)},
+		{"[-1,2,0,0]", New(-1, 2)},
+	}
+	for i, c := range cases {
+		var got Poly
+		if err := json.Unmarshal([]byte(c.s), &got); err != nil {
+			t.Errorf("case %d: Unmarshal(%s) returned error %v", i, c.s, err)
+			continue
+		}
+		if !comparePoly(got, c.want) {
+			t.Errorf("case %d: Unmarshal(%s) == %q, want %q", i, c.s, got, c.want)
+		}
+	}
+	for _, s := range []string{`"x"`, `{}`, `[1,"a"]`} {
+		var p Poly
+		if err := json.Unmarshal([]byte(s), &p); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded, want error", s)
+		}
+	}
+}
+
+// Tests that polynomials embedded in structs round trip through JSON.
+func TestJSONRoundTrip(t *testing.T) {
+	type config struct {
+		Name   string
+		Filter Poly
+	}
+	in := config{"lowpass", New(0.25, -0.5, 0.125)}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal() returned error %v", err)
+	}
+	var out config
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal(%s) returned error %v", data, err)
+	}
+	if out.Name != in.Name || !comparePoly(out.Filter, in.Filter) {
+		t.Errorf("round trip of %s == %+v, want %+v", data, out, in)
+	}
+}
+
+// Tests that polynomials are encoded in human readable form.
+func TestMarshalText(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		want string
+	}{
+		{Poly{}, "0"},
+		{New(1.5), "1.5"},
+		{New(0, -1), "-x"},
+		{New(-3, -1, 2, 0, 4), "4x^4 + 2x^2 - x - 3"},
+		{New(0.1, 0, 1e-7), "1e-07x^2 + 0.1"},
+		{New(1.0/3, 2.0/3), "0.6666666666666666x + 0.3333333333333333"},
+	}
+	for i, c := range cases {
+		got, err := c.p.MarshalText()
+		if err != nil {
+			t.Errorf("case %d: MarshalText() on %q returned error %v", i, c.p, err)
+			continue
+		}
+		if string(got) != c.want {
+			t.Errorf("case %d: MarshalText() on %q == %q, want %q", i, c.p, got, c.want)
+		}
+	}
+	if _, err := New(1, math.NaN()).MarshalText(); err == nil {
+		t.Errorf("MarshalText() on NaN coefficient succeeded, want error")
+	}
+}
+
+// Tests that text encoding round trips exactly.
+func TestTextRoundTrip(t *testing.T) {
+	cases := []Poly{
+		Poly{},
+		New(-1.5),
+		New(0.1, 0, 1e-7),
+		New(1.0/3, -2.0/3, math.Pi, 0, -math.E),
+		New(1e300, -1e-300),
+	}
+	for i, p := range cases {
+		text, err := p.MarshalText()
+		if err != nil {
+			t.Errorf("case %d: MarshalText() on %q returned error %v", i, p, err)
+			continue
+		}
+		var got Poly
+		if err := got.UnmarshalText(text); err != nil {
+			t.Errorf("case %d: UnmarshalText(%q) returned error %v", i, text, err)
+			continue
+		}
+		if !equalCoeffs(got, p) {
+			t.Errorf("case %d: UnmarshalText(%q) == %q, want %q", i, text, got, p)
+		}
+	}
+	var p Poly
+	for _, s := range []string{"x^", "x^9223372036854775807"} {
+		if err := p.UnmarshalText([]byte(s)); err == nil {
+			t.Errorf("UnmarshalText(%q) succeeded, want error", s)
+		}
+	}
+}
+
+// Tests that the binary encoding has the documented layout.
+func TestMarshalBinary(t *testing.T) {
+	got, err := New(1, -2).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() returned error %v", err)
+	}
+	want := []byte{
+		1, 2,
+		0, 0, 0, 0, 0, 0, 0xf0, 0x3f,
+		0, 0, 0, 0, 0, 0, 0x00, 0xc0,
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("MarshalBinary() == %v, want %v", got, want)
+	}
+}
+
+// Tests that binary encoding round trips exactly.
+func TestBinaryRoundTrip(t *testing.T) {
+	cases := []Poly{
+		Poly{},
+		New(-1.5),
+		New(1.0/3, -2.0/3, math.Pi, 0, -math.E),
+		New(math.Inf(1), math.SmallestNonzeroFloat64),
+	}
+	for i, p := range cases {
+		data, err := p.MarshalBinary()
+		if err != nil {
+			t.Errorf("case %d: MarshalBinary() on %q returned error %v", i, p, err)
+			continue
+		}
+		var got Poly
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Errorf("case %d: UnmarshalBinary(%v) returned error %v", i, data, err)
+			continue
+		}
+		if !equalCoeffs(got, p) {
+			t.Errorf("case %d: UnmarshalBinary(%v) == %q, want %q", i, data, got, p)
+		}
+	}
+}
+
+// Tests that malformed binary encodings are rejected.
+func TestUnmarshalBinaryError(t *testing.T) {
+	cases := [][]byte{
+		nil,
+		{2, 1, 0, 0, 0, 0, 0, 0, 0, 0},
+		{1},
+		{1, 2, 0, 0, 0, 0, 0, 0, 0, 0},
+		{1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{1, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x20},
+	}
+	for i, data := range cases {
+		var p Poly
+		if err := p.UnmarshalBinary(data); err == nil {
+			t.Errorf("case %d: UnmarshalBinary(%v) succeeded, want error", i, data)
+		}
+	}
+}
+
+// Tests that polynomials can be transmitted with encoding/gob.
+func TestGobRoundTrip(t *testing.T) {
+	type message struct {
+		ID    int
+		Polys []Poly
+	}
+	in := message{7, []Poly{New(1, 2, 3), Poly{}, New(-0.5, 0, 0, 1e-9)}}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("Encode() returned error %v", err)
+	}
+	var out message
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("Decode() returned error %v", err)
+	}
+	if out.ID != in.ID || len(out.Polys) != len(in.Polys) {
+		t.Fatalf("Decode() == %+v, want %+v", out, in)
+	}
+	for i := range in.Polys {
+		if !equalCoeffs(out.Polys[i], in.Polys[i]) {
+			t.Errorf("Polys[%d] == %q, want %q", i, out.Polys[i], in.Polys[i])
+		}
+	}
+}
+
+// Reports whether two polynomials have exactly the same coefficients.
+func equalCoeffs(p, q Poly) bool {
+	if p.Deg() != q.Deg() {
+		return false
+	}
+	for i := 0; i <= p.Deg(); i++ {
+		if p.Coeff(i) != q.Coeff(i) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,214 @@
+package poly
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"runtime"
+	"sync"
+)
+
+// Evaluates a polynomial at the given complex point z using Horner's method.
+func (p Poly) EvalC(z complex128) complex128 {
+	pco := p.co()
+	var n complex128
+	for i := len(pco) - 1; i >= 0; i-- {
+		n = n*z + complex(pco[i], 0)
+	}
+	return n
+}
+
+// Amount of work, in points times terms, below which batch evaluation is done
+// on the calling goroutine.
+const parallelEvalWork = 1 << 16
+
+// Evaluates a polynomial at each of the given points.
+// Returns a new slice holding p(xs[i]) at index i.
+func (p Poly) EvalAll(xs []float64) []float64 {
+	dst := make([]float64, len(xs))
+	p.EvalInto(dst, xs)
+	return dst
+}
+
+// Evaluates a polynomial at each of the given points, storing p(xs[i]) in
+// dst[i]. The slices must have the same length, and may be the same slice to
+// evaluate in place.
+// Large batches are split across goroutines.
+func (p Poly) EvalInto(dst, xs []float64) {
+	if len(dst) != len(xs) {
+		panic("poly: destination and source lengths differ")
+	}
+	workers := runtime.GOMAXPROCS(0)
+	if len(xs)*len(p.co()) < parallelEvalWork || workers == 1 {
+		p.evalRange(dst, xs)
+		return
+	}
+	chunk := (len(xs) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for lo := 0; lo < len(xs); lo += chunk {
+		hi := lo + chunk
+		if hi > len(xs) {
+			hi = len(xs)
+		}
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			p.evalRange(dst[lo:hi], xs[lo:hi])
+		}(lo, hi)
+	}
+	wg.Wait()
+}
+
+func (p Poly) evalRange(dst, xs []float64) {
+	pco := p.co()
+	for i, x := range xs {
+		var n float64
+		for j := len(pco) - 1; j >= 0; j-- {
+			n = n*x + pco[j]
+		}
+		dst[i] = n
+	}
+}
+
+// Evaluates a polynomial at the given point x using the compensated Horner
+// scheme.
+// The rounding error of every multiplication and addition is captured exactly
+// with error-free transformations and accumulated in a second Horner
+// recurrence, so the result is as accurate as if Horner's method had been
+// carried out in twice the working precision. This matters near roots, and in
+// particular multiple roots, where Eval can lose all significant digits.
+func (p Poly) EvalAccurate(x float64) float64 {
+	pco := p.co()
+	n := len(pco) - 1
+	s := pco[n]
+	var c float64
+	for i := n - 1; i >= 0; i-- {
+		prod, perr := twoProd(s, x)
+		var serr float64
+		s, serr = twoSum(prod, pco[i])
+		c = c*x + (perr + serr)
+	}
+	return s + c
+}
+
+// Returns a+b and the exact rounding error of the sum.
+func twoSum(a, b float64) (s, e float64) {
+	s = a + b
+	z := s - a
+	e = (a - (s - z)) + (b - z)
+	return s, e
+}
+
+// Returns a*b and the exact rounding error of the product.
+func twoProd(a, b float64) (p, e float64) {
+	p = a * b
+	e = math.FMA(a, b, -p)
+	return p, e
+}
+
+// Computes rigorous bounds on the values of a polynomial over the interval
+// [lo, hi].
+// Returns min and max such that min <= p(x) <= max for every x in [lo, hi].
+// Horner's method is carried out in interval arithmetic with every operation
+// rounded outward, so the bounds hold despite rounding error, although they
+// may be wider than the true range. Two such enclosures are intersected: one
+// of p over [lo, hi], and one of p re-centered on the midpoint of the
+// interval, which is much tighter on narrow intervals. Panics if lo > hi.
+func (p Poly) EvalInterval(lo, hi float64) (min, max float64) {
+	if lo > hi {
+		panic("poly: invalid interval")
+	}
+	pco := p.co()
+	n := len(pco) - 1
+
+	min, max = hornerInterval(pco, pco, lo, hi)
+
+	// Taylor shift by m, as in Shift, with interval coefficients.
+	m := lo + (hi-lo)/2
+	cl := make([]float64, len(pco))
+	ch := make([]float64, len(pco))
+	copy(cl, pco)
+	copy(ch, pco)
+	for i := 0; i < n; i++ {
+		for j := n - 1; j >= i; j-- {
+			a, b := intervalMul(cl[j+1], ch[j+1], m, m)
+			cl[j] = roundDown(cl[j] + a)
+			ch[j] = roundUp(ch[j] + b)
+		}
+	}
+
+	r := roundUp(math.Max(hi-m, m-lo))
+	cmin, cmax := hornerInterval(cl, ch, -r, r)
+	return math.Max(min, cmin), math.Min(max, cmax)
+}
+
+// Evaluates the polynomial whose ith coefficient lies in [cl[i], ch[i]] over
+// the interval [lo, hi] using Horner's method, rounding outward.
+func hornerInterval(cl, ch []float64, lo, hi float64) (min, max float64) {
+	n := len(cl) - 1
+	min, max = cl[n], ch[n]
+	for i := n - 1; i >= 0; i-- {
+		min, max = intervalMul(min, max, lo, hi)
+		min = roundDown(min + cl[i])
+		max = roundUp(max + ch[i])
+	}
+	return min, max
+}
+
+// Multiplies the intervals [a, b] and [c, d], rounding outward.
+func intervalMul(a, b, c, d float64) (lo, hi float64) {
+	p := [4]float64{a * c, a * d, b * c, b * d}
+	lo, hi = p[0], p[0]
+	for _, v := range p[1:] {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	return roundDown(lo), roundUp(hi)
+}
+
+func roundDown(x float64) float64 {
+	return math.Nextafter(x, math.Inf(-1))
+}
+
+func roundUp(x float64) float64 {
+	return math.Nextafter(x, math.Inf(1))
+}
+
+// Evaluates a polynomial at the given point x using arbitrary precision
+// floating point arithmetic with prec bits of mantissa.
+// The coefficients are converted exactly, so only the arithmetic introduces
+// error. This gives a high precision reference for ill-conditioned
+// evaluations. If prec is 0, the precision of x is used. Either x or the
+// coefficients may be infinite. Returns an error if any coefficient is NaN,
+// which a big.Float cannot represent, or if the result would be NaN.
+func (p Poly) EvalBig(x *big.Float, prec uint) (*big.Float, error) {
+	if prec == 0 {
+		prec = x.Prec()
+	}
+	pco := p.co()
+	for i, pc := range pco {
+		if math.IsNaN(pc) {
+			return nil, fmt.Errorf("poly: coefficient %v of x^%d is NaN", pc, i)
+		}
+	}
+	n := new(big.Float).SetPrec(prec)
+	if len(pco) == 0 {
+		return n, nil
+	}
+	// Starting from the leading coefficient, rather than multiplying zero by
+	// x, keeps an infinite x from giving 0*Inf.
+	n.SetFloat64(pco[len(pco)-1])
+	c := new(big.Float).SetPrec(53)
+	for i := len(pco) - 2; i >= 0; i-- {
+		c.SetFloat64(pco[i])
+		if (n.IsInf() && x.Sign() == 0) || (n.Sign() == 0 && x.IsInf()) {
+			return nil, fmt.Errorf("poly: evaluation at %v is NaN", x)
+		}
+		n.Mul(n, x)
+		if n.IsInf() && c.IsInf() && n.Sign() != c.Sign() {
+			return nil, fmt.Errorf("poly: evaluation at %v is NaN", x)
+		}
+		n.Add(n, c)
+	}
+	return n, nil
+}
@@ -0,0 +1,249 @@
+package poly
+
+import (
+	"math"
+	"math/big"
+	"math/cmplx"
+	"testing"
+)
+
+// Tests that complex evaluation produces correct results.
+func TestEvalC(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		z    complex128
+		want complex128
+	}{
+		{Poly{}, 1 + 1i, 0},
+		{New(3), 1 + 1i, 3},
+		{New(1, 0, 1), 1i, 0},
+		{New(1, 0, 1), -1i, 0},
+		{New(0, 1), 2 - 3i, 2 - 3i},
+		{New(1, 2, 3), 1 + 1i, 3 + 8i},
+		{New(-1, 2, -3), 2.5, -14.75},
+	}
+	for i, c := range cases {
+		if got := c.p.EvalC(c.z); cmplx.Abs(got-c.want) > 0.00001 {
+			t.Errorf("case %d: EvalC(%v) on %q == %v, want %v", i, c.z, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests that batch evaluation matches pointwise evaluation.
+func TestEvalAll(t *testing.T) {
+	cases := []struct {
+		p Poly
+		n int
+	}{
+		{Poly{}, 0},
+		{New(1, 2, 3), 1},
+		{New(-1, 2, -3), 100},
+		// Large enough to be split across goroutines.
+		{New(1, -0.5, 0.25, 2, -1, 0.125, 3, 0.5), 50000},
+	}
+	for i, c := range cases {
+		xs := make([]float64, c.n)
+		for j := range xs {
+			xs[j] = -2 + 4*float64(j)/float64(c.n)
+		}
+		got := c.p.EvalAll(xs)
+		if len(got) != len(xs) {
+			t.Errorf("case %d: EvalAll() returned %d values, want %d", i, len(got), len(xs))
+			continue
+		}
+		for j, x := range xs {
+			if want := c.p.Eval(x); got[j] != want {
+				t.Errorf("case %d: EvalAll()[%d] on %q == %f, want %f", i, j, c.p, got[j], want)
+				break
+			}
+		}
+	}
+}
+
+// Tests that points can be evaluated in place.
+func TestEvalIntoInPlace(t *testing.T) {
+	p := New(1, 0, 1)
+	xs := []float64{-1, 0, 2, math.Sqrt2}
+	p.EvalInto(xs, xs)
+	want := []float64{2, 1, 5, 3}
+	if !compareVec(xs, want) {
+		t.Errorf("EvalInto() in place == %v, want %v", xs, want)
+	}
+}
+
+func BenchmarkEvalAll(b *testing.B) {
+	p := New(1, -0.5, 0.25, 2, -1, 0.125, 3, 0.5)
+	xs := make([]float64, 1<<16)
+	for i := range xs {
+		xs[i] = float64(i) / float64(len(xs))
+	}
+	dst := make([]float64, len(xs))
+	for i := 0; i < b.N; i++ {
+		p.EvalInto(dst, xs)
+	}
+}
+
+// Tests that compensated evaluation is accurate near a multiple root, where
+// ordinary evaluation is not.
+func TestEvalAccurate(t *testing.T) {
+	cases := []struct {
+		root float64
+		n    int
+		x    float64
+	}{
+		{1, 7, 1.01},
+		{1, 7, 0.99},
+		{2, 6, 2.02},
+		{0.75, 5, 0.7501},
+	}
+	for i, c := range cases {
+		r := make([]float64, c.n)
+		for j := range r {
+			r[j] = c.root
+		}
+		p := FromRoots(r...)
+		// x - root is exact for these values, so this is accurate to a few
+		// units in the last place.
+		want := math.Pow(c.x-c.root, float64(c.n))
+		if got := p.EvalAccurate(c.x); math.Abs(got-want) > 1e-10*math.Abs(want) {
+			t.Errorf("case %d: EvalAccurate(%g) on %q == %g, want %g", i, c.x, p, got, want)
+		}
+	}
+}
+
+// Tests that compensated evaluation agrees with ordinary evaluation on well
+// conditioned inputs.
+func TestEvalAccurateMatchesEval(t *testing.T) {
+	cases := []Poly{Poly{}, New(3), New(1, 2, 3), New(-1, 2, -3, 0.5)}
+	for i, p := range cases {
+		for _, x := range []float64{-2, -0.5, 0, 1, 3} {
+			if got, want := p.EvalAccurate(x), p.Eval(x); math.Abs(got-want) > 0.00001 {
+				t.Errorf("case %d: EvalAccurate(%f) on %q == %f, want %f", i, x, p, got, want)
+			}
+		}
+	}
+}
+
+// Tests that interval evaluation bounds every value in the interval.
+func TestEvalInterval(t *testing.T) {
+	cases := []struct {
+		p          Poly
+		lo, hi     float64
+		wantMin    float64
+		wantMax    float64
+		wantMaxGap float64
+	}{
+		{Poly{}, -1, 1, 0, 0, 1e-300},
+		{New(3), -1, 1, 3, 3, 1e-15},
+		{New(1, 2), 0, 1, 1, 3, 1e-14},
+		{New(1, 2), 0.5, 0.5, 2, 2, 1e-14},
+		{New(0, 0, 1), 1, 2, 1, 4, 1e-14},
+		// Interval arithmetic overestimates x^2 over [-1, 1].
+		{New(0, 0, 1), -1, 1, 0, 1, 1.01},
+		{New(-1, 2, -3, 0.5), -2, 3, -21, -0.645, 40},
+		{FromRoots(1, 1, 1, 1, 1, 1, 1), 0.99, 1.01, -1e-14, 1e-14, 1e-10},
+	}
+	for i, c := range cases {
+		min, max := c.p.EvalInterval(c.lo, c.hi)
+		if min > c.wantMin || max < c.wantMax {
+			t.Errorf("case %d: EvalInterval(%g, %g) on %q == [%g, %g], does not contain [%g, %g]",
+				i, c.lo, c.hi, c.p, min, max, c.wantMin, c.wantMax)
+		}
+		if c.wantMin-min > c.wantMaxGap || max-c.wantMax > c.wantMaxGap {
+			t.Errorf("case %d: EvalInterval(%g, %g) on %q == [%g, %g], too wide for [%g, %g]",
+				i, c.lo, c.hi, c.p, min, max, c.wantMin, c.wantMax)
+		}
+		for j := 0; j <= 100; j++ {
+			x := c.lo + (c.hi-c.lo)*float64(j)/100
+			if v := c.p.EvalAccurate(x); v < min || v > max {
+				t.Errorf("case %d: p(%g) == %g outside [%g, %g]", i, x, v, min, max)
+				break
+			}
+		}
+	}
+}
+
+// Tests that arbitrary precision evaluation matches an exact reference on an
+// ill-conditioned polynomial.
+func TestEvalBig(t *testing.T) {
+	// The coefficients of (x-1)(x-2)...(x-10) are exact in float64.
+	p := FromRoots(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	x := new(big.Float).SetPrec(200).SetFloat64(5.5)
+	x.Add(x, big.NewFloat(1e-9))
+	got, err := p.EvalBig(x, 200)
+	if err != nil {
+		t.Fatalf("EvalBig(%s) returned error %v", x.Text('g', 20), err)
+	}
+
+	want := new(big.Float).SetPrec(200).SetFloat64(1)
+	for i := 1; i <= 10; i++ {
+		d := new(big.Float).SetPrec(200).Sub(x, big.NewFloat(float64(i)))
+		want.Mul(want, d)
+	}
+	diff := new(big.Float).Sub(got, want)
+	diff.Quo(diff, want)
+	if f, _ := diff.Float64(); math.Abs(f) > 1e-40 {
+		t.Errorf("EvalBig(%s) == %s, want %s", x.Text('g', 20), got.Text('g', 30), want.Text('g', 30))
+	}
+}
+
+// Tests that arbitrary precision evaluation at infinity and of polynomials
+// with infinite coefficients is exact, and that NaN coefficients and results
+// are reported as errors.
+func TestEvalBigNonFinite(t *testing.T) {
+	inf := math.Inf(1)
+	nan := math.NaN()
+	cases := []struct {
+		p    Poly
+		x    float64
+		want float64
+	}{
+		{New(1, 2, 3), inf, inf},
+		{New(1, 2, 3), -inf, inf},
+		{New(1, 2, -3), -inf, -inf},
+		{New(0, 0, 0, 5), -inf, -inf},
+		{New(7), inf, 7},
+		{New(inf, 1), 2, inf},
+		{New(1, -inf), 2, -inf},
+		{New(inf, 0, 1), -inf, inf},
+		{New(nan, 1), 2, nan},
+		{New(1, nan), inf, nan},
+		{New(1, inf), 0, nan},
+		{New(-inf, 0, 1), inf, nan},
+	}
+	for i, c := range cases {
+		got, err := c.p.EvalBig(new(big.Float).SetFloat64(c.x), 0)
+		if math.IsNaN(c.want) {
+			if err == nil {
+				t.Errorf("case %d: EvalBig(%g) on %q == %v, want error", i, c.x, c.p, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("case %d: EvalBig(%g) on %q returned error %v", i, c.x, c.p, err)
+			continue
+		}
+		if f, _ := got.Float64(); f != c.want {
+			t.Errorf("case %d: EvalBig(%g) on %q == %v, want %v", i, c.x, c.p, f, c.want)
+		}
+	}
+}
+
+// Tests that arbitrary precision evaluation agrees with Eval at working
+// precision.
+func TestEvalBigMatchesEval(t *testing.T) {
+	cases := []Poly{Poly{}, New(3), New(1, 2, 3), New(-1, 2, -3, 0.5)}
+	for i, p := range cases {
+		for _, x := range []float64{-2, -0.5, 0, 1, 3} {
+			v, err := p.EvalBig(big.NewFloat(x), 0)
+			if err != nil {
+				t.Errorf("case %d: EvalBig(%f) on %q returned error %v", i, x, p, err)
+				continue
+			}
+			got, _ := v.Float64()
+			if want := p.Eval(x); math.Abs(got-want) > 0.00001 {
+				t.Errorf("case %d: EvalBig(%f) on %q == %f, want %f", i, x, p, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package poly
+
+import (
+	"fmt"
+	"math"
+)
+
+// Evaluates a polynomial at a square matrix, given as a slice of rows.
+// Returns p(A) = c0 I + c1 A + c2 A^2 + ... as a new matrix.
+// Uses the Paterson-Stockmeyer scheme: with s close to the square root of the
+// degree d, the powers A^2 through A^s are formed once, and the polynomial is
+// evaluated as a polynomial in A^s whose coefficients are combinations of
+// those powers. This takes about 2 sqrt(d) matrix multiplications, rather
+// than the d taken by Horner's method.
+// Panics if the matrix is not square.
+func (p Poly) EvalMatrix(a [][]float64) [][]float64 {
+	n := len(a)
+	for _, r := range a {
+		if len(r) != n {
+			panic(fmt.Sprintf("poly: matrix evaluation at non-square matrix with row of length %d", len(r)))
+		}
+	}
+	pco := p.co()
+	d := len(pco) - 1
+	s := int(math.Ceil(math.Sqrt(float64(d + 1))))
+	// pow[i] is A^i for i from 0 to s.
+	pow := make([][][]float64, s+1)
+	pow[0] = identity(n)
+	pow[1] = copyMatrix(a)
+	for i := 2; i <= s; i++ {
+		pow[i] = matMul(pow[i-1], a)
+	}
+	// Horner's method in A^s, from the highest block of s coefficients
+	// down.
+	var r [][]float64
+	for j := d / s * s; j >= 0; j -= s {
+		b := make([][]float64, n)
+		for i := range b {
+			b[i] = make([]float64, n)
+		}
+		for i := 0; i < s && j+i <= d; i++ {
+			c := pco[j+i]
+			if c == 0 {
+				continue
+			}
+			for k := range b {
+				for l := range b[k] {
+					b[k][l] += c * pow[i][k][l]
+				}
+			}
+		}
+		if r != nil {
+			r = matMul(r, pow[s])
+			for k := range b {
+				for l := range b[k] {
+					b[k][l] += r[k][l]
+				}
+			}
+		}
+		r = b
+	}
+	return r
+}
+
+// Returns the n by n identity matrix.
+func identity(n int) [][]float64 {
+	m := make([][]float64, n)
+	for i := range m {
+		m[i] = make([]float64, n)
+		m[i][i] = 1
+	}
+	return m
+}
+
+// Returns a copy of a matrix.
+func copyMatrix(a [][]float64) [][]float64 {
+	m := make([][]float64, len(a))
+	for i, r := range a {
+		m[i] = append([]float64(nil), r...)
+	}
+	return m
+}
+
+// Returns the product of two square matrices of the same size.
+func matMul(a, b [][]float64) [][]float64 {
+	n := len(a)
+	m := make([][]float64, n)
+	for i := range m {
+		m[i] = make([]float64, n)
+		for k := 0; k < n; k++ {
+			if a[i][k] == 0 {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				m[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+	return m
+}
@@ -0,0 +1,86 @@
+package poly
+
+import (
+	"math"
+	"testing"
+)
+
+// Returns true if two matrices are equal within a small tolerance.
+func compareMatrix(a, b [][]float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for j := range a[i] {
+			if math.Abs(a[i][j]-b[i][j]) > 0.00001*math.Max(1, math.Abs(b[i][j])) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Tests evaluation of polynomials at matrices.
+func TestEvalMatrix(t *testing.T) {
+	a := [][]float64{{1, 2}, {3, 4}}
+	cases := []struct {
+		p    Poly
+		a    [][]float64
+		want [][]float64
+	}{
+		{Poly{}, a, [][]float64{{0, 0}, {0, 0}}},
+		{New(3), a, [][]float64{{3, 0}, {0, 3}}},
+		{New(0, 1), a, a},
+		{New(1, 1, 1), a, [][]float64{{9, 12}, {18, 27}}},
+		// Cayley-Hamilton: a matrix satisfies its characteristic
+		// polynomial.
+		{New(-2, -5, 1), a, [][]float64{{0, 0}, {0, 0}}},
+		{New(1, 2, 3, 4, 5), [][]float64{{2}}, [][]float64{{129}}},
+		{New(1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1), [][]float64{{0, 1}, {0, 0}}, [][]float64{{1, 0}, {0, 1}}},
+		{New(1, 2), nil, [][]float64{}},
+	}
+	for i, c := range cases {
+		if got := c.p.EvalMatrix(c.a); !compareMatrix(got, c.want) {
+			t.Errorf("case %d: %q.EvalMatrix(%v) == %v, want %v", i, c.p, c.a, got, c.want)
+		}
+	}
+}
+
+// Tests that EvalMatrix agrees with Horner's method for polynomials of many
+// degrees.
+func TestEvalMatrixHorner(t *testing.T) {
+	a := [][]float64{{0.5, -0.25, 0.1}, {0.2, 0.3, -0.4}, {-0.1, 0.6, 0.2}}
+	for d := 0; d <= 20; d++ {
+		c := make([]float64, d+1)
+		for i := range c {
+			c[i] = float64(i%5) - 1.5
+		}
+		p := New(c...)
+		want := make([][]float64, len(a))
+		for i := range want {
+			want[i] = make([]float64, len(a))
+		}
+		for i := d; i >= 0; i-- {
+			want = matMul(want, a)
+			for k := range want {
+				want[k][k] += c[i]
+			}
+		}
+		if got := p.EvalMatrix(a); !compareMatrix(got, want) {
+			t.Errorf("%q.EvalMatrix(%v) == %v, want %v", p, a, got, want)
+		}
+	}
+}
+
+// Tests that EvalMatrix panics on a non-square matrix.
+func TestEvalMatrixNonSquare(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("EvalMatrix of non-square matrix did not panic")
+		}
+	}()
+	New(1, 1).EvalMatrix([][]float64{{1, 2}})
+}
@@ -0,0 +1,366 @@
+package poly
+
+import "sort"
+
+type exprOp int
+
+const (
+	opLit exprOp = iota
+	opAdd
+	opSub
+	opMul
+	opPow
+	opCompose
+)
+
+// Expr is a node in a lazily evaluated polynomial expression.
+// Building an expression performs no polynomial arithmetic. The work is
+// deferred until Poly or Eval is called, at which point the whole expression
+// DAG is planned at once: sums and products are flattened, products are
+// multiplied smallest first, shared subexpressions are computed once, and
+// intermediate coefficient buffers are recycled.
+type Expr struct {
+	op   exprOp
+	p    Poly
+	args []*Expr
+	n    int
+}
+
+// Creates a new expression consisting of the literal polynomial p.
+func Lit(p Poly) *Expr {
+	return &Expr{op: opLit, p: p}
+}
+
+// Returns the expression e+f.
+func (e *Expr) Add(f *Expr) *Expr {
+	return &Expr{op: opAdd, args: []*Expr{e, f}}
+}
+
+// Returns the expression e-f.
+func (e *Expr) Sub(f *Expr) *Expr {
+	return &Expr{op: opSub, args: []*Expr{e, f}}
+}
+
+// Returns the expression e*f.
+func (e *Expr) Mul(f *Expr) *Expr {
+	return &Expr{op: opMul, args: []*Expr{e, f}}
+}
+
+// Returns the expression e^n.
+// Panics if n is negative.
+func (e *Expr) Pow(n int) *Expr {
+	if n < 0 {
+		panic("poly: negative exponent")
+	}
+	return &Expr{op: opPow, args: []*Expr{e}, n: n}
+}
+
+// Returns the expression e(f(x)), the composition of e with f.
+func (e *Expr) Compose(f *Expr) *Expr {
+	return &Expr{op: opCompose, args: []*Expr{e, f}}
+}
+
+// Returns an upper bound on the degree of the polynomial the expression
+// represents, without evaluating it.
+// The bound is exact unless terms cancel.
+func (e *Expr) MaxDeg() int {
+	switch e.op {
+	case opAdd, opSub:
+		a, b := e.args[0].MaxDeg(), e.args[1].MaxDeg()
+		if a > b {
+			return a
+		}
+		return b
+	case opMul:
+		return e.args[0].MaxDeg() + e.args[1].MaxDeg()
+	case opPow:
+		return e.n * e.args[0].MaxDeg()
+	case opCompose:
+		return e.args[0].MaxDeg() * e.args[1].MaxDeg()
+	}
+	return e.p.Deg()
+}
+
+// Evaluates the expression at the given point x.
+// No intermediate polynomials are materialized.
+func (e *Expr) Eval(x float64) float64 {
+	return e.eval(x, map[*Expr]float64{})
+}
+
+func (e *Expr) eval(x float64, memo map[*Expr]float64) float64 {
+	if v, ok := memo[e]; ok {
+		return v
+	}
+	var v float64
+	switch e.op {
+	case opLit:
+		v = e.p.Eval(x)
+	case opAdd:
+		v = e.args[0].eval(x, memo) + e.args[1].eval(x, memo)
+	case opSub:
+		v = e.args[0].eval(x, memo) - e.args[1].eval(x, memo)
+	case opMul:
+		v = e.args[0].eval(x, memo) * e.args[1].eval(x, memo)
+	case opPow:
+		b := e.args[0].eval(x, memo)
+		v = 1
+		for n := e.n; n > 0; n >>= 1 {
+			if n&1 == 1 {
+				v *= b
+			}
+			b *= b
+		}
+	case opCompose:
+		// The outer expression is evaluated at a different point, so it
+		// cannot share memoized values with this one.
+		v = e.args[0].eval(e.args[1].eval(x, memo), map[*Expr]float64{})
+	}
+	memo[e] = v
+	return v
+}
+
+// Computes the polynomial the expression represents.
+func (e *Expr) Poly() Poly {
+	pl := planner{
+		refs: map[*Expr]int{},
+		memo: map[*Expr][]float64{},
+	}
+	pl.count(e)
+	c := pl.value(e)
+	if e.op == opLit {
+		return e.p
+	}
+	return normalized(c)
+}
+
+// Plans and executes the evaluation of an expression DAG.
+type planner struct {
+	// Number of parents that still need the value of each node.
+	refs map[*Expr]int
+	// Computed coefficients of nodes that are still referenced.
+	memo map[*Expr][]float64
+	// Released buffers available for reuse.
+	free [][]float64
+}
+
+// Records how many parents reference each node in the DAG.
+func (pl *planner) count(e *Expr) {
+	pl.refs[e]++
+	if pl.refs[e] > 1 {
+		return
+	}
+	for _, a := range e.args {
+		pl.count(a)
+	}
+}
+
+// Returns a zeroed buffer of length n, reusing a released one if possible.
+func (pl *planner) get(n int) []float64 {
+	for i, b := range pl.free {
+		if cap(b) >= n {
+			pl.free = append(pl.free[:i], pl.free[i+1:]...)
+			b = b[:n]
+			for j := range b {
+				b[j] = 0
+			}
+			return b
+		}
+	}
+	return make([]float64, n)
+}
+
+// Returns a buffer to the free list.
+func (pl *planner) put(b []float64) {
+	pl.free = append(pl.free, b)
+}
+
+// Signals that a parent has consumed the value of e.
+// Once every parent has done so, the buffer holding it is recycled.
+func (pl *planner) done(e *Expr, c []float64) {
+	pl.refs[e]--
+	if pl.refs[e] > 0 {
+		return
+	}
+	delete(pl.memo, e)
+	if e.op != opLit {
+		pl.put(c)
+	}
+}
+
+// Returns the coefficients of the node e.
+// The result must be treated as read only, and passed to done once used.
+func (pl *planner) value(e *Expr) []float64 {
+	if c, ok := pl.memo[e]; ok {
+		return c
+	}
+	var c []float64
+	switch e.op {
+	case opLit:
+		c = e.p.co()
+	case opAdd, opSub:
+		c = pl.sum(e)
+	case opMul:
+		c = pl.product(e)
+	case opPow:
+		c = pl.pow(e)
+	case opCompose:
+		c = pl.compose(e)
+	}
+	c = trim(c)
+	pl.memo[e] = c
+	return c
+}
+
+// A term of a flattened sum.
+type signedExpr struct {
+	e   *Expr
+	neg bool
+}
+
+// Collects the terms of a nest of additions and subtractions.
+// Nodes shared elsewhere in the DAG are kept whole so they are computed once.
+func (pl *planner) flattenSum(e *Expr, neg bool, terms []signedExpr) []signedExpr {
+	if (e.op == opAdd || e.op == opSub) && pl.refs[e] == 1 {
+		delete(pl.refs, e)
+		terms = pl.flattenSum(e.args[0], neg, terms)
+		return pl.flattenSum(e.args[1], neg != (e.op == opSub), terms)
+	}
+	return append(terms, signedExpr{e, neg})
+}
+
+// Computes a sum by accumulating every term into a single buffer.
+func (pl *planner) sum(e *Expr) []float64 {
+	terms := pl.flattenSum(e.args[0], false, nil)
+	terms = pl.flattenSum(e.args[1], e.op == opSub, terms)
+
+	vals := make([][]float64, len(terms))
+	n := 0
+	for i, t := range terms {
+		vals[i] = pl.value(t.e)
+		if len(vals[i]) > n {
+			n = len(vals[i])
+		}
+	}
+	c := pl.get(n)
+	for i, t := range terms {
+		for j, v := range vals[i] {
+			if t.neg {
+				c[j] -= v
+			} else {
+				c[j] += v
+			}
+		}
+		pl.done(t.e, vals[i])
+	}
+	return c
+}
+
+// Collects the factors of a nest of multiplications.
+func (pl *planner) flattenProduct(e *Expr, factors []*Expr) []*Expr {
+	if e.op == opMul && pl.refs[e] == 1 {
+		delete(pl.refs, e)
+		factors = pl.flattenProduct(e.args[0], factors)
+		return pl.flattenProduct(e.args[1], factors)
+	}
+	return append(factors, e)
+}
+
+// Computes a product, always multiplying the two lowest degree operands
+// next. This keeps the intermediate products as small as possible.
+func (pl *planner) product(e *Expr) []float64 {
+	factors := pl.flattenProduct(e.args[0], nil)
+	factors = pl.flattenProduct(e.args[1], factors)
+
+	type operand struct {
+		c    []float64
+		e    *Expr // nil once the operand is an intermediate product
+		size int
+	}
+	ops := make([]operand, len(factors))
+	for i, f := range factors {
+		c := pl.value(f)
+		ops[i] = operand{c, f, len(c)}
+	}
+	release := func(o operand) {
+		if o.e != nil {
+			pl.done(o.e, o.c)
+		} else {
+			pl.put(o.c)
+		}
+	}
+	for len(ops) > 1 {
+		sort.Slice(ops, func(i, j int) bool { return ops[i].size < ops[j].size })
+		a, b := ops[0], ops[1]
+		c := pl.get(len(a.c) + len(b.c) - 1)
+		mulInto(c, a.c, b.c)
+		release(a)
+		release(b)
+		c = trim(c)
+		ops = append(ops[2:], operand{c, nil, len(c)})
+	}
+	return ops[0].c
+}
+
+// Computes a power by repeated squaring.
+func (pl *planner) pow(e *Expr) []float64 {
+	base := pl.value(e.args[0])
+	r := pl.get(1)
+	r[0] = 1
+	b := base
+	owned := false
+	for n := e.n; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			t := pl.get(len(r) + len(b) - 1)
+			mulInto(t, r, b)
+			pl.put(r)
+			r = trim(t)
+		}
+		if n > 1 {
+			t := pl.get(2*len(b) - 1)
+			mulInto(t, b, b)
+			if owned {
+				pl.put(b)
+			}
+			b = trim(t)
+			owned = true
+		}
+	}
+	if owned {
+		pl.put(b)
+	}
+	pl.done(e.args[0], base)
+	return r
+}
+
+// Computes a composition using Horner's scheme, alternating between two
+// buffers for the running result.
+func (pl *planner) compose(e *Expr) []float64 {
+	outer := pl.value(e.args[0])
+	inner := pl.value(e.args[1])
+	n := (len(outer)-1)*(len(inner)-1) + 1
+	r := pl.get(n)[:1]
+	t := pl.get(n)
+	r[0] = outer[len(outer)-1]
+	for i := len(outer) - 2; i >= 0; i-- {
+		t = t[:len(r)+len(inner)-1]
+		for j := range t {
+			t[j] = 0
+		}
+		mulInto(t, r, inner)
+		t[0] += outer[i]
+		r, t = t, r
+	}
+	pl.put(t)
+	pl.done(e.args[0], outer)
+	pl.done(e.args[1], inner)
+	return r
+}
+
+// Removes leading zero coefficients from c, keeping at least one.
+func trim(c []float64) []float64 {
+	i := len(c) - 1
+	for i > 0 && c[i] == 0.0 {
+		i--
+	}
+	return c[:i+1]
+}
@@ -0,0 +1,102 @@
+package poly
+
+import (
+	"math"
+	"testing"
+)
+
+// Tests that expressions evaluate to the same polynomial as eager arithmetic.
+func TestExprPoly(t *testing.T) {
+	p := New(1, 2)
+	q := New(-1, 0, 3)
+	r := New(0.5, -1)
+	a, b, c := Lit(p), Lit(q), Lit(r)
+	shared := a.Mul(b)
+	cases := []struct {
+		e    *Expr
+		want Poly
+	}{
+		{Lit(Poly{}), Poly{}},
+		{a, p},
+		{a.Add(b), p.Add(q)},
+		{a.Sub(b), p.Sub(q)},
+		{a.Sub(a), New()},
+		{a.Mul(b), p.Mul(q)},
+		{a.Mul(b).Mul(c).Mul(a), p.Mul(q).Mul(r).Mul(p)},
+		{a.Add(b).Sub(c.Add(a)), p.Add(q).Sub(r.Add(p))},
+		{a.Pow(0), New(1)},
+		{a.Pow(1), p},
+		{a.Pow(5), p.Mul(p).Mul(p).Mul(p).Mul(p)},
+		{b.Compose(a), New(-1).Add(p.Mul(p).Mul(New(3)))},
+		{Lit(New(7)).Compose(a), New(7)},
+		{a.Compose(Lit(New(2))), New(5)},
+		{shared.Add(shared.Mul(c)).Sub(shared), p.Mul(q).Mul(r)},
+		{a.Add(b).Pow(3).Compose(c.Mul(c)), func() Poly {
+			s := p.Add(q)
+			s3 := s.Mul(s).Mul(s)
+			r2 := r.Mul(r)
+			var out Poly
+			x := New(1)
+			for i := 0; i <= s3.Deg(); i++ {
+				out = out.Add(x.Mul(New(s3.Coeff(i))))
+				x = x.Mul(r2)
+			}
+			return out
+		}()},
+	}
+	for i, c := range cases {
+		if got := c.e.Poly(); !comparePoly(got, c.want) {
+			t.Errorf("case %d: Poly() == %q, want %q", i, got, c.want)
+		}
+	}
+}
+
+// Tests that expressions evaluate pointwise without materializing.
+func TestExprEval(t *testing.T) {
+	a, b := Lit(New(1, 2)), Lit(New(-1, 0, 3))
+	e := a.Mul(b).Pow(3).Compose(a.Sub(b)).Add(b)
+	want := e.Poly()
+	for _, x := range []float64{-1.5, -0.25, 0, 0.75, 1} {
+		if got := e.Eval(x); math.Abs(got-want.Eval(x)) > 0.00001*math.Max(1, math.Abs(got)) {
+			t.Errorf("Eval(%f) == %f, want %f", x, got, want.Eval(x))
+		}
+	}
+}
+
+// Tests that degree bounds are computed structurally.
+func TestExprMaxDeg(t *testing.T) {
+	a, b := Lit(New(1, 2)), Lit(New(-1, 0, 3))
+	cases := []struct {
+		e    *Expr
+		want int
+	}{
+		{a, 1},
+		{a.Add(b), 2},
+		{a.Mul(b), 3},
+		{b.Pow(4), 8},
+		{b.Compose(a.Mul(b)), 6},
+	}
+	for i, c := range cases {
+		if got := c.e.MaxDeg(); got != c.want {
+			t.Errorf("case %d: MaxDeg() == %d, want %d", i, got, c.want)
+		}
+	}
+}
+
+// Tests that evaluating an expression leaves its literals untouched.
+func TestExprLiteralsUnchanged(t *testing.T) {
+	p := New(1, 2, 3)
+	a := Lit(p)
+	a.Add(a).Mul(a).Pow(2).Compose(a).Poly()
+	if want := New(1, 2, 3); !comparePoly(p, want) {
+		t.Errorf("literal modified to %q, want %q", p, want)
+	}
+}
+
+func BenchmarkExprPoly(b *testing.B) {
+	p, q := Lit(New(1, 2, 3, 4)), Lit(New(-1, 0.5, 0.25))
+	e := p.Mul(q).Add(p).Pow(6).Sub(q.Compose(p))
+	for i := 0; i < b.N; i++ {
+		e.Poly()
+	}
+}
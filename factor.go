@@ -0,0 +1,535 @@
+package poly
+
+import (
+	"math/big"
+	"math/rand"
+	"sort"
+)
+
+// Splits off the linear factors of an integer polynomial, which correspond
+// to its rational roots.
+// Returns primitive linear factors bx - a with b > 0, one for each rational
+// root a/b repeated according to its multiplicity and in increasing order of
+// root, and the remaining factor rest, which has no rational roots, such that
+// p is exactly the product of the factors and rest. By the rational root
+// theorem every root a/b in lowest terms has a dividing the lowest nonzero
+// coefficient and b dividing the leading coefficient, so the candidates are
+// found by factoring those two coefficients and each is tested exactly.
+// The zero polynomial and constants have no linear factors.
+// Panics if the lowest nonzero or the leading coefficient does not fit in 64
+// bits.
+func (p IntPoly) LinearFactors() (factors []IntPoly, rest IntPoly) {
+	if p.Deg() < 1 {
+		return nil, p
+	}
+	pco := p.co()
+	// Roots at zero.
+	k := 0
+	for pco[k].Sign() == 0 {
+		factors = append(factors, NewInt(0, 1))
+		k++
+	}
+	rest = NewBigInt(pco[k:]...)
+	if rest.Deg() < 1 {
+		return factors, rest
+	}
+	low, lead := rest.co()[0], rest.co()[rest.Deg()]
+	if !low.IsInt64() && !low.IsUint64() || !lead.IsInt64() && !lead.IsUint64() {
+		panic("poly: coefficient too large for rational root search")
+	}
+	// Since a - b divides p(1) and a + b divides p(-1) for every root a/b,
+	// nonzero values there rule out most candidates cheaply.
+	p1, pm1 := rest.Eval(big.NewInt(1)), rest.Eval(big.NewInt(-1))
+	var roots []*big.Rat
+	var t big.Int
+	for _, b := range divisorsUint64(absUint64(lead)) {
+		bb := new(big.Int).SetUint64(b)
+		for _, a := range divisorsUint64(absUint64(low)) {
+			if gcdUint64(a, b) != 1 {
+				continue
+			}
+			for _, s := range []int64{1, -1} {
+				aa := new(big.Int).SetUint64(a)
+				aa.Mul(aa, big.NewInt(s))
+				if p1.Sign() != 0 && !divides(t.Sub(aa, bb), p1) {
+					continue
+				}
+				if pm1.Sign() != 0 && !divides(t.Add(aa, bb), pm1) {
+					continue
+				}
+				roots = append(roots, new(big.Rat).SetFrac(aa, bb))
+			}
+		}
+	}
+	sort.Slice(roots, func(i, j int) bool { return roots[i].Cmp(roots[j]) < 0 })
+	var linear []IntPoly
+	for _, r := range roots {
+		f := NewBigInt(new(big.Int).Neg(r.Num()), r.Denom())
+		for rest.Deg() > 0 {
+			q, ok := rest.divExact(f)
+			if !ok {
+				break
+			}
+			linear = append(linear, f)
+			rest = q
+		}
+	}
+	// The roots at zero are the smallest nonnegative roots, so they go
+	// after the negative ones.
+	i := sort.Search(len(linear), func(i int) bool { return linear[i].co()[0].Sign() <= 0 })
+	factors = append(append(append([]IntPoly(nil), linear[:i]...), factors...), linear[i:]...)
+	return factors, rest
+}
+
+// Returns the rational roots of an integer polynomial, repeated according to
+// their multiplicity, in increasing order.
+// The zero polynomial and constants have no reported roots.
+// Panics if the lowest nonzero or the leading coefficient does not fit in 64
+// bits.
+func (p IntPoly) RationalRoots() []*big.Rat {
+	factors, _ := p.LinearFactors()
+	roots := make([]*big.Rat, len(factors))
+	for i, f := range factors {
+		roots[i] = new(big.Rat).SetFrac(new(big.Int).Neg(f.co()[0]), f.co()[1])
+	}
+	return roots
+}
+
+// Divides p by q over the integers.
+// Returns the quotient and true if q divides p exactly, otherwise false.
+func (p IntPoly) divExact(q IntPoly) (IntPoly, bool) {
+	pco, qco := p.co(), q.co()
+	d := len(qco) - 1
+	lead := qco[d]
+	if len(pco) <= d {
+		return IntPoly{}, p.isZero()
+	}
+	r := make([]*big.Int, len(pco))
+	for i, pc := range pco {
+		r[i] = new(big.Int).Set(pc)
+	}
+	c := make([]*big.Int, len(pco)-d)
+	var m, t big.Int
+	for i := len(r) - 1; i >= d; i-- {
+		c[i-d] = new(big.Int)
+		c[i-d].QuoRem(r[i], lead, &m)
+		if m.Sign() != 0 {
+			return IntPoly{}, false
+		}
+		for j := 0; j < d; j++ {
+			r[i-d+j].Sub(r[i-d+j], t.Mul(c[i-d], qco[j]))
+		}
+	}
+	for _, ri := range r[:d] {
+		if ri.Sign() != 0 {
+			return IntPoly{}, false
+		}
+	}
+	return normalizedInt(c), true
+}
+
+// Reports whether d divides x. Zero divides only zero.
+func divides(d, x *big.Int) bool {
+	if d.Sign() == 0 {
+		return x.Sign() == 0
+	}
+	return new(big.Int).Rem(x, d).Sign() == 0
+}
+
+// Returns the absolute value of an integer that fits in 64 bits as a uint64.
+func absUint64(x *big.Int) uint64 {
+	return new(big.Int).Abs(x).Uint64()
+}
+
+// Returns the positive divisors of n > 0 in increasing order.
+func divisorsUint64(n uint64) []uint64 {
+	d := []uint64{1}
+	for _, f := range factorUint64(n) {
+		m := len(d)
+		for pow := f; n%f == 0; pow *= f {
+			n /= f
+			for _, x := range d[:m] {
+				d = append(d, x*pow)
+			}
+		}
+	}
+	sort.Slice(d, func(i, j int) bool { return d[i] < d[j] })
+	return d
+}
+
+// IntFactor is an irreducible factor of a polynomial along with its
+// multiplicity.
+type IntFactor struct {
+	Poly IntPoly
+	Mult int
+}
+
+// Factors an integer polynomial into irreducible factors over the integers,
+// which by Gauss's lemma are also irreducible over the rationals.
+// Returns the content c and the distinct primitive irreducible factors f_i,
+// each with a positive leading coefficient, and their multiplicities m_i, such
+// that p = c * f_1^m_1 * f_2^m_2 * ..., ordered by degree and then by
+// coefficients. The zero polynomial has content 0 and constants have no
+// factors.
+// The polynomial is first split into square-free parts with Yun's algorithm.
+// Each part is then factored with the Berlekamp-Zassenhaus algorithm: it is
+// factored modulo a small prime p by the Cantor-Zassenhaus algorithm, the
+// factorization is lifted to one modulo p^k larger than twice the
+// Landau-Mignotte bound on the coefficients of any factor by Hensel lifting,
+// and the true factors are found by trying products of the lifted factors.
+// The last step takes time exponential in the number of modular factors in
+// the worst case, but few polynomials come close to it.
+func (p IntPoly) Factor() (c *big.Int, factors []IntFactor) {
+	c = p.Content()
+	if p.Deg() < 1 {
+		return c, nil
+	}
+	for _, f := range p.PrimitivePart().squareFree() {
+		for _, g := range f.Poly.zassenhaus() {
+			factors = append(factors, IntFactor{g, f.Mult})
+		}
+	}
+	sort.Slice(factors, func(i, j int) bool {
+		return factors[i].Poly.cmp(factors[j].Poly) < 0
+	})
+	return c, factors
+}
+
+// Factors a rational polynomial into irreducible factors over the rationals.
+// Returns a rational constant c and factors as for IntPoly.Factor, with each
+// factor a primitive integer polynomial, such that p = c * f_1^m_1 * ....
+// The zero polynomial has constant 0 and constants have no factors.
+func (p RatPoly) Factor() (c *big.Rat, factors []IntFactor) {
+	a, l := p.clearDenominators()
+	ic, factors := a.Factor()
+	return new(big.Rat).SetFrac(ic, l), factors
+}
+
+// Returns the primitive integer polynomial with a positive leading
+// coefficient that is a rational multiple of p.
+func (p RatPoly) primitive() IntPoly {
+	a, _ := p.clearDenominators()
+	return a.PrimitivePart()
+}
+
+// Multiplies a rational polynomial by the least common multiple l of the
+// denominators of its coefficients.
+// Returns the resulting integer polynomial and l.
+func (p RatPoly) clearDenominators() (IntPoly, *big.Int) {
+	pco := p.co()
+	l := big.NewInt(1)
+	var g big.Int
+	for _, pc := range pco {
+		g.GCD(nil, nil, l, pc.Denom())
+		l.Mul(l, g.Quo(pc.Denom(), &g))
+	}
+	a := make([]*big.Int, len(pco))
+	for i, pc := range pco {
+		a[i] = new(big.Int).Mul(pc.Num(), new(big.Int).Quo(l, pc.Denom()))
+	}
+	return normalizedInt(a), l
+}
+
+// Compares two polynomials by degree and then by coefficients from the
+// highest order term down. Returns -1, 0 or 1.
+func (p IntPoly) cmp(q IntPoly) int {
+	if p.Deg() != q.Deg() {
+		if p.Deg() < q.Deg() {
+			return -1
+		}
+		return 1
+	}
+	pco, qco := p.co(), q.co()
+	for i := len(pco) - 1; i >= 0; i-- {
+		if c := pco[i].Cmp(qco[i]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// Computes the square-free factorization of a primitive polynomial with a
+// positive leading coefficient using Yun's algorithm over the rationals.
+// Returns the non-constant square-free parts as primitive integer
+// polynomials along with their multiplicities.
+func (p IntPoly) squareFree() []IntFactor {
+	f := p.Rat()
+	df := f.Der()
+	a := f.GCD(df)
+	b := f.Div(a)
+	d := df.Div(a).Sub(b.Der())
+	var factors []IntFactor
+	for k := 1; b.Deg() > 0; k++ {
+		a = b.GCD(d)
+		if a.Deg() > 0 {
+			factors = append(factors, IntFactor{a.primitive(), k})
+		}
+		b = b.Div(a)
+		d = d.Div(a).Sub(b.Der())
+	}
+	return factors
+}
+
+// Number of suitable primes tried when choosing the one that gives the fewest
+// modular factors.
+const zassenhausPrimes = 5
+
+// Factors a square-free primitive polynomial with a positive leading
+// coefficient into irreducible factors using the Berlekamp-Zassenhaus
+// algorithm.
+func (f IntPoly) zassenhaus() []IntPoly {
+	n := f.Deg()
+	if n == 1 {
+		return []IntPoly{f}
+	}
+	lead := f.co()[n]
+	rnd := rand.New(rand.NewSource(1))
+	// Choose an odd prime not dividing the leading coefficient modulo which
+	// f remains square-free, preferring one that gives few factors.
+	var p uint64
+	var modular []GFPoly
+	for q, tried := uint64(3), 0; tried < zassenhausPrimes; q += 2 {
+		if !new(big.Int).SetUint64(q).ProbablyPrime(0) || new(big.Int).Rem(lead, new(big.Int).SetUint64(q)).Sign() == 0 {
+			continue
+		}
+		fq := f.toGF(q)
+		if fq.GCD(fq.Der()).Deg() > 0 {
+			continue
+		}
+		tried++
+		if m := fq.Monic().factorSquareFree(rnd); modular == nil || len(m) < len(modular) {
+			p, modular = q, m
+		}
+		if len(modular) == 1 {
+			return []IntPoly{f}
+		}
+	}
+	// Every coefficient of lead/lc(g) * g for a factor g of f is less than
+	// B = |lead| 2^n |f|, so the factors are determined modulo p^k > 2B.
+	var sq big.Int
+	for _, c := range f.co() {
+		sq.Add(&sq, new(big.Int).Mul(c, c))
+	}
+	bound := new(big.Int).Sqrt(&sq)
+	bound.Add(bound, big.NewInt(1)).Mul(bound, new(big.Int).Abs(lead)).Lsh(bound, uint(n+1))
+	pb := new(big.Int).SetUint64(p)
+	k, pk := 1, new(big.Int).Set(pb)
+	for pk.Cmp(bound) <= 0 {
+		k++
+		pk.Mul(pk, pb)
+	}
+	lifted := f.henselLift(modular, p, k)
+	// Try products of subsets of the lifted factors, smallest first,
+	// removing each true factor that is found along with the subset.
+	var factors []IntPoly
+	for s := 1; 2*s <= len(lifted); {
+		found := false
+		for idx := combination(s); idx != nil; idx = nextCombination(idx, len(lifted)) {
+			g := IntPoly{[]*big.Int{new(big.Int).Set(f.co()[f.Deg()])}}
+			for _, i := range idx {
+				g = g.Mul(lifted[i]).symmetricMod(pk)
+			}
+			g = g.PrimitivePart()
+			q, ok := f.divExact(g)
+			if !ok {
+				continue
+			}
+			factors = append(factors, g)
+			f = q
+			for j := len(idx) - 1; j >= 0; j-- {
+				lifted = append(lifted[:idx[j]], lifted[idx[j]+1:]...)
+			}
+			found = true
+			break
+		}
+		if !found {
+			s++
+		}
+	}
+	return append(factors, f)
+}
+
+// Returns the first combination of s indices in lexicographic order.
+func combination(s int) []int {
+	idx := make([]int, s)
+	for i := range idx {
+		idx[i] = i
+	}
+	return idx
+}
+
+// Advances a combination of indices less than n to the next in lexicographic
+// order. Returns nil after the last combination.
+func nextCombination(idx []int, n int) []int {
+	s := len(idx)
+	i := s - 1
+	for i >= 0 && idx[i] == n-s+i {
+		i--
+	}
+	if i < 0 {
+		return nil
+	}
+	idx[i]++
+	for j := i + 1; j < s; j++ {
+		idx[j] = idx[j-1] + 1
+	}
+	return idx
+}
+
+// Lifts a factorization of f modulo a prime p into monic pairwise coprime
+// factors to a factorization modulo p^k.
+// Returns the lifted monic factors, with coefficients reduced modulo p^k.
+// Each factor in turn is split from the product of the rest, with the
+// leading coefficient of f kept on the remaining factor.
+func (f IntPoly) henselLift(factors []GFPoly, p uint64, k int) []IntPoly {
+	pk := new(big.Int).Exp(new(big.Int).SetUint64(p), big.NewInt(int64(k)), nil)
+	lifted := make([]IntPoly, len(factors))
+	for i, g := range factors[:len(factors)-1] {
+		h := f.toGF(p).Div(g)
+		lifted[i], f = f.henselLift2(g, h, p, k)
+	}
+	// What remains is the leading coefficient times the last factor.
+	inv := new(big.Int).ModInverse(f.co()[f.Deg()], pk)
+	lifted[len(factors)-1] = f.Scale(inv).symmetricMod(pk)
+	return lifted
+}
+
+// Lifts a factorization f = gh modulo a prime p, with g monic and coprime to
+// h, to a factorization f = GH modulo p^k by linear Hensel lifting, where
+// G = g and H = h modulo p, G is monic, and H has the leading coefficient of
+// f.
+func (f IntPoly) henselLift2(g, h GFPoly, p uint64, k int) (G, H IntPoly) {
+	s, t := extGCDGF(g, h)
+	G = fromGF(g)
+	hc := fromGF(h).co()
+	hc[len(hc)-1] = f.co()[f.Deg()]
+	H = NewBigInt(hc...)
+	pb := new(big.Int).SetUint64(p)
+	pj := new(big.Int).Set(pb)
+	for j := 1; j < k; j++ {
+		// With e = (f - GH)/p^j, find dg and dh with deg(dg) < deg(g)
+		// such that e = g dh + h dg modulo p, using sg + th = 1.
+		e := f.Sub(G.Mul(H)).co()
+		c := make([]*big.Int, len(e))
+		for i, ec := range e {
+			c[i] = new(big.Int).Quo(ec, pj)
+		}
+		ep := normalizedInt(c).toGF(p)
+		quo, dg := t.Mul(ep).DivMod(g)
+		dh := s.Mul(ep).Add(quo.Mul(h))
+		pjb := IntPoly{[]*big.Int{pj}}
+		G = G.Add(fromGF(dg).Mul(pjb))
+		H = H.Add(fromGF(dh).Mul(pjb))
+		pj = new(big.Int).Mul(pj, pb)
+	}
+	return G.symmetricMod(pj), H.symmetricMod(pj)
+}
+
+// Computes s and t such that sf + tg = 1 for coprime polynomials over GF(p).
+func extGCDGF(f, g GFPoly) (s, t GFPoly) {
+	p := f.modulus(g)
+	r0, r1 := f, g
+	s0, s1 := GFPoly{p, []uint64{1}}, GFPoly{p: p}
+	t0, t1 := GFPoly{p: p}, GFPoly{p, []uint64{1}}
+	for !r1.isZero() {
+		quo, rem := r0.DivMod(r1)
+		r0, r1 = r1, rem
+		s0, s1 = s1, s0.Sub(quo.Mul(s1))
+		t0, t1 = t1, t0.Sub(quo.Mul(t1))
+	}
+	inv := invMod(r0.co()[0], p)
+	return s0.Scale(inv), t0.Scale(inv)
+}
+
+// Reduces the coefficients of a polynomial modulo a prime p.
+func (f IntPoly) toGF(p uint64) GFPoly {
+	fco := f.co()
+	pb := new(big.Int).SetUint64(p)
+	c := make([]uint64, len(fco))
+	var t big.Int
+	for i, fc := range fco {
+		c[i] = t.Mod(fc, pb).Uint64()
+	}
+	return normalizedGF(p, c)
+}
+
+// Returns the integer polynomial with the coefficients of f in [0, p).
+func fromGF(f GFPoly) IntPoly {
+	fco := f.co()
+	c := make([]*big.Int, len(fco))
+	for i, fc := range fco {
+		c[i] = new(big.Int).SetUint64(fc)
+	}
+	return normalizedInt(c)
+}
+
+// Reduces the coefficients of a polynomial modulo m into the symmetric range
+// (-m/2, m/2].
+func (f IntPoly) symmetricMod(m *big.Int) IntPoly {
+	fco := f.co()
+	half := new(big.Int).Rsh(m, 1)
+	c := make([]*big.Int, len(fco))
+	for i, fc := range fco {
+		c[i] = new(big.Int).Mod(fc, m)
+		if c[i].Cmp(half) > 0 {
+			c[i].Sub(c[i], m)
+		}
+	}
+	return normalizedInt(c)
+}
+
+// Factors a monic square-free polynomial over GF(p), for an odd prime p, into
+// monic irreducible factors, using distinct-degree factorization followed by
+// the Cantor-Zassenhaus equal-degree splitting.
+func (f GFPoly) factorSquareFree(rnd *rand.Rand) []GFPoly {
+	p := f.p
+	x := GFPoly{p, []uint64{0, 1}}
+	pb := new(big.Int).SetUint64(p)
+	var factors []GFPoly
+	// h is x^(p^d) mod f.
+	h := x.Mod(f)
+	for d := 1; 2*d <= f.Deg(); d++ {
+		h = h.PowMod(pb, f)
+		if g := h.Sub(x).GCD(f); g.Deg() > 0 {
+			factors = append(factors, g.splitEqualDegree(d, rnd)...)
+			f = f.Div(g)
+			h = h.Mod(f)
+		}
+	}
+	if f.Deg() > 0 {
+		factors = append(factors, f)
+	}
+	return factors
+}
+
+// Splits a monic square-free polynomial over GF(p), for an odd prime p, whose
+// irreducible factors all have degree d, into those factors.
+func (f GFPoly) splitEqualDegree(d int, rnd *rand.Rand) []GFPoly {
+	n := f.Deg()
+	if n == d {
+		return []GFPoly{f}
+	}
+	p := f.p
+	// A random a is a square in about half of the fields GF(p^d) given by
+	// the factors, so a^((p^d-1)/2) - 1 shares about half of them with f.
+	e := new(big.Int).Exp(new(big.Int).SetUint64(p), big.NewInt(int64(d)), nil)
+	e.Rsh(e, 1)
+	one := GFPoly{p, []uint64{1}}
+	for {
+		c := make([]uint64, n)
+		for i := range c {
+			c[i] = rnd.Uint64() % p
+		}
+		a := normalizedGF(p, c)
+		if a.Deg() < 1 {
+			continue
+		}
+		g := a.GCD(f)
+		if g.Deg() == 0 {
+			g = a.PowMod(e, f).Sub(one).GCD(f)
+		}
+		if g.Deg() > 0 && g.Deg() < n {
+			return append(g.splitEqualDegree(d, rnd), f.Div(g).splitEqualDegree(d, rnd)...)
+		}
+	}
+}
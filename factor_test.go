@@ -0,0 +1,191 @@
+package poly
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// Tests splitting off the linear factors of integer polynomials.
+func TestLinearFactors(t *testing.T) {
+	cases := []struct {
+		p       IntPoly
+		factors string
+		rest    string
+	}{
+		{IntPoly{}, "[]", "0"},
+		{NewInt(7), "[]", "7"},
+		{NewInt(-3, 1), "[x - 3]", "1"},
+		{NewInt(3, 2), "[2x + 3]", "1"},
+		{NewInt(-6, 4), "[2x - 3]", "2"},
+		{NewInt(0, 0, 5), "[x x]", "5"},
+		{NewInt(-6, 11, -6, 1), "[x - 1 x - 2 x - 3]", "1"},
+		{NewInt(1, 0, 1), "[]", "x^2 + 1"},
+		{NewInt(-2, 0, 1), "[]", "x^2 - 2"},
+		// (2x - 1)(3x + 2)^2 (x^2 + x + 1) x
+		{NewInt(1, 1, 1).Mul(NewInt(0, 1)).Mul(NewInt(-1, 2)).Mul(NewInt(2, 3).Mul(NewInt(2, 3))), "[3x + 2 3x + 2 x 2x - 1]", "x^2 + x + 1"},
+		{NewInt(1, -2, 1).Mul(NewInt(1, 2, 1)).Scale(big.NewInt(-4)), "[x + 1 x + 1 x - 1 x - 1]", "-4"},
+		{NewInt(5, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1), "[]", "x^10 + 5"},
+		{NewInt(-1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1), "[x + 1 x - 1]", "x^8 + x^6 + x^4 + x^2 + 1"},
+	}
+	for i, c := range cases {
+		factors, rest := c.p.LinearFactors()
+		if got := fmt.Sprint(factors); got != c.factors || rest.String() != c.rest {
+			t.Errorf("case %d: LinearFactors() on %q == %v, %q, want %v, %q", i, c.p, got, rest, c.factors, c.rest)
+		}
+		prod := rest
+		for _, f := range factors {
+			prod = prod.Mul(f)
+		}
+		if prod.String() != c.p.String() {
+			t.Errorf("case %d: product of LinearFactors() on %q == %q", i, c.p, prod)
+		}
+	}
+}
+
+// Tests finding the rational roots of integer polynomials.
+func TestRationalRoots(t *testing.T) {
+	cases := []struct {
+		p    IntPoly
+		want string
+	}{
+		{IntPoly{}, "[]"},
+		{NewInt(1, 0, 1), "[]"},
+		{NewInt(-6, 11, -6, 1), "[1/1 2/1 3/1]"},
+		{NewInt(0, -1, 0, 4), "[-1/2 0/1 1/2]"},
+		{NewInt(-12, 1).Mul(NewInt(5, 36)).Mul(NewInt(-7, 0, 1)), "[-5/36 12/1]"},
+		// Large but 64 bit coefficients.
+		{NewInt(-1000000007, 998244353), "[1000000007/998244353]"},
+	}
+	for i, c := range cases {
+		if got := fmt.Sprint(c.p.RationalRoots()); got != c.want {
+			t.Errorf("case %d: RationalRoots() on %q == %v, want %v", i, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests enumeration of divisors.
+func TestDivisorsUint64(t *testing.T) {
+	cases := []struct {
+		n    uint64
+		want string
+	}{
+		{1, "[1]"},
+		{12, "[1 2 3 4 6 12]"},
+		{49, "[1 7 49]"},
+		{1 << 4, "[1 2 4 8 16]"},
+		{2 * 3 * 1000000007, "[1 2 3 6 1000000007 2000000014 3000000021 6000000042]"},
+	}
+	for i, c := range cases {
+		if got := fmt.Sprint(divisorsUint64(c.n)); got != c.want {
+			t.Errorf("case %d: divisorsUint64(%d) == %v, want %v", i, c.n, got, c.want)
+		}
+	}
+}
+
+// Returns a string listing factors with their multiplicities.
+func factorString(c fmt.Stringer, factors []IntFactor) string {
+	s := c.String()
+	for _, f := range factors {
+		s += fmt.Sprintf(" (%v)^%d", f.Poly, f.Mult)
+	}
+	return s
+}
+
+// Tests factorization of integer polynomials into irreducible factors.
+func TestIntPolyFactor(t *testing.T) {
+	cases := []struct {
+		p    IntPoly
+		want string
+	}{
+		{IntPoly{}, "0"},
+		{NewInt(-7), "-7"},
+		{NewInt(-6, 4), "2 (2x - 3)^1"},
+		{NewInt(-1, 0, 0, 0, 1), "1 (x - 1)^1 (x + 1)^1 (x^2 + 1)^1"},
+		{NewInt(0, 0, 3, 3), "3 (x)^2 (x + 1)^1"},
+		{NewInt(-2, 0, 1).Mul(NewInt(-3, 0, 1)), "1 (x^2 - 3)^1 (x^2 - 2)^1"},
+		{NewInt(1, 1, 1).Mul(NewInt(1, 1, 1)).Mul(NewInt(-1, 2)).Scale(big.NewInt(-5)), "-5 (2x - 1)^1 (x^2 + x + 1)^2"},
+		// x^4 + 1 is irreducible over the integers, but splits modulo
+		// every prime.
+		{NewInt(1, 0, 0, 0, 1), "1 (x^4 + 1)^1"},
+		{NewInt(-1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1), "1 (x - 1)^1 (x + 1)^1 (x^2 - x + 1)^1 (x^2 + 1)^1 (x^2 + x + 1)^1 (x^4 - x^2 + 1)^1"},
+		// Swinnerton-Dyer polynomial for 2 and 3, also irreducible but
+		// split modulo every prime.
+		{NewInt(1, 0, -10, 0, 1), "1 (x^4 - 10x^2 + 1)^1"},
+		{NewInt(5, -1, 0, 4).Mul(NewInt(-1, -1, 0, 0, 0, 1)).Mul(NewInt(-1, -1, 0, 0, 0, 1)), "1 (4x^3 - x + 5)^1 (x^5 - x - 1)^2"},
+		{NewInt(-1, 1).Mul(NewInt(-1, 1)).Mul(NewInt(-1, 1)).Mul(NewInt(2, 0, 1)).Mul(NewInt(2, 0, 1)), "1 (x - 1)^3 (x^2 + 2)^2"},
+	}
+	for i, c := range cases {
+		if got := factorString(c.p.Factor()); got != c.want {
+			t.Errorf("case %d: Factor() on %q == %s, want %s", i, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests that the product of the factors of a large polynomial is the
+// polynomial.
+func TestIntPolyFactorProduct(t *testing.T) {
+	p := NewInt(123456789, -987654321, 1).Mul(NewInt(-31, 0, 0, 17)).Mul(NewInt(1, 2, 3, 4, 5, 6, 7, 8)).Mul(NewInt(-100003, 99991))
+	c, factors := p.Factor()
+	if len(factors) != 4 {
+		t.Errorf("Factor() on %q == %s, want 4 factors", p, factorString(c, factors))
+	}
+	prod := IntPoly{[]*big.Int{c}}
+	for _, f := range factors {
+		for i := 0; i < f.Mult; i++ {
+			prod = prod.Mul(f.Poly)
+		}
+	}
+	if prod.String() != p.String() {
+		t.Errorf("product of Factor() on %q == %q", p, prod)
+	}
+}
+
+// Tests factorization of rational polynomials.
+func TestRatPolyFactor(t *testing.T) {
+	cases := []struct {
+		p    RatPoly
+		want string
+	}{
+		{RatPoly{}, "0/1"},
+		{NewRat(big.NewRat(-1, 4), big.NewRat(0, 1), big.NewRat(1, 1)), "1/4 (2x - 1)^1 (2x + 1)^1"},
+		{NewRat(big.NewRat(1, 3), big.NewRat(2, 3), big.NewRat(1, 3)), "1/3 (x + 1)^2"},
+		{NewRat(big.NewRat(3, 2), big.NewRat(0, 1), big.NewRat(-3, 4)), "-3/4 (x^2 - 2)^1"},
+	}
+	for i, c := range cases {
+		if got := factorString(c.p.Factor()); got != c.want {
+			t.Errorf("case %d: Factor() on %q == %s, want %s", i, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests factorization over GF(p) into irreducible factors.
+func TestFactorSquareFreeGF(t *testing.T) {
+	cases := []struct {
+		f GFPoly
+		n int
+	}{
+		{NewGF(5, 1, 0, 1), 2},
+		{NewGF(3, 1, 0, 1), 1},
+		{NewGF(7, 6, 0, 0, 0, 0, 0, 0, 0, 1), 5},
+		{NewGF(17, 1, 0, 0, 0, 0, 0, 0, 0, 1), 8},
+		{NewGF(1000003, 5, 3, 0, 1).Mul(NewGF(1000003, 7, 0, 1)).Mul(NewGF(1000003, 2, 1)), 4},
+	}
+	for i, c := range cases {
+		got := c.f.factorSquareFree(rand.New(rand.NewSource(1)))
+		if len(got) != c.n {
+			t.Errorf("case %d: factorSquareFree() on %q == %v, want %d factors", i, c.f, got, c.n)
+		}
+		prod := NewGF(c.f.p, 1)
+		for _, g := range got {
+			prod = prod.Mul(g)
+			if !g.IsIrreducible() {
+				t.Errorf("case %d: factorSquareFree() on %q has reducible factor %q", i, c.f, g)
+			}
+		}
+		if prod.String() != c.f.String() {
+			t.Errorf("case %d: product of factorSquareFree() on %q == %q", i, c.f, prod)
+		}
+	}
+}
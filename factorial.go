@@ -0,0 +1,102 @@
+package poly
+
+// Creates the falling factorial polynomial x(x-1)(x-2)...(x-n+1) of degree n.
+// For n = 0 it is 1.
+// Panics if n is negative.
+func FallingFactorial(n int) Poly {
+	if n < 0 {
+		panic("poly: negative degree")
+	}
+	return New(stirling1(n)[n]...)
+}
+
+// Creates the rising factorial polynomial x(x+1)(x+2)...(x+n-1) of degree n.
+// For n = 0 it is 1.
+// Panics if n is negative.
+func RisingFactorial(n int) Poly {
+	if n < 0 {
+		panic("poly: negative degree")
+	}
+	// x^(rising n) = (-1)^n (-x)^(falling n).
+	s := stirling1(n)[n]
+	c := make([]float64, n+1)
+	for k, sk := range s {
+		if (n-k)%2 == 1 {
+			sk = -sk
+		}
+		c[k] = sk
+	}
+	return New(c...)
+}
+
+// Computes the coefficients of a polynomial in the falling factorial basis.
+// The result c satisfies p(x) = sum of c[k] * FallingFactorial(k) and has
+// one coefficient per coefficient of p. It is computed with the Stirling
+// numbers of the second kind S(n,k), since x^n = sum of S(n,k) times the k-th
+// falling factorial. In this basis the forward difference p(x+1) - p(x) has
+// coefficients (k+1) c[k+1], which makes it the natural basis for finite
+// difference calculus and for summing polynomials over integers.
+func (p Poly) ToFallingFactorial() []float64 {
+	pco := p.co()
+	n := len(pco) - 1
+	s := stirling2(n)
+	c := make([]float64, n+1)
+	for m, pm := range pco {
+		for k := 0; k <= m; k++ {
+			c[k] += pm * s[m][k]
+		}
+	}
+	return c
+}
+
+// Creates a polynomial from its coefficients in the falling factorial basis,
+// the inverse of ToFallingFactorial. It is computed with the signed Stirling
+// numbers of the first kind. No coefficients creates the zero polynomial.
+func FromFallingFactorial(c []float64) Poly {
+	if len(c) == 0 {
+		return Poly{}
+	}
+	n := len(c) - 1
+	s := stirling1(n)
+	q := make([]float64, n+1)
+	for k, ck := range c {
+		for m := 0; m <= k; m++ {
+			q[m] += ck * s[k][m]
+		}
+	}
+	return normalized(q)
+}
+
+// Returns the signed Stirling numbers of the first kind s(i,k) for i up to
+// n, which are the coefficients of the falling factorials.
+func stirling1(n int) [][]float64 {
+	s := make([][]float64, n+1)
+	s[0] = []float64{1}
+	for i := 1; i <= n; i++ {
+		// x^(falling i) = (x - (i-1)) x^(falling i-1).
+		s[i] = make([]float64, i+1)
+		for k := 1; k <= i; k++ {
+			s[i][k] = s[i-1][k-1]
+			if k < i {
+				s[i][k] -= float64(i-1) * s[i-1][k]
+			}
+		}
+	}
+	return s
+}
+
+// Returns the Stirling numbers of the second kind S(i,k) for i up to n.
+func stirling2(n int) [][]float64 {
+	s := make([][]float64, n+1)
+	s[0] = []float64{1}
+	for i := 1; i <= n; i++ {
+		s[i] = make([]float64, i+1)
+		for k := 1; k <= i; k++ {
+			s[i][k] = s[i-1][k-1]
+			if k < i {
+				s[i][k] += float64(k) * s[i-1][k]
+			}
+		}
+	}
+	return s
+}
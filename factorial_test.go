@@ -0,0 +1,93 @@
+package poly
+
+import (
+	"math"
+	"testing"
+)
+
+// Tests that falling and rising factorial polynomials are generated
+// correctly.
+func TestFactorialPolys(t *testing.T) {
+	cases := []struct {
+		n               int
+		falling, rising Poly
+	}{
+		{0, New(1), New(1)},
+		{1, New(0, 1), New(0, 1)},
+		{2, New(0, -1, 1), New(0, 1, 1)},
+		{3, New(0, 2, -3, 1), New(0, 2, 3, 1)},
+		{4, FromRoots(0, 1, 2, 3), FromRoots(0, -1, -2, -3)},
+	}
+	for i, c := range cases {
+		if got := FallingFactorial(c.n); !equalCoeffs(got, c.falling) {
+			t.Errorf("case %d: FallingFactorial(%d) == %q, want %q", i, c.n, got, c.falling)
+		}
+		if got := RisingFactorial(c.n); !equalCoeffs(got, c.rising) {
+			t.Errorf("case %d: RisingFactorial(%d) == %q, want %q", i, c.n, got, c.rising)
+		}
+	}
+}
+
+// Tests conversion to the falling factorial basis.
+func TestToFallingFactorial(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		want []float64
+	}{
+		{Poly{}, []float64{0}},
+		{New(5), []float64{5}},
+		{New(0, 0, 1), []float64{0, 1, 1}},
+		{New(0, 0, 0, 1), []float64{0, 1, 3, 1}},
+		{New(0, 0, 0, 0, 1), []float64{0, 1, 7, 6, 1}},
+		{FallingFactorial(3), []float64{0, 0, 0, 1}},
+		{New(2, -1, 3), []float64{2, 2, 3}},
+	}
+	for i, c := range cases {
+		got := c.p.ToFallingFactorial()
+		ok := len(got) == len(c.want)
+		for k := 0; ok && k < len(got); k++ {
+			ok = math.Abs(got[k]-c.want[k]) <= 0.00001
+		}
+		if !ok {
+			t.Errorf("case %d: ToFallingFactorial() on %q == %v, want %v", i, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests conversion from the falling factorial basis and the forward
+// difference rule.
+func TestFromFallingFactorial(t *testing.T) {
+	if got := FromFallingFactorial(nil); !comparePoly(got, Poly{}) {
+		t.Errorf("FromFallingFactorial(nil) == %q, want %q", got, Poly{})
+	}
+	p := New(1, -2, 0.5, 3, -1, 0.25)
+	c := p.ToFallingFactorial()
+	if got := FromFallingFactorial(c); !comparePoly(got, p) {
+		t.Errorf("FromFallingFactorial(ToFallingFactorial()) == %q, want %q", got, p)
+	}
+	d := make([]float64, len(c)-1)
+	for k := range d {
+		d[k] = float64(k+1) * c[k+1]
+	}
+	if got, want := FromFallingFactorial(d), p.Shift(1).Sub(p); !comparePoly(got, want) {
+		t.Errorf("forward difference == %q, want %q", got, want)
+	}
+}
+
+// Tests that the factorial generators panic on negative degrees.
+func TestFactorialPanic(t *testing.T) {
+	gens := map[string]func(int) Poly{
+		"FallingFactorial": FallingFactorial,
+		"RisingFactorial":  RisingFactorial,
+	}
+	for name, gen := range gens {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s(-1) did not panic", name)
+				}
+			}()
+			gen(-1)
+		}()
+	}
+}
@@ -0,0 +1,151 @@
+package poly
+
+import (
+	"fmt"
+	"math"
+)
+
+// Relative size below which a diagonal entry of the triangular factor marks
+// a least squares problem as rank deficient.
+const fitTol = 1e-13
+
+// Computes the polynomial of degree at most deg that best fits the points
+// (xs[i], ys[i]) in the least squares sense.
+// The xs are first mapped affinely onto [-1, 1] and the Vandermonde system in
+// the mapped variable is solved by Householder QR factorization, which avoids
+// the squared condition number of the normal equations. The solution is then
+// mapped back to the original variable.
+// Returns an error if xs and ys differ in length, if deg is negative, or if
+// there are fewer than deg+1 distinct xs.
+func Fit(xs, ys []float64, deg int) (Poly, error) {
+	return fit(xs, ys, nil, deg)
+}
+
+// Computes the polynomial of degree at most deg that minimizes the weighted
+// sum of squared residuals, sum of ws[i] * (p(xs[i]) - ys[i])^2.
+// For measurements with known standard deviations s[i], the weights are
+// usually 1/s[i]^2. Points with zero weight are ignored. The fit is computed
+// as by Fit, with each row of the Vandermonde system scaled by sqrt(ws[i]).
+// Returns an error if xs, ys and ws differ in length, if a weight is negative
+// or not finite, if deg is negative, or if there are fewer than deg+1 distinct
+// xs with positive weight.
+func FitWeighted(xs, ys, ws []float64, deg int) (Poly, error) {
+	if len(ws) != len(xs) {
+		return Poly{}, fmt.Errorf("poly: %d x values but %d weights", len(xs), len(ws))
+	}
+	for _, w := range ws {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			return Poly{}, fmt.Errorf("poly: invalid weight %g", w)
+		}
+	}
+	return fit(xs, ys, ws, deg)
+}
+
+// Computes a least squares fit, weighted unless ws is nil.
+func fit(xs, ys, ws []float64, deg int) (Poly, error) {
+	if len(xs) != len(ys) {
+		return Poly{}, fmt.Errorf("poly: %d x values but %d y values", len(xs), len(ys))
+	}
+	if deg < 0 {
+		return Poly{}, fmt.Errorf("poly: negative degree %d", deg)
+	}
+	if len(xs) < deg+1 {
+		return Poly{}, fmt.Errorf("poly: %d points cannot determine a polynomial of degree %d", len(xs), deg)
+	}
+	c, h := fitCenter(xs)
+	a := make([][]float64, deg+1)
+	for j := range a {
+		a[j] = make([]float64, len(xs))
+	}
+	b := make([]float64, len(ys))
+	for i, x := range xs {
+		t := (x - c) / h
+		v := 1.0
+		if ws != nil {
+			v = math.Sqrt(ws[i])
+		}
+		b[i] = v * ys[i]
+		for j := range a {
+			a[j][i] = v
+			v *= t
+		}
+	}
+	q, err := lstsq(a, b)
+	if err != nil {
+		return Poly{}, err
+	}
+	return New(q...).Shift(-c / h).ScaleVar(1 / h), nil
+}
+
+// Returns the center and half width of the range of xs, using a half width
+// of 1 if all the xs are equal.
+func fitCenter(xs []float64) (c, h float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, x := range xs {
+		lo = math.Min(lo, x)
+		hi = math.Max(hi, x)
+	}
+	c, h = (lo+hi)/2, (hi-lo)/2
+	if h == 0 {
+		h = 1
+	}
+	return c, h
+}
+
+// Solves the least squares problem min |Ax - b| by Householder QR
+// factorization, where a holds the columns of A. Both a and b are
+// overwritten. Returns an error if A is numerically rank deficient.
+func lstsq(a [][]float64, b []float64) ([]float64, error) {
+	n, m := len(a), len(b)
+	var scale float64
+	for _, col := range a {
+		scale = math.Max(scale, norm(col))
+	}
+	for k := 0; k < n; k++ {
+		col := a[k]
+		alpha := norm(col[k:])
+		if alpha <= fitTol*scale {
+			return nil, fmt.Errorf("poly: least squares problem is rank deficient")
+		}
+		if col[k] > 0 {
+			alpha = -alpha
+		}
+		// The reflector is I - 2vv'/v'v with v = col[k:] - alpha*e1, which
+		// maps col[k:] to alpha*e1.
+		col[k] -= alpha
+		vv := -2 * alpha * col[k]
+		reflect := func(y []float64) {
+			var s float64
+			for i := k; i < m; i++ {
+				s += col[i] * y[i]
+			}
+			s *= 2 / vv
+			for i := k; i < m; i++ {
+				y[i] -= s * col[i]
+			}
+		}
+		for j := k + 1; j < n; j++ {
+			reflect(a[j])
+		}
+		reflect(b)
+		col[k] = alpha
+	}
+	x := make([]float64, n)
+	for k := n - 1; k >= 0; k-- {
+		s := b[k]
+		for j := k + 1; j < n; j++ {
+			s -= a[j][k] * x[j]
+		}
+		x[k] = s / a[k][k]
+	}
+	return x, nil
+}
+
+// Returns the Euclidean norm of a vector, avoiding overflow and underflow.
+func norm(v []float64) float64 {
+	var s float64
+	for _, x := range v {
+		s = math.Hypot(s, x)
+	}
+	return s
+}
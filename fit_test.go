@@ -0,0 +1,116 @@
+package poly
+
+import (
+	"math"
+	"testing"
+)
+
+// Tests that least squares fits are computed correctly.
+func TestFit(t *testing.T) {
+	cases := []struct {
+		xs, ys []float64
+		deg    int
+		want   Poly
+	}{
+		{[]float64{1, 2, 3}, []float64{2, 4, 9}, 0, New(5)},
+		{[]float64{0, 1, 2, 3}, []float64{0, 1, 1, 2}, 1, New(0.1, 0.6)},
+		{[]float64{0, 1}, []float64{1, 3}, 1, New(1, 2)},
+		{[]float64{-1, 0, 1, 2}, []float64{1, 0, 1, 4}, 2, New(0, 0, 1)},
+		{[]float64{5, 5, 5}, []float64{1, 2, 6}, 0, New(3)},
+		{[]float64{-2, -1, 0, 1, 2}, []float64{0, 1, 2, 1, 0}, 2, New(58.0/35, 0, -3.0/7)},
+	}
+	for i, c := range cases {
+		got, err := Fit(c.xs, c.ys, c.deg)
+		if err != nil {
+			t.Errorf("case %d: Fit(%v, %v, %d) returned error %v", i, c.xs, c.ys, c.deg, err)
+			continue
+		}
+		if !comparePoly(got, c.want) {
+			t.Errorf("case %d: Fit(%v, %v, %d) == %q, want %q", i, c.xs, c.ys, c.deg, got, c.want)
+		}
+	}
+}
+
+// Tests that a fit far from the origin is accurate.
+func TestFitConditioning(t *testing.T) {
+	q := New(1, -2, 0.5, 3, -1, 0.25)
+	var xs, ys []float64
+	for i := 0; i <= 40; i++ {
+		x := 10 + float64(i)/20
+		xs = append(xs, x)
+		ys = append(ys, q.Eval(x-11))
+	}
+	got, err := Fit(xs, ys, 5)
+	if err != nil {
+		t.Fatalf("Fit() returned error %v", err)
+	}
+	if got := got.Shift(11); !comparePoly(got, q) {
+		t.Errorf("Fit().Shift(11) == %q, want %q", got, q)
+	}
+}
+
+// Tests that invalid fitting problems are rejected.
+func TestFitError(t *testing.T) {
+	cases := []struct {
+		xs, ys []float64
+		deg    int
+	}{
+		{nil, nil, 0},
+		{[]float64{1, 2}, []float64{1}, 0},
+		{[]float64{1, 2}, []float64{1, 2}, -1},
+		{[]float64{1, 2}, []float64{1, 2}, 2},
+		{[]float64{1, 1, 1}, []float64{1, 2, 3}, 1},
+		{[]float64{0, 1, 0, 1}, []float64{1, 2, 3, 4}, 2},
+	}
+	for i, c := range cases {
+		if got, err := Fit(c.xs, c.ys, c.deg); err == nil {
+			t.Errorf("case %d: Fit(%v, %v, %d) == %q, want error", i, c.xs, c.ys, c.deg, got)
+		}
+	}
+}
+
+// Tests that weighted least squares fits are computed correctly.
+func TestFitWeighted(t *testing.T) {
+	cases := []struct {
+		xs, ys, ws []float64
+		deg        int
+		want       Poly
+	}{
+		{[]float64{1, 2, 3}, []float64{2, 4, 9}, []float64{1, 1, 1}, 0, New(5)},
+		{[]float64{1, 2, 3}, []float64{2, 4, 9}, []float64{1, 0, 3}, 0, New(7.25)},
+		{[]float64{0, 1, 2, 3}, []float64{0, 1, 1, 2}, []float64{2, 2, 2, 2}, 1, New(0.1, 0.6)},
+		// A zero weight removes the outlier.
+		{[]float64{0, 1, 2, 3}, []float64{1, 3, 100, 7}, []float64{1, 1, 0, 1}, 1, New(1, 2)},
+		{[]float64{-1, 0, 1}, []float64{1, 0, 3}, []float64{1, 2, 1}, 1, New(1, 1)},
+	}
+	for i, c := range cases {
+		got, err := FitWeighted(c.xs, c.ys, c.ws, c.deg)
+		if err != nil {
+			t.Errorf("case %d: FitWeighted(%v, %v, %v, %d) returned error %v", i, c.xs, c.ys, c.ws, c.deg, err)
+			continue
+		}
+		if !comparePoly(got, c.want) {
+			t.Errorf("case %d: FitWeighted(%v, %v, %v, %d) == %q, want %q", i, c.xs, c.ys, c.ws, c.deg, got, c.want)
+		}
+	}
+}
+
+// Tests that invalid weighted fitting problems are rejected.
+func TestFitWeightedError(t *testing.T) {
+	cases := []struct {
+		xs, ys, ws []float64
+		deg        int
+	}{
+		{[]float64{1, 2}, []float64{1, 2}, []float64{1}, 0},
+		{[]float64{1, 2}, []float64{1, 2}, []float64{1, -1}, 0},
+		{[]float64{1, 2}, []float64{1, 2}, []float64{1, math.NaN()}, 0},
+		{[]float64{1, 2}, []float64{1, 2}, []float64{1, math.Inf(1)}, 0},
+		{[]float64{1, 2, 3}, []float64{1, 2, 3}, []float64{1, 0, 0}, 1},
+		{[]float64{1, 2}, []float64{1, 2}, []float64{0, 0}, 0},
+	}
+	for i, c := range cases {
+		if got, err := FitWeighted(c.xs, c.ys, c.ws, c.deg); err == nil {
+			t.Errorf("case %d: FitWeighted(%v, %v, %v, %d) == %q, want error", i, c.xs, c.ys, c.ws, c.deg, got)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package poly
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Describes how to write a polynomial as a sum of terms.
+type termFormat struct {
+	// Writes a coefficient.
+	coeff func(float64) string
+	// Terms whose coefficients are smaller in magnitude than min are omitted.
+	min float64
+	// Name of the variable, "x" if empty.
+	variable string
+	// Writes terms with zero coefficients rather than omitting them.
+	showZero bool
+	// Writes terms lowest order first.
+	ascending bool
+	// Writes exponents in braces for LaTeX.
+	latex bool
+	// Added to each exponent when writing, so that Laurent polynomials can
+	// be written from their coefficients.
+	shift int
+}
+
+// Formats the polynomial as a sum of terms, highest order first unless the
+// format is ascending.
+// Terms whose coefficients are zero or smaller in magnitude than the minimum
+// are omitted, unless every term would be omitted in which case the constant
+// term is written.
+func (f termFormat) format(p Poly) string {
+	var buffer bytes.Buffer
+
+	pco := p.co()
+	plen := len(pco)
+	variable := f.variable
+	if variable == "" {
+		variable = "x"
+	}
+
+	omit := func(e int) bool {
+		absc := math.Abs(pco[e])
+		return !f.showZero && (absc == 0 || absc < f.min)
+	}
+	// If every term would be omitted, write the constant term alone.
+	all := true
+	for e := range pco {
+		all = all && omit(e)
+	}
+
+	first := true
+	for i := 0; i < plen; i++ {
+		e := plen - 1 - i
+		if f.ascending {
+			e = i
+		}
+		if omit(e) && !(all && e == 0) {
+			continue
+		}
+		absc := math.Abs(pco[e])
+
+		c := pco[e]
+		if !first {
+			if c < 0 {
+				buffer.WriteString(" - ")
+			} else {
+				buffer.WriteString(" + ")
+			}
+			c = absc
+		}
+		x := e + f.shift
+		if absc != 1.0 || x == 0 {
+			buffer.WriteString(f.coeff(c))
+		} else if c == -1.0 && first {
+			buffer.WriteString("-")
+		}
+		if x != 0 {
+			buffer.WriteString(variable)
+			if x != 1 {
+				if f.latex {
+					buffer.WriteString(fmt.Sprintf("^{%d}", x))
+				} else {
+					buffer.WriteString(fmt.Sprintf("^%d", x))
+				}
+			}
+		}
+		first = false
+	}
+	return buffer.String()
+}
+
+// FormatOpts controls how a polynomial is written by Format.
+// The zero value writes the terms highest order first in the variable x, with
+// coefficients rounded to integers.
+type FormatOpts struct {
+	// Name of the variable. Defaults to "x" if empty.
+	Var string
+	// Number of digits after the decimal point in each coefficient. A
+	// negative value uses the fewest digits that represent each coefficient
+	// exactly.
+	Prec int
+	// Writes terms with zero coefficients. Otherwise terms whose
+	// coefficients round to zero at the given precision are omitted.
+	ShowZero bool
+	// Writes terms lowest order first.
+	Ascending bool
+	// Notation used for coefficients, as for strconv.FormatFloat: 'f' (the
+	// default if zero), 'e', 'E', 'g' or 'G'. With 'e' and 'g' only terms
+	// with zero coefficients are omitted.
+	Notation byte
+}
+
+// Returns a string representing the polynomial according to the options.
+// Example:
+//
+//	opts := poly.FormatOpts{Var: "t", Prec: 2, Ascending: true}
+//	s := opts.Format(poly.New(1, 0, -0.5))
+//
+//	s is "1.00 - 0.50t^2".
+func (opts FormatOpts) Format(p Poly) string {
+	notation := opts.Notation
+	if notation == 0 {
+		notation = 'f'
+	}
+	min := 0.0
+	if notation == 'f' && opts.Prec >= 0 {
+		min = 0.5 * math.Pow(10, -float64(opts.Prec))
+	}
+	return termFormat{
+		coeff: func(c float64) string {
+			return strconv.FormatFloat(c, notation, opts.Prec, 64)
+		},
+		min:       min,
+		variable:  opts.Var,
+		showZero:  opts.ShowZero,
+		ascending: opts.Ascending,
+	}.format(p)
+}
+
+// Returns a LaTeX math mode representation of the polynomial, such as
+// "4x^{4} + 2x^{2} - x - 3".
+// Coefficients are rounded to prec digits after the decimal point, with
+// trailing zeros removed. A negative prec uses the fewest digits that
+// represent each coefficient exactly. Terms that round to zero are omitted.
+func (p Poly) LaTeX(prec int) string {
+	min := 0.0
+	if prec >= 0 {
+		min = 0.5 * math.Pow(10, -float64(prec))
+	}
+	return termFormat{
+		coeff: func(c float64) string {
+			s := strconv.FormatFloat(c, 'f', prec, 64)
+			if strings.Contains(s, ".") {
+				s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+			}
+			if s == "-0" {
+				s = "0"
+			}
+			return s
+		},
+		min:   min,
+		latex: true,
+	}.format(p)
+}
+
+// Implements fmt.Formatter.
+// The %v and %s verbs write the same representation as String, unless a
+// precision is given, in which case it sets the number of digits after the
+// decimal point. The %e, %E, %f, %F, %g and %G verbs write coefficients in the
+// corresponding notation, with the precision defaulting to 6 for %e and %f and
+// to the smallest exact representation for %g. The %q verb writes the quoted
+// String. Widths pad the whole polynomial.
+// Example:
+//
+//	fmt.Sprintf("%.1e", poly.New(-3, 0, 4))
+//
+//	This writes "4.0e+00x^2 - 3.0e+00".
+func (p Poly) Format(f fmt.State, verb rune) {
+	prec, hasPrec := f.Precision()
+	var s string
+	switch verb {
+	case 'v', 's':
+		if hasPrec {
+			s = FormatOpts{Prec: prec}.Format(p)
+		} else {
+			s = p.String()
+		}
+	case 'q':
+		s = strconv.Quote(p.String())
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		notation := byte(verb)
+		if verb == 'F' {
+			notation = 'f'
+		}
+		if !hasPrec {
+			prec = 6
+			if notation == 'g' || notation == 'G' {
+				prec = -1
+			}
+		}
+		s = FormatOpts{Prec: prec, Notation: notation}.Format(p)
+	default:
+		s = fmt.Sprintf("%%!%c(poly.Poly=%s)", verb, p.String())
+	}
+	if w, ok := f.Width(); ok && len(s) < w {
+		pad := strings.Repeat(" ", w-len(s))
+		if f.Flag('-') {
+			s += pad
+		} else {
+			s = pad + s
+		}
+	}
+	fmt.Fprint(f, s)
+}
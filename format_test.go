@@ -0,0 +1,98 @@
+package poly
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Tests that the LaTeX representation is correct.
+func TestLaTeX(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		prec int
+		want string
+	}{
+		{Poly{}, 3, "0"},
+		{New(1.234), 3, "1.234"},
+		{New(1.234), 1, "1.2"},
+		{New(-1.25), -1, "-1.25"},
+		{New(0, 1), 3, "x"},
+		{New(0, -1), 3, "-x"},
+		{New(0, 0, 2.5), 3, "2.5x^{2}"},
+		{New(0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1), 3, "x^{11}"},
+		{New(-3, -1, 2, 0, 4), 3, "4x^{4} + 2x^{2} - x - 3"},
+		{New(0.5, 0.0001, 1), 3, "x^{2} + 0.5"},
+		{New(0.5, 0.0001, 1), -1, "x^{2} + 0.0001x + 0.5"},
+		{New(1.0/3, 2.0/3), 4, "0.6667x + 0.3333"},
+	}
+	for i, c := range cases {
+		if got := c.p.LaTeX(c.prec); got != c.want {
+			t.Errorf("case %d: LaTeX(%d) on %q == %q, want %q", i, c.prec, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests that formatting options are applied.
+func TestFormatOpts(t *testing.T) {
+	p := New(-3, -1, 2, 0, 4)
+	cases := []struct {
+		opts FormatOpts
+		p    Poly
+		want string
+	}{
+		{FormatOpts{}, p, "4x^4 + 2x^2 - x - 3"},
+		{FormatOpts{Prec: 3}, p, "4.000x^4 + 2.000x^2 - x - 3.000"},
+		{FormatOpts{Prec: -1}, New(0.125, 1.5), "1.5x + 0.125"},
+		{FormatOpts{Var: "t", Prec: 1}, p, "4.0t^4 + 2.0t^2 - t - 3.0"},
+		{FormatOpts{Var: "z", Prec: 1, ShowZero: true}, p, "4.0z^4 + 0.0z^3 + 2.0z^2 - z - 3.0"},
+		{FormatOpts{Prec: 1, Ascending: true}, p, "-3.0 - x + 2.0x^2 + 4.0x^4"},
+		{FormatOpts{Prec: 1, Ascending: true, ShowZero: true}, New(0, 1, 0, -2), "0.0 + x + 0.0x^2 - 2.0x^3"},
+		{FormatOpts{Var: "t", Prec: 2, Ascending: true}, New(1, 0, -0.5), "1.00 - 0.50t^2"},
+		{FormatOpts{Prec: 2}, New(1, 0.001, 0.5), "0.50x^2 + 1.00"},
+		{FormatOpts{Prec: 2}, Poly{}, "0.00"},
+		{FormatOpts{Prec: 2, Ascending: true}, New(0.001, 0, 0.001), "0.00"},
+		{FormatOpts{Prec: 2}, New(0.001, 0, 0.001), "0.00"},
+	}
+	for i, c := range cases {
+		if got := c.opts.Format(c.p); got != c.want {
+			t.Errorf("case %d: Format(%q) with %+v == %q, want %q", i, c.p, c.opts, got, c.want)
+		}
+	}
+}
+
+// Tests that fmt verbs control the coefficient notation and precision.
+func TestFormatter(t *testing.T) {
+	p := New(-3, -1, 2.5, 0, 4)
+	cases := []struct {
+		format string
+		p      Poly
+		want   string
+	}{
+		{"%v", p, "4.000x^4 + 2.500x^2 - x - 3.000"},
+		{"%s", p, "4.000x^4 + 2.500x^2 - x - 3.000"},
+		{"%v", Poly{}, "0.000"},
+		{"%.1v", p, "4.0x^4 + 2.5x^2 - x - 3.0"},
+		{"%.6v", New(1.0 / 3), "0.333333"},
+		{"%.0s", p, "4x^4 + 2x^2 - x - 3"},
+		{"%q", New(0, 1), `"x"`},
+		{"%f", New(0.5, 1), "x + 0.500000"},
+		{"%.2f", p, "4.00x^4 + 2.50x^2 - x - 3.00"},
+		{"%.2F", New(0.125), "0.12"},
+		{"%e", New(1234.5), "1.234500e+03"},
+		{"%.1e", New(-3, 0, 4), "4.0e+00x^2 - 3.0e+00"},
+		{"%.2E", New(0, 0.001), "1.00E-03x"},
+		{"%g", New(1e-7, 0.5), "0.5x + 1e-07"},
+		{"%.3G", New(1.0/3, 1e20), "1E+20x + 0.333"},
+		{"%12v", New(0, 1), "           x"},
+		{"%-6.1f|", New(2), "2.0   |"},
+		{"%d", New(1), "%!d(poly.Poly=1.000)"},
+	}
+	for i, c := range cases {
+		if got := fmt.Sprintf(c.format, c.p); got != c.want {
+			t.Errorf("case %d: Sprintf(%q) on %q == %q, want %q", i, c.format, c.p, got, c.want)
+		}
+	}
+	if got, want := fmt.Sprintf("%.1f", []Poly{New(1), New(0, 2)}), "[1.0 2.0x]"; got != want {
+		t.Errorf("Sprintf(%q) on slice == %q, want %q", "%.1f", got, want)
+	}
+}
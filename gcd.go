@@ -0,0 +1,99 @@
+package poly
+
+import "math"
+
+// Relative tolerance below which a remainder coefficient is treated as zero
+// by the Euclidean algorithm.
+const gcdTol = 1e-9
+
+// Computes the greatest common divisor of two polynomials using the Euclidean
+// algorithm.
+// Remainder coefficients smaller than a small tolerance relative to the
+// largest coefficient of p and q are treated as zero, so that common factors
+// are found despite rounding error.
+// The result is monic, unless both p and q are zero, in which case it is zero.
+func (p Poly) GCD(q Poly) Poly {
+	return p.gcd(q, gcdTol)
+}
+
+// Computes the monic greatest common divisor, treating remainder coefficients
+// smaller than tol relative to the largest coefficient of p and q as zero.
+func (p Poly) gcd(q Poly, tol float64) Poly {
+	eps := tol * math.Max(p.maxAbs(), q.maxAbs())
+	a, b := p.chop(eps), q.chop(eps)
+	for !b.isZero() {
+		a, b = b, a.Mod(b).chop(eps)
+	}
+	if a.isZero() {
+		return Poly{}
+	}
+	return a.monic()
+}
+
+// Computes the greatest common divisor of two polynomials along with Bezout
+// coefficients using the extended Euclidean algorithm.
+// Returns g, s and t such that s*p + t*q = g, where g is GCD(p, q). The same
+// tolerance as GCD is applied to the remainders.
+func ExtGCD(p, q Poly) (g, s, t Poly) {
+	eps := gcdTol * math.Max(p.maxAbs(), q.maxAbs())
+	r0, r1 := p.chop(eps), q.chop(eps)
+	s0, s1 := New(1), New(0)
+	t0, t1 := New(0), New(1)
+	for !r1.isZero() {
+		quo, rem := r0.DivMod(r1)
+		r0, r1 = r1, rem.chop(eps)
+		s0, s1 = s1, s0.Sub(quo.Mul(s1))
+		t0, t1 = t1, t0.Sub(quo.Mul(t1))
+	}
+	if r0.isZero() {
+		return Poly{}, Poly{}, Poly{}
+	}
+	k := 1 / r0.Coeff(r0.Deg())
+	return r0.Scale(k), s0.Scale(k), t0.Scale(k)
+}
+
+// Computes the least common multiple of two polynomials as p*q/GCD(p, q).
+// The result is monic, unless either p or q is zero, in which case it is zero.
+func (p Poly) LCM(q Poly) Poly {
+	if p.isZero() || q.isZero() {
+		return Poly{}
+	}
+	return p.Mul(q).Div(p.GCD(q)).monic()
+}
+
+// Returns the largest absolute value of any coefficient.
+func (p Poly) maxAbs() float64 {
+	var m float64
+	for _, c := range p.co() {
+		m = math.Max(m, math.Abs(c))
+	}
+	return m
+}
+
+// Returns the polynomial with all coefficients no larger than eps in
+// magnitude set to zero.
+func (p Poly) chop(eps float64) Poly {
+	pco := p.co()
+	c := make([]float64, len(pco))
+	for i, pc := range pco {
+		if math.Abs(pc) > eps {
+			c[i] = pc
+		}
+	}
+	return normalized(c)
+}
+
+// Reports whether the polynomial is identically zero.
+func (p Poly) isZero() bool {
+	return p.Deg() == 0 && p.Coeff(0) == 0.0
+}
+
+// Returns the polynomial scaled so that its leading coefficient is 1.
+// The zero polynomial is returned unchanged.
+func (p Poly) monic() Poly {
+	lead := p.Coeff(p.Deg())
+	if lead == 0.0 {
+		return p
+	}
+	return p.Scale(1 / lead)
+}
@@ -0,0 +1,82 @@
+package poly
+
+import "testing"
+
+// Tests that greatest common divisors are computed correctly.
+func TestGCD(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		q    Poly
+		want Poly
+	}{
+		{Poly{}, Poly{}, Poly{}},
+		{New(2, 4), Poly{}, New(0.5, 1)},
+		{Poly{}, New(2, 4), New(0.5, 1)},
+		{New(3), New(1, 1), New(1)},
+		{New(2, -3, 1), New(-3, 2, 1), New(-1, 1)},
+		{New(2, -3, 1), New(6, -5, 1), New(-2, 1)},
+		{New(2, -3, 1), New(-2, -1, 1), New(-2, 1)},
+		{New(-2, 2), New(-3, 3), New(-1, 1)},
+		{New(1, 0, 1), New(1, 1), New(1)},
+		// (x-1)^2 (x+2) and its derivative share the factor (x-1).
+		{New(2, -3, 0, 1), New(2, -3, 0, 1).Der(), New(-1, 1)},
+		// (x^2+1)(x-0.1) and (x^2+1)(x+0.3).
+		{New(-0.1, 1, -0.1, 1), New(0.3, 1, 0.3, 1), New(1, 0, 1)},
+	}
+	for i, c := range cases {
+		if got := c.p.GCD(c.q); !comparePoly(got, c.want) {
+			t.Errorf("case %d: GCD(%q) on %q == %q, want %q", i, c.q, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests that least common multiples are computed correctly.
+func TestLCM(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		q    Poly
+		want Poly
+	}{
+		{Poly{}, Poly{}, Poly{}},
+		{New(1, 2), Poly{}, Poly{}},
+		{New(3), New(5), New(1)},
+		{New(2), New(1, 1), New(1, 1)},
+		{New(-1, 1), New(1, 1), New(-1, 0, 1)},
+		{New(2, -3, 1), New(6, -5, 1), New(-6, 11, -6, 1)},
+		{New(-2, 2), New(-3, 3), New(-1, 1)},
+		{New(1, 0, 1), New(1, 0, 1).Mul(New(2, 1)), New(2, 1, 2, 1)},
+	}
+	for i, c := range cases {
+		if got := c.p.LCM(c.q); !comparePoly(got, c.want) {
+			t.Errorf("case %d: LCM(%q) on %q == %q, want %q", i, c.q, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests that the extended Euclidean algorithm produces Bezout coefficients.
+func TestExtGCD(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		q    Poly
+		want Poly
+	}{
+		{Poly{}, Poly{}, Poly{}},
+		{New(2, 4), Poly{}, New(0.5, 1)},
+		{Poly{}, New(2, 4), New(0.5, 1)},
+		{New(3), New(1, 1), New(1)},
+		{New(1, 0, 1), New(1, 1), New(1)},
+		{New(2, -3, 1), New(6, -5, 1), New(-2, 1)},
+		{New(2, -3, 0, 1), New(2, -3, 0, 1).Der(), New(-1, 1)},
+		{New(1, 2, 3, 4, 5), New(-1, 0, 2), New(1)},
+	}
+	for i, c := range cases {
+		g, s, u := ExtGCD(c.p, c.q)
+		if !comparePoly(g, c.want) {
+			t.Errorf("case %d: ExtGCD(%q, %q) == %q, want %q", i, c.p, c.q, g, c.want)
+		}
+		// Higher order terms cancel only up to rounding error.
+		if got := s.Mul(c.p).Add(u.Mul(c.q)); !got.Sub(g).chop(0.00001).isZero() {
+			t.Errorf("case %d: s*p + t*q == %q, want %q", i, got, g)
+		}
+	}
+}
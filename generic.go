@@ -0,0 +1,248 @@
+package poly
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Scalar is the set of coefficient types supported by Of.
+type Scalar interface {
+	float32 | float64 | complex64 | complex128
+}
+
+// Of represents a polynomial of arbitrary degree with coefficients of type T.
+// It provides the core arithmetic of Poly for every Scalar type from a
+// single implementation, for example Of[float32] for memory-bound work or
+// Of[complex128] for polynomials with complex coefficients. Poly remains the
+// float64 type with the full feature set.
+// A zero valued Of is equivalent to 0.
+type Of[T Scalar] struct {
+	coeff []T
+}
+
+// Creates a new polynomial with coefficients of type T.
+// The ith parameter represents the coefficient of x^i.
+// Example:
+//
+//	p := poly.NewOf[complex128](1, 2i, 3)
+//
+//	This represents 1 + 2i*x + 3*x^2
+func NewOf[T Scalar](c ...T) Of[T] {
+	a := make([]T, len(c))
+	copy(a, c)
+	return normalizedOf(a)
+}
+
+// Returns the coefficient array, which is {0} for the zero value.
+func (p Of[T]) co() []T {
+	if len(p.coeff) == 0 {
+		return []T{0}
+	}
+	return p.coeff
+}
+
+// Returns a polynomial with the given coefficients, removing zero
+// coefficients of terms with degree greater than 0.
+func normalizedOf[T Scalar](c []T) Of[T] {
+	i := len(c) - 1
+	for i > 0 && c[i] == 0 {
+		i--
+	}
+	if i < 0 {
+		return Of[T]{}
+	}
+	return Of[T]{c[0 : i+1]}
+}
+
+// Converts a float64 to any Scalar type. A non-constant integer or float
+// cannot be converted to a complex type directly, so the conversion is
+// dispatched on the type.
+func scalar[T Scalar](x float64) T {
+	var t T
+	switch p := any(&t).(type) {
+	case *float32:
+		*p = float32(x)
+	case *float64:
+		*p = x
+	case *complex64:
+		*p = complex(float32(x), 0)
+	case *complex128:
+		*p = complex(x, 0)
+	}
+	return t
+}
+
+// Returns the highest degree of the polynomial's highest order term.
+func (p Of[T]) Deg() int {
+	return len(p.co()) - 1
+}
+
+// Returns the coefficient of the ith order term.
+func (p Of[T]) Coeff(i int) T {
+	if i < 0 || i > p.Deg() {
+		return 0
+	}
+	return p.co()[i]
+}
+
+// Returns a copy of the coefficients, where the ith element is the
+// coefficient of x^i. The result has length Deg()+1, and is {0} for the zero
+// polynomial, as for Poly.Coefficients.
+func (p Of[T]) Coefficients() []T {
+	pco := p.co()
+	c := make([]T, len(pco))
+	copy(c, pco)
+	return c
+}
+
+// Evaluates a polynomial at the given point x using Horner's method.
+func (p Of[T]) Eval(x T) T {
+	pco := p.co()
+	var n T
+	for i := len(pco) - 1; i >= 0; i-- {
+		n = n*x + pco[i]
+	}
+	return n
+}
+
+// Adds a polynomial to another polynomial.
+// Returns p+q.
+func (p Of[T]) Add(q Of[T]) Of[T] {
+	pco, qco := p.co(), q.co()
+	if len(pco) < len(qco) {
+		pco, qco = qco, pco
+	}
+	c := make([]T, len(pco))
+	copy(c, pco)
+	for i, qc := range qco {
+		c[i] += qc
+	}
+	return normalizedOf(c)
+}
+
+// Subtracts a polynomial from another polynomial.
+// Returns p-q.
+func (p Of[T]) Sub(q Of[T]) Of[T] {
+	return p.Add(q.Neg())
+}
+
+// Multiplies a polynomial by a scalar.
+// Returns k*p.
+func (p Of[T]) Scale(k T) Of[T] {
+	pco := p.co()
+	c := make([]T, len(pco))
+	for i, pc := range pco {
+		c[i] = k * pc
+	}
+	return normalizedOf(c)
+}
+
+// Negates a polynomial.
+// Returns -p.
+func (p Of[T]) Neg() Of[T] {
+	return p.Scale(-1)
+}
+
+// Multiplies a polynomial by another polynomial.
+// Returns p*q.
+func (p Of[T]) Mul(q Of[T]) Of[T] {
+	pco, qco := p.co(), q.co()
+	c := make([]T, len(pco)+len(qco)-1)
+	for i, pc := range pco {
+		for j, qc := range qco {
+			c[i+j] += pc * qc
+		}
+	}
+	return normalizedOf(c)
+}
+
+// Divides a polynomial by another polynomial using Euclidean long division.
+// Returns the quotient and remainder of p/q, such that p = quo*q + rem and the
+// degree of rem is less than the degree of q.
+// If q is zero, the quotient is zero and the remainder is p.
+func (p Of[T]) DivMod(q Of[T]) (quo, rem Of[T]) {
+	qco := q.co()
+	d := len(qco) - 1
+	lead := qco[d]
+	pco := p.co()
+	if lead == 0 || len(pco) <= d {
+		return Of[T]{}, p
+	}
+	r := make([]T, len(pco))
+	copy(r, pco)
+	c := make([]T, len(pco)-d)
+	for i := len(r) - 1; i >= d; i-- {
+		k := r[i] / lead
+		c[i-d] = k
+		for j := 0; j < d; j++ {
+			r[i-d+j] -= k * qco[j]
+		}
+		r[i] = 0
+	}
+	return normalizedOf(c), normalizedOf(r[:d])
+}
+
+// Divides a polynomial by another polynomial.
+// Returns the quotient of p/q, discarding any remainder.
+func (p Of[T]) Div(q Of[T]) Of[T] {
+	quo, _ := p.DivMod(q)
+	return quo
+}
+
+// Computes the remainder of dividing a polynomial by another polynomial.
+// Returns p mod q.
+func (p Of[T]) Mod(q Of[T]) Of[T] {
+	_, rem := p.DivMod(q)
+	return rem
+}
+
+// Computes the derivative of a polynomial.
+func (p Of[T]) Der() Of[T] {
+	pco := p.co()
+	c := make([]T, len(pco)-1)
+	for i := range c {
+		c[i] = pco[i+1] * scalar[T](float64(i+1))
+	}
+	return normalizedOf(c)
+}
+
+// Computes the definite integral of a polynomial.
+// The provided constant k will be used as the 0th order term of the result.
+func (p Of[T]) Int(k T) Of[T] {
+	pco := p.co()
+	c := make([]T, len(pco)+1)
+	c[0] = k
+	for i, pc := range pco {
+		c[i+1] = pc / scalar[T](float64(i+1))
+	}
+	return normalizedOf(c)
+}
+
+// Returns a printable string representing the polynomial, with each nonzero
+// coefficient formatted by fmt's %v verb.
+func (p Of[T]) String() string {
+	var b strings.Builder
+	pco := p.co()
+	for i := len(pco) - 1; i >= 0; i-- {
+		if pco[i] == 0 && (i > 0 || b.Len() > 0) {
+			continue
+		}
+		s := fmt.Sprint(pco[i])
+		switch {
+		case b.Len() == 0:
+		case strings.HasPrefix(s, "-"):
+			b.WriteString(" - ")
+			s = s[1:]
+		default:
+			b.WriteString(" + ")
+		}
+		b.WriteString(s)
+		switch {
+		case i == 1:
+			b.WriteString("x")
+		case i > 1:
+			fmt.Fprintf(&b, "x^%d", i)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,109 @@
+package poly
+
+import (
+	"math/cmplx"
+	"testing"
+)
+
+// Compares two polynomials with complex coefficients.
+func compareOf(p, q Of[complex128]) bool {
+	if p.Deg() != q.Deg() {
+		return false
+	}
+	for i := 0; i <= p.Deg(); i++ {
+		if cmplx.Abs(p.Coeff(i)-q.Coeff(i)) > 0.00001 {
+			return false
+		}
+	}
+	return true
+}
+
+// Tests the arithmetic of polynomials with float32 coefficients.
+func TestOfFloat32(t *testing.T) {
+	p := NewOf[float32](1, -2, 3)
+	q := NewOf[float32](0.5, 1)
+	cases := []struct {
+		name      string
+		got, want Of[float32]
+	}{
+		{"Add", p.Add(q), NewOf[float32](1.5, -1, 3)},
+		{"Sub", p.Sub(q), NewOf[float32](0.5, -3, 3)},
+		{"Sub self", p.Sub(p), Of[float32]{}},
+		{"Scale", p.Scale(2), NewOf[float32](2, -4, 6)},
+		{"Mul", p.Mul(q), NewOf[float32](0.5, 0, -0.5, 3)},
+		{"Div", p.Mul(q).Div(q), p},
+		{"Mod", p.Mod(q), NewOf[float32](2.75)},
+		{"Der", p.Der(), NewOf[float32](-2, 6)},
+		{"Int", p.Int(4), NewOf[float32](4, 1, -1, 1)},
+		{"Der const", NewOf[float32](7).Der(), Of[float32]{}},
+	}
+	for _, c := range cases {
+		if c.got.String() != c.want.String() {
+			t.Errorf("%s == %q, want %q", c.name, c.got, c.want)
+		}
+	}
+	if got, want := p.Eval(2), float32(9); got != want {
+		t.Errorf("Eval(2) == %v, want %v", got, want)
+	}
+}
+
+// Tests the arithmetic of polynomials with complex coefficients.
+func TestOfComplex(t *testing.T) {
+	p := NewOf[complex128](1, 2i, 3)
+	q := NewOf[complex128](-1i, 1)
+	cases := []struct {
+		name      string
+		got, want Of[complex128]
+	}{
+		{"Add", p.Add(q), NewOf[complex128](1-1i, 1+2i, 3)},
+		{"Sub", p.Sub(q), NewOf[complex128](1+1i, -1+2i, 3)},
+		{"Mul", q.Mul(NewOf[complex128](1i, 1)), NewOf[complex128](1, 0, 1)},
+		{"Div", p.Mul(q).Div(q), p},
+		{"Mod", p.Mod(q), NewOf[complex128](-4)},
+		{"Der", p.Der(), NewOf[complex128](2i, 6)},
+		{"Int", p.Der().Int(1), p},
+		{"DivMod by zero", p.Div(Of[complex128]{}), Of[complex128]{}},
+	}
+	for _, c := range cases {
+		if !compareOf(c.got, c.want) {
+			t.Errorf("%s == %q, want %q", c.name, c.got, c.want)
+		}
+	}
+	if got, want := p.Eval(1i), complex(-4, 0); cmplx.Abs(got-want) > 0.00001 {
+		t.Errorf("Eval(i) == %v, want %v", got, want)
+	}
+}
+
+// Tests the string representation of generic polynomials.
+func TestOfString(t *testing.T) {
+	cases := []struct {
+		got, want string
+	}{
+		{Of[float64]{}.String(), "0"},
+		{NewOf[float64](-1.5).String(), "-1.5"},
+		{NewOf[float64](1, 0, -2).String(), "-2x^2 + 1"},
+		{NewOf[float32](0, 2.5).String(), "2.5x"},
+		{NewOf[complex128](1i, 0, 2-1i).String(), "(2-1i)x^2 + (0+1i)"},
+	}
+	for i, c := range cases {
+		if c.got != c.want {
+			t.Errorf("case %d: String() == %q, want %q", i, c.got, c.want)
+		}
+	}
+}
+
+// Tests that the coefficients of a generic polynomial are returned as a copy.
+func TestOfCoefficients(t *testing.T) {
+	p := NewOf[complex128](1i, 0, 2-1i)
+	got := p.Coefficients()
+	if len(got) != 3 || got[0] != 1i || got[2] != 2-1i {
+		t.Errorf("Coefficients() on %q == %v, want [(0+1i) 0 (2-1i)]", p, got)
+	}
+	got[0] = 5
+	if p.Coeff(0) != 1i {
+		t.Errorf("modifying Coefficients() changed %q", p)
+	}
+	if got := (Of[float32]{}).Coefficients(); len(got) != 1 || got[0] != 0 {
+		t.Errorf("Coefficients() on 0 == %v, want [0]", got)
+	}
+}
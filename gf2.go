@@ -0,0 +1,315 @@
+package poly
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+// GF2Poly represents a polynomial with coefficients in GF(2), the field of
+// integers modulo 2. Coefficients are packed into bits, so that bit i of word
+// i/64 is the coefficient of x^i, and addition and multiplication use xor and
+// carry-less multiplication.
+// Reduced modulo an irreducible polynomial of degree m, GF2Poly values
+// represent elements of the field GF(2^m), as used by CRCs, AES-GCM and
+// Reed-Solomon codes.
+// A zero valued GF2Poly is equivalent to 0.
+type GF2Poly struct {
+	w []uint64
+}
+
+// Creates a new polynomial over GF(2) as a sum of powers of x.
+// Each parameter e adds the term x^e. Since coefficients are added modulo 2,
+// a repeated exponent cancels.
+// Example:
+//
+//	p := poly.NewGF2(8, 4, 3, 1, 0)
+//
+//	This represents x^8 + x^4 + x^3 + x + 1
+func NewGF2(exps ...int) GF2Poly {
+	var w []uint64
+	for _, e := range exps {
+		if e < 0 {
+			panic("poly: negative exponent")
+		}
+		for len(w) <= e/64 {
+			w = append(w, 0)
+		}
+		w[e/64] ^= 1 << (e % 64)
+	}
+	return normalizedGF2(w)
+}
+
+// Creates a new polynomial over GF(2) from bits, where bit i of v is the
+// coefficient of x^i.
+func GF2FromUint(v uint64) GF2Poly {
+	return normalizedGF2([]uint64{v})
+}
+
+// Creates a new polynomial over GF(2) from words of bits, where bit i of
+// w[i/64] is the coefficient of x^i.
+func GF2FromWords(w []uint64) GF2Poly {
+	return normalizedGF2(append([]uint64(nil), w...))
+}
+
+// Returns a polynomial with the given words, removing high zero words.
+func normalizedGF2(w []uint64) GF2Poly {
+	i := len(w)
+	for i > 0 && w[i-1] == 0 {
+		i--
+	}
+	return GF2Poly{w[:i]}
+}
+
+// Returns the coefficients of x^0 through x^63 as the bits of a uint64, and
+// whether the polynomial has degree less than 64, so that no coefficients
+// were lost.
+func (f GF2Poly) Uint64() (uint64, bool) {
+	if len(f.w) == 0 {
+		return 0, true
+	}
+	return f.w[0], len(f.w) == 1
+}
+
+// Returns a copy of the coefficients packed into words, where bit i of
+// w[i/64] is the coefficient of x^i.
+func (f GF2Poly) Words() []uint64 {
+	return append([]uint64(nil), f.w...)
+}
+
+// Returns the degree, or -1 for the zero polynomial.
+func (f GF2Poly) deg() int {
+	if len(f.w) == 0 {
+		return -1
+	}
+	n := len(f.w) - 1
+	return 64*n + bits.Len64(f.w[n]) - 1
+}
+
+// Returns the highest degree of the polynomial's highest order term.
+func (f GF2Poly) Deg() int {
+	return max(f.deg(), 0)
+}
+
+// Returns the coefficient of the ith order term, which is 0 or 1.
+func (f GF2Poly) Coeff(i int) uint {
+	if i < 0 || i/64 >= len(f.w) {
+		return 0
+	}
+	return uint(f.w[i/64]>>(i%64)) & 1
+}
+
+// Reports whether the polynomial is identically zero.
+func (f GF2Poly) isZero() bool {
+	return len(f.w) == 0
+}
+
+// Reports whether two polynomials are equal.
+func (f GF2Poly) Equal(g GF2Poly) bool {
+	if len(f.w) != len(g.w) {
+		return false
+	}
+	for i := range f.w {
+		if f.w[i] != g.w[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Evaluates a polynomial at x, which is 0 or 1.
+func (f GF2Poly) Eval(x uint) uint {
+	if x&1 == 0 {
+		return f.Coeff(0)
+	}
+	var n int
+	for _, w := range f.w {
+		n += bits.OnesCount64(w)
+	}
+	return uint(n) & 1
+}
+
+// Adds a polynomial to another polynomial. Over GF(2), subtraction is the
+// same as addition.
+// Returns f+g.
+func (f GF2Poly) Add(g GF2Poly) GF2Poly {
+	a, b := f.w, g.w
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	w := append([]uint64(nil), a...)
+	for i, bw := range b {
+		w[i] ^= bw
+	}
+	return normalizedGF2(w)
+}
+
+// Returns the carry-less product of two words as a 128 bit value.
+func clmul(a, b uint64) (hi, lo uint64) {
+	for b != 0 {
+		i := bits.TrailingZeros64(b)
+		b &= b - 1
+		lo ^= a << i
+		if i > 0 {
+			hi ^= a >> (64 - i)
+		}
+	}
+	return hi, lo
+}
+
+// Multiplies a polynomial by another polynomial using carry-less
+// multiplication.
+// Returns f*g.
+func (f GF2Poly) Mul(g GF2Poly) GF2Poly {
+	if f.isZero() || g.isZero() {
+		return GF2Poly{}
+	}
+	w := make([]uint64, len(f.w)+len(g.w))
+	for i, fw := range f.w {
+		for j, gw := range g.w {
+			hi, lo := clmul(fw, gw)
+			w[i+j] ^= lo
+			w[i+j+1] ^= hi
+		}
+	}
+	return normalizedGF2(w)
+}
+
+// Xors the words of src, shifted left by s bits, into dst.
+func xorShifted(dst, src []uint64, s int) {
+	k, r := s/64, s%64
+	for i, sw := range src {
+		dst[i+k] ^= sw << r
+		if r > 0 && i+k+1 < len(dst) {
+			dst[i+k+1] ^= sw >> (64 - r)
+		}
+	}
+}
+
+// Divides a polynomial by another polynomial using long division.
+// Returns the quotient and remainder of f/g, such that f = quo*g + rem and the
+// degree of rem is less than the degree of g.
+// Panics if g is zero.
+func (f GF2Poly) DivMod(g GF2Poly) (quo, rem GF2Poly) {
+	dg := g.deg()
+	if dg < 0 {
+		panic("poly: division by zero")
+	}
+	df := f.deg()
+	if df < dg {
+		return GF2Poly{}, f
+	}
+	r := append([]uint64(nil), f.w...)
+	q := make([]uint64, (df-dg)/64+1)
+	for d := df; d >= dg; d-- {
+		if r[d/64]>>(d%64)&1 == 1 {
+			xorShifted(r, g.w, d-dg)
+			q[(d-dg)/64] |= 1 << ((d - dg) % 64)
+		}
+	}
+	return normalizedGF2(q), normalizedGF2(r)
+}
+
+// Divides a polynomial by another polynomial.
+// Returns the quotient of f/g, discarding any remainder.
+func (f GF2Poly) Div(g GF2Poly) GF2Poly {
+	quo, _ := f.DivMod(g)
+	return quo
+}
+
+// Computes the remainder of dividing a polynomial by another polynomial.
+// Returns f mod g.
+func (f GF2Poly) Mod(g GF2Poly) GF2Poly {
+	_, rem := f.DivMod(g)
+	return rem
+}
+
+// Computes the greatest common divisor of two polynomials using the Euclidean
+// algorithm. It is zero only if both f and g are zero.
+func (f GF2Poly) GCD(g GF2Poly) GF2Poly {
+	a, b := f, g
+	for !b.isZero() {
+		a, b = b, a.Mod(b)
+	}
+	return a
+}
+
+// Computes the derivative of a polynomial. Over GF(2), the terms of even
+// degree vanish.
+func (f GF2Poly) Der() GF2Poly {
+	w := make([]uint64, len(f.w))
+	for i, fw := range f.w {
+		w[i] = fw >> 1 & 0x5555555555555555
+	}
+	return normalizedGF2(w)
+}
+
+// Multiplies a polynomial by another polynomial modulo m.
+// Returns f*g mod m. Panics if m is zero.
+func (f GF2Poly) MulMod(g, m GF2Poly) GF2Poly {
+	return f.Mul(g).Mod(m)
+}
+
+// Computes the multiplicative inverse of a polynomial modulo m using the
+// extended Euclidean algorithm. When m is irreducible of degree k, this is
+// the inverse in the field GF(2^k).
+// Returns the inverse, and false if none exists because f and m have a
+// common factor. Panics if m is zero.
+func (f GF2Poly) InvMod(m GF2Poly) (GF2Poly, bool) {
+	r0, r1 := m, f.Mod(m)
+	t0, t1 := GF2Poly{}, NewGF2(0)
+	for !r1.isZero() {
+		quo, rem := r0.DivMod(r1)
+		r0, r1 = r1, rem
+		t0, t1 = t1, t0.Add(quo.Mul(t1))
+	}
+	if r0.deg() != 0 {
+		return GF2Poly{}, false
+	}
+	return t0.Mod(m), true
+}
+
+// Raises a polynomial to a non-negative integer power modulo m, reducing
+// after every multiplication so that the exponent may be arbitrarily large.
+// Returns f^n mod m. Panics if m is zero.
+func (f GF2Poly) PowMod(n *big.Int, m GF2Poly) GF2Poly {
+	if n.Sign() < 0 {
+		panic("poly: negative exponent")
+	}
+	r := NewGF2(0).Mod(m)
+	b := f.Mod(m)
+	for i := n.BitLen() - 1; i >= 0; i-- {
+		r = r.MulMod(r, m)
+		if n.Bit(i) == 1 {
+			r = r.MulMod(b, m)
+		}
+	}
+	return r
+}
+
+// Returns a printable string representing the polynomial, such as
+// "x^8 + x^4 + x^3 + x + 1".
+func (f GF2Poly) String() string {
+	var b strings.Builder
+	for i := f.deg(); i >= 0; i-- {
+		if f.Coeff(i) == 0 {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString(" + ")
+		}
+		switch i {
+		case 0:
+			b.WriteString("1")
+		case 1:
+			b.WriteString("x")
+		default:
+			fmt.Fprintf(&b, "x^%d", i)
+		}
+	}
+	if b.Len() == 0 {
+		return "0"
+	}
+	return b.String()
+}
@@ -0,0 +1,99 @@
+package poly
+
+import (
+	"math/big"
+	"testing"
+)
+
+// The polynomial defining the AES field GF(2^8).
+var aesPoly = NewGF2(8, 4, 3, 1, 0)
+
+// Tests arithmetic on polynomials over GF(2).
+func TestGF2Poly(t *testing.T) {
+	f := NewGF2(3, 1, 0)
+	g := NewGF2(1, 0)
+	cases := []struct {
+		name string
+		got  GF2Poly
+		want string
+	}{
+		{"zero", GF2Poly{}, "0"},
+		{"f", f, "x^3 + x + 1"},
+		{"cancel", NewGF2(2, 1, 2), "x"},
+		{"Add", f.Add(g), "x^3"},
+		{"Add self", f.Add(f), "0"},
+		{"Mul", f.Mul(g), "x^4 + x^3 + x^2 + 1"},
+		{"Mul square", g.Mul(g), "x^2 + 1"},
+		{"Div", f.Mul(g).Div(g), "x^3 + x + 1"},
+		{"Mod", NewGF2(5).Mod(f), "x^2 + x + 1"},
+		{"GCD", f.Mul(g).GCD(g.Mul(g)), "x + 1"},
+		{"Der", NewGF2(5, 4, 3, 1).Der(), "x^4 + x^2 + 1"},
+		{"FromUint", GF2FromUint(0x11b), aesPoly.String()},
+	}
+	for _, c := range cases {
+		if got := c.got.String(); got != c.want {
+			t.Errorf("%s == %q, want %q", c.name, got, c.want)
+		}
+	}
+	if v, ok := aesPoly.Uint64(); v != 0x11b || !ok {
+		t.Errorf("Uint64() on %q == %#x, %v, want 0x11b, true", aesPoly, v, ok)
+	}
+	if got, want := f.Eval(1), uint(1); got != want {
+		t.Errorf("Eval(1) on %q == %d, want %d", f, got, want)
+	}
+}
+
+// Tests multi-word polynomials, whose products cross word boundaries.
+func TestGF2PolyWide(t *testing.T) {
+	f := NewGF2(100, 63, 64, 0)
+	g := NewGF2(70, 1)
+	p := f.Mul(g)
+	if got, want := p.String(), "x^170 + x^134 + x^133 + x^101 + x^70 + x^65 + x^64 + x"; got != want {
+		t.Errorf("Mul == %q, want %q", got, want)
+	}
+	quo, rem := p.Add(NewGF2(5)).DivMod(g)
+	if !quo.Equal(f) || !rem.Equal(NewGF2(5)) {
+		t.Errorf("DivMod == %q, %q, want %q, %q", quo, rem, f, NewGF2(5))
+	}
+	if got := p.Deg(); got != 170 {
+		t.Errorf("Deg() == %d, want 170", got)
+	}
+	if _, ok := p.Uint64(); ok {
+		t.Errorf("Uint64() on %q reported no loss", p)
+	}
+}
+
+// Tests field arithmetic in GF(2^8) and GF(2^128).
+func TestGF2PolyField(t *testing.T) {
+	// Examples from FIPS-197.
+	if got := GF2FromUint(0x57).MulMod(GF2FromUint(0x83), aesPoly); !got.Equal(GF2FromUint(0xc1)) {
+		t.Errorf("{57}*{83} == %q, want {c1}", got)
+	}
+	if got, ok := GF2FromUint(0x53).InvMod(aesPoly); !ok || !got.Equal(GF2FromUint(0xca)) {
+		t.Errorf("InvMod({53}) == %q, %v, want {ca}", got, ok)
+	}
+	// Every nonzero element of GF(2^8) has an inverse.
+	for v := uint64(1); v < 256; v++ {
+		a := GF2FromUint(v)
+		inv, ok := a.InvMod(aesPoly)
+		if !ok || !a.MulMod(inv, aesPoly).Equal(NewGF2(0)) {
+			t.Errorf("InvMod(%#x) == %q, %v", v, inv, ok)
+		}
+	}
+	if _, ok := NewGF2(1, 0).InvMod(NewGF2(2, 0)); ok {
+		t.Errorf("InvMod(x + 1) modulo x^2 + 1 succeeded, want failure")
+	}
+	// The GCM field GF(2^128).
+	gcm := NewGF2(128, 7, 2, 1, 0)
+	a := GF2FromWords([]uint64{0x0123456789abcdef, 0xfedcba9876543210})
+	inv, ok := a.InvMod(gcm)
+	if !ok || !a.MulMod(inv, gcm).Equal(NewGF2(0)) {
+		t.Errorf("InvMod(%q) == %q, %v", a, inv, ok)
+	}
+	// a^(2^128 - 1) = 1 for every nonzero a.
+	n := new(big.Int).Lsh(big.NewInt(1), 128)
+	n.Sub(n, big.NewInt(1))
+	if got := a.PowMod(n, gcm); !got.Equal(NewGF2(0)) {
+		t.Errorf("PowMod(2^128 - 1) == %q, want 1", got)
+	}
+}
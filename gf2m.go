@@ -0,0 +1,314 @@
+package poly
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GF2m is the finite field GF(2^m), for m between 1 and 16. Elements are
+// represented as integers below 2^m whose bits are the coefficients of a
+// polynomial over GF(2), reduced modulo the primitive polynomial defining the
+// field. The primitive element alpha is the class of x, so that every nonzero
+// element is a power of alpha, and multiplication uses logarithm tables.
+type GF2m struct {
+	m   int
+	f   GF2Poly
+	exp []uint16
+	log []uint16
+}
+
+// Creates the field GF(2^m) defined by a primitive polynomial f of degree m.
+// Returns an error if f is not primitive or m is not between 1 and 16.
+func NewGF2m(f GF2Poly) (*GF2m, error) {
+	m := f.deg()
+	if m < 1 || m > 16 {
+		return nil, fmt.Errorf("poly: field polynomial %v has degree %d, want 1 to 16", f, m)
+	}
+	if !f.IsPrimitive() {
+		return nil, fmt.Errorf("poly: field polynomial %v is not primitive", f)
+	}
+	n := 1<<m - 1
+	fv, _ := f.Uint64()
+	// The exp table is doubled so that exp[log[a]+log[b]] needs no reduction.
+	gf := &GF2m{m, f, make([]uint16, 2*n), make([]uint16, n+1)}
+	a := uint64(1)
+	for i := 0; i < n; i++ {
+		gf.exp[i] = uint16(a)
+		gf.exp[i+n] = uint16(a)
+		gf.log[a] = uint16(i)
+		a <<= 1
+		if a>>m == 1 {
+			a ^= fv
+		}
+	}
+	return gf, nil
+}
+
+// Returns m, where the field has 2^m elements.
+func (gf *GF2m) Degree() int {
+	return gf.m
+}
+
+// Returns the primitive polynomial defining the field.
+func (gf *GF2m) Modulus() GF2Poly {
+	return gf.f
+}
+
+// Returns the number of nonzero elements, 2^m - 1, which is the order of
+// alpha.
+func (gf *GF2m) order() int {
+	return len(gf.log) - 1
+}
+
+// Adds two field elements. In characteristic 2, this is also subtraction.
+func (gf *GF2m) Add(a, b uint16) uint16 {
+	return a ^ b
+}
+
+// Multiplies two field elements.
+func (gf *GF2m) Mul(a, b uint16) uint16 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gf.exp[int(gf.log[a])+int(gf.log[b])]
+}
+
+// Divides a field element by another. Panics if b is zero.
+func (gf *GF2m) Div(a, b uint16) uint16 {
+	if b == 0 {
+		panic("poly: division by zero")
+	}
+	if a == 0 {
+		return 0
+	}
+	return gf.exp[int(gf.log[a])+gf.order()-int(gf.log[b])]
+}
+
+// Returns the multiplicative inverse of a field element. Panics if a is
+// zero.
+func (gf *GF2m) Inv(a uint16) uint16 {
+	return gf.Div(1, a)
+}
+
+// Returns alpha^i, where alpha is the primitive element. The exponent may be
+// negative.
+func (gf *GF2m) Exp(i int) uint16 {
+	n := gf.order()
+	return gf.exp[(i%n+n)%n]
+}
+
+// Returns the discrete logarithm of a nonzero field element, the i between
+// 0 and 2^m - 2 such that alpha^i = a. Panics if a is zero.
+func (gf *GF2m) Log(a uint16) int {
+	if a == 0 {
+		panic("poly: logarithm of zero")
+	}
+	return int(gf.log[a])
+}
+
+// Raises a field element to an integer power, which may be negative for a
+// nonzero element.
+func (gf *GF2m) Pow(a uint16, n int) uint16 {
+	if a == 0 {
+		if n < 0 {
+			panic("poly: division by zero")
+		}
+		if n == 0 {
+			return 1
+		}
+		return 0
+	}
+	return gf.Exp(int(gf.log[a]) * (n % gf.order()))
+}
+
+// GF2mPoly represents a polynomial with coefficients in a field GF(2^m).
+// Binary operations require both operands to be over the same field.
+type GF2mPoly struct {
+	gf    *GF2m
+	coeff []uint16
+}
+
+// Creates a new polynomial over the field gf.
+// The ith parameter represents the coefficient of x^i. Panics if a
+// coefficient is not an element of the field.
+func NewGF2mPoly(gf *GF2m, c ...uint16) GF2mPoly {
+	a := make([]uint16, len(c))
+	for i, ci := range c {
+		if int(ci) > gf.order() {
+			panic(fmt.Sprintf("poly: %d is not an element of GF(2^%d)", ci, gf.m))
+		}
+		a[i] = ci
+	}
+	return normalizedGF2m(gf, a)
+}
+
+// Returns a polynomial with the given coefficients, removing zero
+// coefficients of terms with degree greater than 0.
+func normalizedGF2m(gf *GF2m, c []uint16) GF2mPoly {
+	i := len(c) - 1
+	for i > 0 && c[i] == 0 {
+		i--
+	}
+	if i < 0 {
+		return GF2mPoly{gf: gf}
+	}
+	return GF2mPoly{gf, c[0 : i+1]}
+}
+
+// Returns the field of the coefficients.
+func (f GF2mPoly) Field() *GF2m {
+	return f.gf
+}
+
+// Returns the coefficient array, which is {0} for the zero polynomial.
+func (f GF2mPoly) co() []uint16 {
+	if len(f.coeff) == 0 {
+		return []uint16{0}
+	}
+	return f.coeff
+}
+
+// Returns the common field of f and g, panicking if they differ.
+func (f GF2mPoly) field(g GF2mPoly) *GF2m {
+	if f.gf != g.gf {
+		panic("poly: mismatched fields")
+	}
+	return f.gf
+}
+
+// Returns the highest degree of the polynomial's highest order term.
+func (f GF2mPoly) Deg() int {
+	return len(f.co()) - 1
+}
+
+// Returns the coefficient of the ith order term.
+func (f GF2mPoly) Coeff(i int) uint16 {
+	if i < 0 || i > f.Deg() {
+		return 0
+	}
+	return f.co()[i]
+}
+
+// Reports whether the polynomial is identically zero.
+func (f GF2mPoly) isZero() bool {
+	return f.Deg() == 0 && f.co()[0] == 0
+}
+
+// Evaluates a polynomial at the given field element x.
+func (f GF2mPoly) Eval(x uint16) uint16 {
+	fco := f.co()
+	var n uint16
+	for i := len(fco) - 1; i >= 0; i-- {
+		n = f.gf.Mul(n, x) ^ fco[i]
+	}
+	return n
+}
+
+// Adds a polynomial to another polynomial. In characteristic 2, this is also
+// subtraction.
+// Returns f+g.
+func (f GF2mPoly) Add(g GF2mPoly) GF2mPoly {
+	gf := f.field(g)
+	fco, gco := f.co(), g.co()
+	if len(fco) < len(gco) {
+		fco, gco = gco, fco
+	}
+	c := make([]uint16, len(fco))
+	copy(c, fco)
+	for i, gc := range gco {
+		c[i] ^= gc
+	}
+	return normalizedGF2m(gf, c)
+}
+
+// Multiplies a polynomial by a field element.
+// Returns k*f.
+func (f GF2mPoly) Scale(k uint16) GF2mPoly {
+	fco := f.co()
+	c := make([]uint16, len(fco))
+	for i, fc := range fco {
+		c[i] = f.gf.Mul(k, fc)
+	}
+	return normalizedGF2m(f.gf, c)
+}
+
+// Multiplies a polynomial by another polynomial.
+// Returns f*g.
+func (f GF2mPoly) Mul(g GF2mPoly) GF2mPoly {
+	gf := f.field(g)
+	fco, gco := f.co(), g.co()
+	c := make([]uint16, len(fco)+len(gco)-1)
+	for i, fc := range fco {
+		for j, gc := range gco {
+			c[i+j] ^= gf.Mul(fc, gc)
+		}
+	}
+	return normalizedGF2m(gf, c)
+}
+
+// Divides a polynomial by another polynomial using Euclidean long division.
+// Returns the quotient and remainder of f/g, such that f = quo*g + rem and the
+// degree of rem is less than the degree of g.
+// Panics if g is zero.
+func (f GF2mPoly) DivMod(g GF2mPoly) (quo, rem GF2mPoly) {
+	gf := f.field(g)
+	if g.isZero() {
+		panic("poly: division by zero")
+	}
+	gco := g.co()
+	d := len(gco) - 1
+	fco := f.co()
+	if len(fco) <= d {
+		return GF2mPoly{gf: gf}, f
+	}
+	r := make([]uint16, len(fco))
+	copy(r, fco)
+	c := make([]uint16, len(fco)-d)
+	for i := len(r) - 1; i >= d; i-- {
+		k := gf.Div(r[i], gco[d])
+		c[i-d] = k
+		for j := 0; j < d; j++ {
+			r[i-d+j] ^= gf.Mul(k, gco[j])
+		}
+	}
+	return normalizedGF2m(gf, c), normalizedGF2m(gf, r[:d])
+}
+
+// Divides a polynomial by another polynomial.
+// Returns the quotient of f/g, discarding any remainder.
+func (f GF2mPoly) Div(g GF2mPoly) GF2mPoly {
+	quo, _ := f.DivMod(g)
+	return quo
+}
+
+// Computes the remainder of dividing a polynomial by another polynomial.
+// Returns f mod g.
+func (f GF2mPoly) Mod(g GF2mPoly) GF2mPoly {
+	_, rem := f.DivMod(g)
+	return rem
+}
+
+// Returns a printable string representing the polynomial, with coefficients
+// written as integers.
+func (f GF2mPoly) String() string {
+	var b strings.Builder
+	fco := f.co()
+	for i := len(fco) - 1; i >= 0; i-- {
+		if fco[i] == 0 && (i > 0 || b.Len() > 0) {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString(" + ")
+		}
+		if i == 0 || fco[i] != 1 {
+			fmt.Fprint(&b, fco[i])
+		}
+		switch {
+		case i == 1:
+			b.WriteString("x")
+		case i > 1:
+			fmt.Fprintf(&b, "x^%d", i)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,85 @@
+package poly
+
+import "testing"
+
+// Tests arithmetic in the field GF(2^8).
+func TestGF2m(t *testing.T) {
+	gf, err := NewGF2m(NewGF2(8, 4, 3, 2, 0))
+	if err != nil {
+		t.Fatalf("NewGF2m() returned error %v", err)
+	}
+	if got := gf.Degree(); got != 8 {
+		t.Errorf("Degree() == %d, want 8", got)
+	}
+	for a := 1; a < 256; a++ {
+		x := uint16(a)
+		if got := gf.Mul(x, gf.Inv(x)); got != 1 {
+			t.Errorf("%d * Inv(%d) == %d, want 1", x, x, got)
+		}
+		if got := gf.Exp(gf.Log(x)); got != x {
+			t.Errorf("Exp(Log(%d)) == %d, want %d", x, got, x)
+		}
+	}
+	// Multiplication agrees with polynomial multiplication modulo f.
+	a, b := uint16(0x57), uint16(0x83)
+	want, _ := GF2FromUint(uint64(a)).MulMod(GF2FromUint(uint64(b)), gf.Modulus()).Uint64()
+	if got := gf.Mul(a, b); uint64(got) != want {
+		t.Errorf("Mul(%#x, %#x) == %#x, want %#x", a, b, got, want)
+	}
+	cases := []struct {
+		got, want uint16
+	}{
+		{gf.Exp(0), 1},
+		{gf.Exp(1), 2},
+		{gf.Exp(8), 0x1d},
+		{gf.Exp(-1), gf.Inv(2)},
+		{gf.Div(gf.Mul(a, b), b), a},
+		{gf.Pow(2, 255), 1},
+		{gf.Pow(3, -2), gf.Inv(gf.Mul(3, 3))},
+		{gf.Pow(0, 0), 1},
+		{gf.Add(a, a), 0},
+	}
+	for i, c := range cases {
+		if c.got != c.want {
+			t.Errorf("case %d: got %d, want %d", i, c.got, c.want)
+		}
+	}
+}
+
+// Tests that only primitive polynomials define fields.
+func TestNewGF2mError(t *testing.T) {
+	cases := []GF2Poly{
+		GF2Poly{},
+		aesPoly,
+		NewGF2(4, 2, 0),
+		NewGF2(17, 3, 0),
+	}
+	for i, f := range cases {
+		if _, err := NewGF2m(f); err == nil {
+			t.Errorf("case %d: NewGF2m(%q) succeeded, want error", i, f)
+		}
+	}
+}
+
+// Tests arithmetic on polynomials over GF(2^4).
+func TestGF2mPoly(t *testing.T) {
+	gf, _ := NewGF2m(NewGF2(4, 1, 0))
+	f := NewGF2mPoly(gf, 3, 7, 1)
+	g := NewGF2mPoly(gf, 5, 1)
+	quo, rem := f.DivMod(g)
+	if got := quo.Mul(g).Add(rem); got.String() != f.String() {
+		t.Errorf("quo*g + rem == %q, want %q", got, f)
+	}
+	if rem.Deg() != 0 {
+		t.Errorf("DivMod(%q) on %q has remainder %q", g, f, rem)
+	}
+	if got, want := rem.Coeff(0), f.Eval(5); got != want {
+		t.Errorf("remainder == %d, want f(5) == %d", got, want)
+	}
+	if got, want := f.Add(f).String(), "0"; got != want {
+		t.Errorf("f + f == %q, want %q", got, want)
+	}
+	if got, want := g.Scale(2).String(), "2x + 10"; got != want {
+		t.Errorf("Scale(2) == %q, want %q", got, want)
+	}
+}
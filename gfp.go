@@ -0,0 +1,343 @@
+package poly
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+// GFPoly represents a polynomial with coefficients in the finite field GF(p)
+// of integers modulo a prime p. Any prime that fits in a uint64 may be used.
+// Binary operations require both operands to have the same modulus.
+// A zero valued GFPoly is equivalent to 0, and takes its modulus from the
+// other operand of a binary operation.
+type GFPoly struct {
+	p     uint64
+	coeff []uint64
+}
+
+// Creates a new polynomial over GF(p).
+// The ith parameter represents the coefficient of x^i, and is reduced modulo
+// p. Panics if p is not prime.
+func NewGF(p uint64, c ...uint64) GFPoly {
+	if !new(big.Int).SetUint64(p).ProbablyPrime(0) {
+		panic(fmt.Sprintf("poly: modulus %d is not prime", p))
+	}
+	a := make([]uint64, len(c))
+	for i, ci := range c {
+		a[i] = ci % p
+	}
+	return normalizedGF(p, a)
+}
+
+// Returns the prime modulus p of the field GF(p), or 0 for the zero value.
+func (f GFPoly) Modulus() uint64 {
+	return f.p
+}
+
+// Returns the coefficient array, which is {0} for the zero value.
+func (f GFPoly) co() []uint64 {
+	if len(f.coeff) == 0 {
+		return []uint64{0}
+	}
+	return f.coeff
+}
+
+// Returns a polynomial with the given coefficients, removing zero
+// coefficients of terms with degree greater than 0.
+func normalizedGF(p uint64, c []uint64) GFPoly {
+	i := len(c) - 1
+	for i > 0 && c[i] == 0 {
+		i--
+	}
+	if i < 0 {
+		return GFPoly{p: p}
+	}
+	return GFPoly{p, c[0 : i+1]}
+}
+
+// Returns the common modulus of f and g, panicking if they differ.
+func (f GFPoly) modulus(g GFPoly) uint64 {
+	switch {
+	case f.p == 0:
+		return g.p
+	case g.p == 0 || f.p == g.p:
+		return f.p
+	}
+	panic(fmt.Sprintf("poly: mismatched moduli %d and %d", f.p, g.p))
+}
+
+// Returns a*b mod p, for a and b less than p.
+func mulMod(a, b, p uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	_, r := bits.Div64(hi, lo, p)
+	return r
+}
+
+// Returns a+b mod p, for a and b less than p.
+func addMod(a, b, p uint64) uint64 {
+	s, carry := bits.Add64(a, b, 0)
+	if carry != 0 || s >= p {
+		s -= p
+	}
+	return s
+}
+
+// Returns a-b mod p, for a and b less than p.
+func subMod(a, b, p uint64) uint64 {
+	if a >= b {
+		return a - b
+	}
+	return a + (p - b)
+}
+
+// Returns a^n mod p.
+func powMod(a, n, p uint64) uint64 {
+	r := 1 % p
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			r = mulMod(r, a, p)
+		}
+		a = mulMod(a, a, p)
+	}
+	return r
+}
+
+// Returns the multiplicative inverse of a nonzero a modulo a prime p, using
+// Fermat's little theorem.
+func invMod(a, p uint64) uint64 {
+	return powMod(a, p-2, p)
+}
+
+// Returns the highest degree of the polynomial's highest order term.
+func (f GFPoly) Deg() int {
+	return len(f.co()) - 1
+}
+
+// Returns the coefficient of the ith order term.
+func (f GFPoly) Coeff(i int) uint64 {
+	if i < 0 || i > f.Deg() {
+		return 0
+	}
+	return f.co()[i]
+}
+
+// Reports whether the polynomial is identically zero.
+func (f GFPoly) isZero() bool {
+	return f.Deg() == 0 && f.co()[0] == 0
+}
+
+// Evaluates a polynomial at the given point x, which is reduced modulo p.
+func (f GFPoly) Eval(x uint64) uint64 {
+	if f.isZero() {
+		return 0
+	}
+	x %= f.p
+	fco := f.co()
+	var n uint64
+	for i := len(fco) - 1; i >= 0; i-- {
+		n = addMod(mulMod(n, x, f.p), fco[i], f.p)
+	}
+	return n
+}
+
+// Adds a polynomial to another polynomial.
+// Returns f+g.
+func (f GFPoly) Add(g GFPoly) GFPoly {
+	p := f.modulus(g)
+	fco, gco := f.co(), g.co()
+	if len(fco) < len(gco) {
+		fco, gco = gco, fco
+	}
+	c := make([]uint64, len(fco))
+	copy(c, fco)
+	for i, gc := range gco {
+		c[i] = addMod(c[i], gc, p)
+	}
+	return normalizedGF(p, c)
+}
+
+// Subtracts a polynomial from another polynomial.
+// Returns f-g.
+func (f GFPoly) Sub(g GFPoly) GFPoly {
+	return f.Add(g.Neg())
+}
+
+// Negates a polynomial.
+// Returns -f.
+func (f GFPoly) Neg() GFPoly {
+	fco := f.co()
+	c := make([]uint64, len(fco))
+	for i, fc := range fco {
+		c[i] = subMod(0, fc, f.p)
+	}
+	return normalizedGF(f.p, c)
+}
+
+// Multiplies a polynomial by a scalar, which is reduced modulo p.
+// Returns k*f.
+func (f GFPoly) Scale(k uint64) GFPoly {
+	if f.isZero() {
+		return f
+	}
+	k %= f.p
+	fco := f.co()
+	c := make([]uint64, len(fco))
+	for i, fc := range fco {
+		c[i] = mulMod(k, fc, f.p)
+	}
+	return normalizedGF(f.p, c)
+}
+
+// Multiplies a polynomial by another polynomial.
+// Returns f*g.
+func (f GFPoly) Mul(g GFPoly) GFPoly {
+	p := f.modulus(g)
+	if f.isZero() || g.isZero() {
+		return GFPoly{p: p}
+	}
+	fco, gco := f.co(), g.co()
+	c := make([]uint64, len(fco)+len(gco)-1)
+	for i, fc := range fco {
+		for j, gc := range gco {
+			c[i+j] = addMod(c[i+j], mulMod(fc, gc, p), p)
+		}
+	}
+	return normalizedGF(p, c)
+}
+
+// Divides a polynomial by another polynomial using Euclidean long division.
+// Returns the quotient and remainder of f/g, such that f = quo*g + rem and the
+// degree of rem is less than the degree of g.
+// Panics if g is zero.
+func (f GFPoly) DivMod(g GFPoly) (quo, rem GFPoly) {
+	p := f.modulus(g)
+	if g.isZero() {
+		panic("poly: division by zero")
+	}
+	gco := g.co()
+	d := len(gco) - 1
+	fco := f.co()
+	if len(fco) <= d {
+		return GFPoly{p: p}, normalizedGF(p, append([]uint64(nil), fco...))
+	}
+	inv := invMod(gco[d], p)
+	r := make([]uint64, len(fco))
+	copy(r, fco)
+	c := make([]uint64, len(fco)-d)
+	for i := len(r) - 1; i >= d; i-- {
+		k := mulMod(r[i], inv, p)
+		c[i-d] = k
+		for j := 0; j < d; j++ {
+			r[i-d+j] = subMod(r[i-d+j], mulMod(k, gco[j], p), p)
+		}
+	}
+	return normalizedGF(p, c), normalizedGF(p, r[:d])
+}
+
+// Divides a polynomial by another polynomial.
+// Returns the quotient of f/g, discarding any remainder.
+func (f GFPoly) Div(g GFPoly) GFPoly {
+	quo, _ := f.DivMod(g)
+	return quo
+}
+
+// Computes the remainder of dividing a polynomial by another polynomial.
+// Returns f mod g.
+func (f GFPoly) Mod(g GFPoly) GFPoly {
+	_, rem := f.DivMod(g)
+	return rem
+}
+
+// Returns the polynomial scaled so that its leading coefficient is 1.
+// The zero polynomial is returned unchanged.
+func (f GFPoly) Monic() GFPoly {
+	if f.isZero() {
+		return f
+	}
+	return f.Scale(invMod(f.co()[f.Deg()], f.p))
+}
+
+// Computes the greatest common divisor of two polynomials using the Euclidean
+// algorithm.
+// The result is monic, unless both f and g are zero, in which case it is zero.
+func (f GFPoly) GCD(g GFPoly) GFPoly {
+	a, b := f, g
+	for !b.isZero() {
+		a, b = b, a.Mod(b)
+	}
+	return a.Monic()
+}
+
+// Computes the derivative of a polynomial.
+func (f GFPoly) Der() GFPoly {
+	fco := f.co()
+	c := make([]uint64, len(fco)-1)
+	for i := range c {
+		c[i] = mulMod(fco[i+1], uint64(i+1)%f.p, f.p)
+	}
+	return normalizedGF(f.p, c)
+}
+
+// Raises a polynomial to a non-negative integer power by repeated squaring.
+// Returns f^n.
+func (f GFPoly) Pow(n int) GFPoly {
+	if n < 0 {
+		panic("poly: negative exponent")
+	}
+	r := GFPoly{f.p, []uint64{1 % max(f.p, 1)}}
+	for b := f; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			r = r.Mul(b)
+		}
+		if n > 1 {
+			b = b.Mul(b)
+		}
+	}
+	return r
+}
+
+// Raises a polynomial to a non-negative integer power modulo another
+// polynomial m, reducing after every multiplication so that the exponent may
+// be arbitrarily large.
+// Returns f^n mod m. Panics if m is zero.
+func (f GFPoly) PowMod(n *big.Int, m GFPoly) GFPoly {
+	if n.Sign() < 0 {
+		panic("poly: negative exponent")
+	}
+	p := f.modulus(m)
+	r := GFPoly{p, []uint64{1}}.Mod(m)
+	b := f.Mod(m)
+	for i := n.BitLen() - 1; i >= 0; i-- {
+		r = r.Mul(r).Mod(m)
+		if n.Bit(i) == 1 {
+			r = r.Mul(b).Mod(m)
+		}
+	}
+	return r
+}
+
+// Returns a printable string representing the polynomial.
+func (f GFPoly) String() string {
+	var b strings.Builder
+	fco := f.co()
+	for i := len(fco) - 1; i >= 0; i-- {
+		if fco[i] == 0 && (i > 0 || b.Len() > 0) {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString(" + ")
+		}
+		if i == 0 || fco[i] != 1 {
+			fmt.Fprint(&b, fco[i])
+		}
+		switch {
+		case i == 1:
+			b.WriteString("x")
+		case i > 1:
+			fmt.Fprintf(&b, "x^%d", i)
+		}
+	}
+	return b.String()
+}
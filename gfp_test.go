@@ -0,0 +1,117 @@
+package poly
+
+import (
+	"math/big"
+	"testing"
+)
+
+// Tests arithmetic on polynomials over GF(p).
+func TestGFPoly(t *testing.T) {
+	f := NewGF(7, 3, 5, 1)
+	g := NewGF(7, 6, 1)
+	cases := []struct {
+		name string
+		got  GFPoly
+		want string
+	}{
+		{"f", f, "x^2 + 5x + 3"},
+		{"reduced", NewGF(7, 10, 15, 8), "x^2 + x + 3"},
+		{"Add", f.Add(g), "x^2 + 6x + 2"},
+		{"Sub", f.Sub(f), "0"},
+		{"Neg", g.Neg(), "6x + 1"},
+		{"Scale", f.Scale(3), "3x^2 + x + 2"},
+		{"Mul", f.Mul(g), "x^3 + 4x^2 + 5x + 4"},
+		{"Div", f.Div(g), "x + 6"},
+		{"Mod", f.Mod(g), "2"},
+		{"Monic", NewGF(7, 1, 3).Monic(), "x + 5"},
+		{"Der", f.Der(), "2x + 5"},
+		// The derivative of x^7 vanishes in characteristic 7.
+		{"Der char", NewGF(7, 1, 0, 0, 0, 0, 0, 0, 1).Der(), "0"},
+		{"Pow", g.Pow(7), "x^7 + 6"},
+		{"Pow 0", g.Pow(0), "1"},
+		{"zero value", GFPoly{}.Add(g), "x + 6"},
+	}
+	for _, c := range cases {
+		if got := c.got.String(); got != c.want {
+			t.Errorf("%s == %q, want %q", c.name, got, c.want)
+		}
+	}
+	if got, want := f.Eval(4), uint64(4); got != want {
+		t.Errorf("Eval(4) on %q == %d, want %d", f, got, want)
+	}
+}
+
+// Tests that division satisfies f = quo*g + rem for a large prime.
+func TestGFPolyDivMod(t *testing.T) {
+	const p = 18446744073709551557 // The largest prime below 2^64.
+	f := NewGF(p, p-1, 12345678901234567, 3, p-2, 98765432109876543)
+	g := NewGF(p, 5, p-7, 11)
+	quo, rem := f.DivMod(g)
+	if rem.Deg() >= g.Deg() {
+		t.Errorf("DivMod(%q) on %q has remainder %q of degree %d", g, f, rem, rem.Deg())
+	}
+	if got := quo.Mul(g).Add(rem); got.String() != f.String() {
+		t.Errorf("quo*g + rem == %q, want %q", got, f)
+	}
+}
+
+// Tests greatest common divisors over GF(p).
+func TestGFPolyGCD(t *testing.T) {
+	a := NewGF(5, 2, 1)
+	cases := []struct {
+		f, g GFPoly
+		want string
+	}{
+		{NewGF(5), NewGF(5), "0"},
+		{a.Scale(3), NewGF(5), "x + 2"},
+		{a.Mul(NewGF(5, 1, 0, 1)), a.Mul(NewGF(5, 1, 1)), "x + 2"},
+		{NewGF(5, 1, 1), NewGF(5, 2, 1), "1"},
+		// x^2 + 1 = (x + 2)(x + 3) over GF(5).
+		{NewGF(5, 1, 0, 1), NewGF(5, 3, 1).Pow(3), "x + 3"},
+	}
+	for i, c := range cases {
+		if got := c.f.GCD(c.g); got.String() != c.want {
+			t.Errorf("case %d: GCD(%q) on %q == %q, want %q", i, c.g, c.f, got, c.want)
+		}
+	}
+}
+
+// Tests modular exponentiation with large exponents.
+func TestGFPolyPowMod(t *testing.T) {
+	m := NewGF(3, 2, 2, 0, 1)
+	x := NewGF(3, 0, 1)
+	cases := []struct {
+		n    int64
+		want GFPoly
+	}{
+		{0, NewGF(3, 1)},
+		{1, x},
+		{5, x.Pow(5).Mod(m)},
+		// x^p is the Frobenius map, so x^(3^3) = x modulo an irreducible cubic.
+		{27, x},
+	}
+	for i, c := range cases {
+		if got := x.PowMod(big.NewInt(c.n), m); got.String() != c.want.String() {
+			t.Errorf("case %d: PowMod(%d, %q) == %q, want %q", i, c.n, m, got, c.want)
+		}
+	}
+}
+
+// Tests that mixing moduli and non-prime moduli panic.
+func TestGFPolyPanics(t *testing.T) {
+	cases := []func(){
+		func() { NewGF(6, 1) },
+		func() { NewGF(5, 1).Add(NewGF(7, 1)) },
+		func() { NewGF(5, 1).Div(NewGF(5)) },
+	}
+	for i, f := range cases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("case %d did not panic", i)
+				}
+			}()
+			f()
+		}()
+	}
+}
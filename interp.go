@@ -0,0 +1,176 @@
+package poly
+
+import "fmt"
+
+// Computes the interpolating polynomial through the points (xs[i], ys[i]).
+// The result is the unique polynomial of degree at most len(xs)-1 that takes
+// the value ys[i] at xs[i]. It is computed from Newton's divided differences.
+// Returns an error if xs and ys differ in length, if there are no points, or
+// if the xs are not distinct.
+func Interpolate(xs, ys []float64) (Poly, error) {
+	if err := checkPoints(xs, ys); err != nil {
+		return Poly{}, err
+	}
+	return newtonPoly(xs, dividedDiffs(xs, ys)), nil
+}
+
+// Computes the Hermite interpolating polynomial, which matches both values
+// and derivatives at the nodes xs.
+// ys[i] lists the value at xs[i] followed by any number of its derivatives,
+// so ys[i][k] is the k-th derivative at xs[i]. The result is the unique
+// polynomial of degree less than the total number of conditions that
+// satisfies all of them. It is computed from divided differences on the
+// nodes repeated once per condition.
+// Returns an error if xs and ys differ in length, if there are no nodes, if
+// the xs are not distinct, or if some node has no conditions.
+// Example:
+//
+//	// A cubic easing curve from (0, 0) to (1, 1) starting and ending at rest.
+//	p, _ := poly.InterpolateHermite([]float64{0, 1}, [][]float64{{0, 0}, {1, 0}})
+//	// p is -2x^3 + 3x^2
+func InterpolateHermite(xs []float64, ys [][]float64) (Poly, error) {
+	if len(xs) != len(ys) {
+		return Poly{}, fmt.Errorf("poly: %d x values but %d y values", len(xs), len(ys))
+	}
+	vals := make([]float64, len(xs))
+	for i, y := range ys {
+		if len(y) == 0 {
+			return Poly{}, fmt.Errorf("poly: no conditions at x value %g", xs[i])
+		}
+		vals[i] = y[0]
+	}
+	if err := checkPoints(xs, vals); err != nil {
+		return Poly{}, err
+	}
+	var z, d []float64
+	var node []int
+	for i, x := range xs {
+		for range ys[i] {
+			z = append(z, x)
+			d = append(d, ys[i][0])
+			node = append(node, i)
+		}
+	}
+	fact := 1.0
+	for k := 1; k < len(d); k++ {
+		fact *= float64(k)
+		for i := len(d) - 1; i >= k; i-- {
+			if z[i] == z[i-k] {
+				d[i] = ys[node[i]][k] / fact
+			} else {
+				d[i] = (d[i] - d[i-1]) / (z[i] - z[i-k])
+			}
+		}
+	}
+	return newtonPoly(z, d), nil
+}
+
+// Checks that xs and ys describe at least one point with distinct xs.
+func checkPoints(xs, ys []float64) error {
+	if len(xs) != len(ys) {
+		return fmt.Errorf("poly: %d x values but %d y values", len(xs), len(ys))
+	}
+	if len(xs) == 0 {
+		return fmt.Errorf("poly: no points to interpolate")
+	}
+	seen := make(map[float64]bool, len(xs))
+	for _, x := range xs {
+		if seen[x] {
+			return fmt.Errorf("poly: duplicate x value %g", x)
+		}
+		seen[x] = true
+	}
+	return nil
+}
+
+// Computes the divided differences f[x0], f[x0,x1], ..., f[x0,...,xn].
+func dividedDiffs(xs, ys []float64) []float64 {
+	d := make([]float64, len(ys))
+	copy(d, ys)
+	for k := 1; k < len(d); k++ {
+		for i := len(d) - 1; i >= k; i-- {
+			d[i] = (d[i] - d[i-1]) / (xs[i] - xs[i-k])
+		}
+	}
+	return d
+}
+
+// Expands the Newton form d[0] + d[1](x-x0) + d[2](x-x0)(x-x1) + ... into
+// a polynomial.
+func newtonPoly(xs, d []float64) Poly {
+	n := len(d)
+	c := make([]float64, n)
+	c[0] = d[n-1]
+	for k := n - 2; k >= 0; k-- {
+		// Multiply by (x - xs[k]) and add d[k]; c holds n-1-k coefficients.
+		m := n - 1 - k
+		for i := m; i >= 1; i-- {
+			c[i] = c[i-1] - xs[k]*c[i]
+		}
+		c[0] = d[k] - xs[k]*c[0]
+	}
+	return normalized(c)
+}
+
+// NewtonInterp is an interpolating polynomial in Newton form that can be
+// extended one point at a time.
+// The zero value interpolates no points and is ready to use.
+// Example:
+//
+//	var ni poly.NewtonInterp
+//	ni.AddPoint(0, 1)
+//	ni.AddPoint(1, 3)
+//	ni.Poly() // 2x + 1
+type NewtonInterp struct {
+	xs []float64
+	// Divided differences f[x0], f[x0,x1], ..., the coefficients of the
+	// Newton form.
+	d []float64
+	// Divided differences f[xn], f[x(n-1),xn], ..., f[x0,...,xn] for the
+	// most recent point xn, which are all that is needed to add another.
+	last []float64
+}
+
+// Returns the number of points interpolated.
+func (ni *NewtonInterp) Len() int {
+	return len(ni.xs)
+}
+
+// Adds the point (x, y), updating the interpolant in time linear in the
+// number of points.
+// Returns an error, leaving the interpolant unchanged, if x duplicates the x
+// value of an earlier point.
+func (ni *NewtonInterp) AddPoint(x, y float64) error {
+	for _, xi := range ni.xs {
+		if xi == x {
+			return fmt.Errorf("poly: duplicate x value %g", x)
+		}
+	}
+	n := len(ni.xs)
+	last := make([]float64, n+1)
+	last[0] = y
+	for k := 1; k <= n; k++ {
+		last[k] = (last[k-1] - ni.last[k-1]) / (x - ni.xs[n-k])
+	}
+	ni.xs = append(ni.xs, x)
+	ni.d = append(ni.d, last[n])
+	ni.last = last
+	return nil
+}
+
+// Evaluates the interpolant at x using the nested Newton form.
+func (ni *NewtonInterp) Eval(x float64) float64 {
+	var v float64
+	for k := len(ni.d) - 1; k >= 0; k-- {
+		v = v*(x-ni.xs[k]) + ni.d[k]
+	}
+	return v
+}
+
+// Returns the interpolant as a polynomial.
+func (ni *NewtonInterp) Poly() Poly {
+	if len(ni.d) == 0 {
+		return Poly{}
+	}
+	return newtonPoly(ni.xs, ni.d)
+}
@@ -0,0 +1,139 @@
+package poly
+
+import (
+	"math"
+	"testing"
+)
+
+// Tests that interpolating polynomials pass through the given points.
+func TestInterpolate(t *testing.T) {
+	cases := []struct {
+		xs, ys []float64
+		want   Poly
+	}{
+		{[]float64{2}, []float64{5}, New(5)},
+		{[]float64{0, 1}, []float64{1, 3}, New(1, 2)},
+		{[]float64{-1, 0, 1}, []float64{1, 0, 1}, New(0, 0, 1)},
+		{[]float64{1, -1, 0}, []float64{1, 1, 0}, New(0, 0, 1)},
+		{[]float64{0, 1, 2, 3}, []float64{1, 1, 1, 1}, New(1)},
+		{[]float64{0, 1, 2, 3}, []float64{-1, 0, 7, 26}, New(-1, 0, 0, 1)},
+		{[]float64{-2, -0.5, 0.25, 1.5, 3}, []float64{
+			New(3, -1, 0.5, 2, -0.25).Eval(-2),
+			New(3, -1, 0.5, 2, -0.25).Eval(-0.5),
+			New(3, -1, 0.5, 2, -0.25).Eval(0.25),
+			New(3, -1, 0.5, 2, -0.25).Eval(1.5),
+			New(3, -1, 0.5, 2, -0.25).Eval(3),
+		}, New(3, -1, 0.5, 2, -0.25)},
+	}
+	for i, c := range cases {
+		got, err := Interpolate(c.xs, c.ys)
+		if err != nil {
+			t.Errorf("case %d: Interpolate(%v, %v) returned error %v", i, c.xs, c.ys, err)
+			continue
+		}
+		if !comparePoly(got, c.want) {
+			t.Errorf("case %d: Interpolate(%v, %v) == %q, want %q", i, c.xs, c.ys, got, c.want)
+		}
+	}
+}
+
+// Tests that invalid interpolation points are rejected.
+func TestInterpolateError(t *testing.T) {
+	cases := []struct {
+		xs, ys []float64
+	}{
+		{nil, nil},
+		{[]float64{1, 2}, []float64{1}},
+		{[]float64{1, 2, 1}, []float64{1, 2, 3}},
+	}
+	for i, c := range cases {
+		if got, err := Interpolate(c.xs, c.ys); err == nil {
+			t.Errorf("case %d: Interpolate(%v, %v) == %q, want error", i, c.xs, c.ys, got)
+		}
+	}
+}
+
+// Tests that Hermite interpolation matches values and derivatives.
+func TestInterpolateHermite(t *testing.T) {
+	p := New(3, -1, 0.5, 2, -0.25, 0.125)
+	dp, d2p := p.Der(), p.Der().Der()
+	cases := []struct {
+		xs   []float64
+		ys   [][]float64
+		want Poly
+	}{
+		{[]float64{2}, [][]float64{{5}}, New(5)},
+		{[]float64{2}, [][]float64{{5, 3}}, New(-1, 3)},
+		{[]float64{1}, [][]float64{{1, 2, 2}}, New(0, 0, 1)},
+		{[]float64{0, 1}, [][]float64{{0, 0}, {1, 0}}, New(0, 0, 3, -2)},
+		{[]float64{0, 1, 2}, [][]float64{{1}, {2}, {5}}, New(1, 0, 1)},
+		{[]float64{-1, 0.5, 2}, [][]float64{
+			{p.Eval(-1), dp.Eval(-1)},
+			{p.Eval(0.5)},
+			{p.Eval(2), dp.Eval(2), d2p.Eval(2)},
+		}, p},
+	}
+	for i, c := range cases {
+		got, err := InterpolateHermite(c.xs, c.ys)
+		if err != nil {
+			t.Errorf("case %d: InterpolateHermite(%v, %v) returned error %v", i, c.xs, c.ys, err)
+			continue
+		}
+		if !comparePoly(got, c.want) {
+			t.Errorf("case %d: InterpolateHermite(%v, %v) == %q, want %q", i, c.xs, c.ys, got, c.want)
+		}
+	}
+}
+
+// Tests that invalid Hermite interpolation conditions are rejected.
+func TestInterpolateHermiteError(t *testing.T) {
+	cases := []struct {
+		xs []float64
+		ys [][]float64
+	}{
+		{nil, nil},
+		{[]float64{1, 2}, [][]float64{{1}}},
+		{[]float64{1, 2}, [][]float64{{1}, {}}},
+		{[]float64{1, 1}, [][]float64{{1}, {2}}},
+	}
+	for i, c := range cases {
+		if got, err := InterpolateHermite(c.xs, c.ys); err == nil {
+			t.Errorf("case %d: InterpolateHermite(%v, %v) == %q, want error", i, c.xs, c.ys, got)
+		}
+	}
+}
+
+// Tests that points added one at a time give the same polynomial as
+// Interpolate.
+func TestNewtonInterp(t *testing.T) {
+	xs := []float64{-2, -0.5, 0.25, 1.5, 3, 4}
+	p := New(3, -1, 0.5, 2, -0.25)
+	var ni NewtonInterp
+	if got := ni.Poly(); !comparePoly(got, Poly{}) {
+		t.Errorf("Poly() with no points == %q, want %q", got, Poly{})
+	}
+	for i, x := range xs {
+		if err := ni.AddPoint(x, p.Eval(x)); err != nil {
+			t.Fatalf("AddPoint(%f, %f) returned error %v", x, p.Eval(x), err)
+		}
+		ys := p.EvalAll(xs[:i+1])
+		want, _ := Interpolate(xs[:i+1], ys)
+		if got := ni.Poly(); !comparePoly(got, want) {
+			t.Errorf("after %d points: Poly() == %q, want %q", i+1, got, want)
+		}
+		if ni.Len() != i+1 {
+			t.Errorf("after %d points: Len() == %d, want %d", i+1, ni.Len(), i+1)
+		}
+		for _, x := range []float64{-1, 0, 2.5} {
+			if got, want := ni.Eval(x), want.Eval(x); math.Abs(got-want) > 0.00001 {
+				t.Errorf("after %d points: Eval(%f) == %f, want %f", i+1, x, got, want)
+			}
+		}
+	}
+	if err := ni.AddPoint(0.25, 0); err == nil {
+		t.Errorf("AddPoint(0.25, 0) with duplicate x succeeded, want error")
+	}
+	if got := ni.Poly(); !comparePoly(got, p) {
+		t.Errorf("Poly() after failed AddPoint == %q, want %q", got, p)
+	}
+}
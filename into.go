@@ -0,0 +1,79 @@
+package poly
+
+// The functions in this file store their results in caller provided slices,
+// so that loops which repeatedly combine polynomials need not allocate. Each
+// returns a Poly whose coefficients are held in dst, which is resliced if
+// it has enough capacity and otherwise replaced by a newly allocated slice.
+// The returned Poly shares memory with dst, so it is only valid until dst is
+// next written.
+
+// Returns dst resliced to length n if it has the capacity, or a new slice of
+// length n otherwise.
+func grow(dst []float64, n int) []float64 {
+	if cap(dst) < n {
+		return make([]float64, n)
+	}
+	return dst[:n]
+}
+
+// Adds two polynomials, storing the coefficients of the result in dst.
+// Returns p+q. The coefficients of p or q may be stored in dst, so that
+// p = AddTo(buf, p, q) updates p in place.
+func AddTo(dst []float64, p, q Poly) Poly {
+	pco, qco := p.co(), q.co()
+	if len(pco) < len(qco) {
+		pco, qco = qco, pco
+	}
+	c := grow(dst, len(pco))
+	for i, pc := range pco {
+		if i < len(qco) {
+			pc += qco[i]
+		}
+		c[i] = pc
+	}
+	return normalized(c)
+}
+
+// Subtracts a polynomial from another, storing the coefficients of the
+// result in dst.
+// Returns p-q. The coefficients of p or q may be stored in dst.
+func SubTo(dst []float64, p, q Poly) Poly {
+	pco, qco := p.co(), q.co()
+	c := grow(dst, max(len(pco), len(qco)))
+	for i := range c {
+		var pc, qc float64
+		if i < len(pco) {
+			pc = pco[i]
+		}
+		if i < len(qco) {
+			qc = qco[i]
+		}
+		c[i] = pc - qc
+	}
+	return normalized(c)
+}
+
+// Multiplies a polynomial by a scalar, storing the coefficients of the
+// result in dst.
+// Returns k*p. The coefficients of p may be stored in dst.
+func ScaleTo(dst []float64, p Poly, k float64) Poly {
+	pco := p.co()
+	c := grow(dst, len(pco))
+	for i, pc := range pco {
+		c[i] = k * pc
+	}
+	return normalized(c)
+}
+
+// Multiplies two polynomials, storing the coefficients of the result in dst,
+// which must not hold the coefficients of p or q.
+// Returns p*q. The schoolbook method used for small operands needs no other
+// storage, but the faster methods used when both operands have many terms
+// allocate temporary slices.
+func MulTo(dst []float64, p, q Poly) Poly {
+	pco, qco := p.co(), q.co()
+	c := grow(dst, len(pco)+len(qco)-1)
+	clear(c)
+	mulInto(c, pco, qco)
+	return normalized(c)
+}
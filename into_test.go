@@ -0,0 +1,73 @@
+package poly
+
+import "testing"
+
+// Tests destination based arithmetic against the allocating methods.
+func TestInto(t *testing.T) {
+	cases := []struct{ p, q Poly }{
+		{Poly{}, Poly{}},
+		{New(1, 2, 3), New(4, 5)},
+		{New(4, 5), New(1, 2, 3)},
+		{New(1, 2, 3), New(-1, -2, -3)},
+		{New(0, 0, 1), Poly{}},
+	}
+	for i, c := range cases {
+		buf := make([]float64, 0, 16)
+		if got, want := AddTo(buf, c.p, c.q), c.p.Add(c.q); !equalCoeffs(got, want) {
+			t.Errorf("case %d: AddTo(%q, %q) == %q, want %q", i, c.p, c.q, got, want)
+		}
+		if got, want := SubTo(buf, c.p, c.q), c.p.Sub(c.q); !equalCoeffs(got, want) {
+			t.Errorf("case %d: SubTo(%q, %q) == %q, want %q", i, c.p, c.q, got, want)
+		}
+		if got, want := ScaleTo(buf, c.p, -2), c.p.Scale(-2); !equalCoeffs(got, want) {
+			t.Errorf("case %d: ScaleTo(%q, -2) == %q, want %q", i, c.p, got, want)
+		}
+		if got, want := MulTo(buf, c.p, c.q), c.p.Mul(c.q); !equalCoeffs(got, want) {
+			t.Errorf("case %d: MulTo(%q, %q) == %q, want %q", i, c.p, c.q, got, want)
+		}
+		// A destination without enough capacity is replaced.
+		if got, want := MulTo(nil, c.p, c.q), c.p.Mul(c.q); !equalCoeffs(got, want) {
+			t.Errorf("case %d: MulTo(nil, %q, %q) == %q, want %q", i, c.p, c.q, got, want)
+		}
+	}
+}
+
+// Tests that results are stored in the destination and may be updated in
+// place.
+func TestIntoInPlace(t *testing.T) {
+	buf := make([]float64, 8)
+	p := New(1, 1)
+	acc := ScaleTo(buf, New(1), 1)
+	tmp := make([]float64, 8)
+	for i := 0; i < 4; i++ {
+		// acc = acc*p + 1, using tmp for the product.
+		acc = AddTo(buf, MulTo(tmp, acc, p), New(1))
+	}
+	if want := New(5, 10, 10, 5, 1); !equalCoeffs(acc, want) {
+		t.Errorf("accumulated %q, want %q", acc, want)
+	}
+	if &acc.co()[0] != &buf[0] {
+		t.Errorf("AddTo() did not store the result in dst")
+	}
+	acc = SubTo(buf, acc, New(5, 10))
+	acc = ScaleTo(buf, acc, 0.5)
+	if want := New(0, 0, 5, 2.5, 0.5); !equalCoeffs(acc, want) {
+		t.Errorf("updated in place to %q, want %q", acc, want)
+	}
+}
+
+// Tests that the destination based functions do not allocate when the
+// destination is large enough.
+func TestIntoAllocs(t *testing.T) {
+	p, q := New(1, 2, 3, 4), New(5, 6, 7)
+	buf := make([]float64, 16)
+	allocs := testing.AllocsPerRun(100, func() {
+		r := MulTo(buf, p, q)
+		r = AddTo(buf, r, q)
+		r = SubTo(buf, r, p)
+		ScaleTo(buf, r, 3)
+	})
+	if allocs != 0 {
+		t.Errorf("destination based arithmetic made %v allocations, want 0", allocs)
+	}
+}
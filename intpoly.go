@@ -0,0 +1,362 @@
+package poly
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// IntPoly represents a polynomial with arbitrary size integer coefficients.
+// All operations are exact. Since integer polynomials are not closed under
+// division, IntPoly provides pseudo-division instead of DivMod.
+// IntPoly values are immutable; no method modifies its receiver or arguments.
+// A zero valued IntPoly is equivalent to 0.
+type IntPoly struct {
+	coeff []*big.Int
+}
+
+// Creates a new IntPoly.
+// The ith parameter represents the coefficient of x^i.
+func NewInt(c ...int64) IntPoly {
+	a := make([]*big.Int, len(c))
+	for i, ci := range c {
+		a[i] = big.NewInt(ci)
+	}
+	return normalizedInt(a)
+}
+
+// Creates a new IntPoly from big.Int coefficients.
+// The ith parameter represents the coefficient of x^i. The coefficients are
+// copied, and nil is treated as zero.
+func NewBigInt(c ...*big.Int) IntPoly {
+	a := make([]*big.Int, len(c))
+	for i, ci := range c {
+		a[i] = new(big.Int)
+		if ci != nil {
+			a[i].Set(ci)
+		}
+	}
+	return normalizedInt(a)
+}
+
+// Converts a polynomial to one with exact rational coefficients.
+func (p IntPoly) Rat() RatPoly {
+	pco := p.co()
+	c := make([]*big.Rat, len(pco))
+	for i, pc := range pco {
+		c[i] = new(big.Rat).SetInt(pc)
+	}
+	return normalizedRat(c)
+}
+
+// Converts a polynomial to one with float64 coefficients, rounding each
+// coefficient to the nearest float64.
+func (p IntPoly) Poly() Poly {
+	pco := p.co()
+	c := make([]float64, len(pco))
+	for i, pc := range pco {
+		c[i], _ = new(big.Float).SetInt(pc).Float64()
+	}
+	return normalized(c)
+}
+
+// Returns the coefficient array, which is {0} for the zero value.
+// The elements must not be modified.
+func (p IntPoly) co() []*big.Int {
+	if len(p.coeff) == 0 {
+		return []*big.Int{new(big.Int)}
+	}
+	return p.coeff
+}
+
+// Returns a polynomial with the given coefficients, removing zero
+// coefficients of terms with degree greater than 0.
+func normalizedInt(c []*big.Int) IntPoly {
+	i := len(c) - 1
+	for i > 0 && c[i].Sign() == 0 {
+		i--
+	}
+	if i < 0 {
+		return IntPoly{}
+	}
+	return IntPoly{c[0 : i+1]}
+}
+
+// Returns the highest degree of the polynomial's highest order term.
+func (p IntPoly) Deg() int {
+	return len(p.co()) - 1
+}
+
+// Returns a copy of the coefficient of the ith order term.
+func (p IntPoly) Coeff(i int) *big.Int {
+	if i < 0 || i > p.Deg() {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(p.co()[i])
+}
+
+// Reports whether the polynomial is identically zero.
+func (p IntPoly) isZero() bool {
+	return p.Deg() == 0 && p.co()[0].Sign() == 0
+}
+
+// Evaluates a polynomial at the given point x using Horner's method.
+func (p IntPoly) Eval(x *big.Int) *big.Int {
+	pco := p.co()
+	n := new(big.Int)
+	for i := len(pco) - 1; i >= 0; i-- {
+		n.Mul(n, x)
+		n.Add(n, pco[i])
+	}
+	return n
+}
+
+// Adds a polynomial to another polynomial.
+// Returns p+q.
+func (p IntPoly) Add(q IntPoly) IntPoly {
+	pco, qco := p.co(), q.co()
+	if len(pco) < len(qco) {
+		pco, qco = qco, pco
+	}
+	c := make([]*big.Int, len(pco))
+	for i, pc := range pco {
+		c[i] = new(big.Int).Set(pc)
+		if i < len(qco) {
+			c[i].Add(c[i], qco[i])
+		}
+	}
+	return normalizedInt(c)
+}
+
+// Subtracts a polynomial from another polynomial.
+// Returns p-q.
+func (p IntPoly) Sub(q IntPoly) IntPoly {
+	return p.Add(q.Neg())
+}
+
+// Multiplies a polynomial by a scalar.
+// Returns k*p.
+func (p IntPoly) Scale(k *big.Int) IntPoly {
+	pco := p.co()
+	c := make([]*big.Int, len(pco))
+	for i, pc := range pco {
+		c[i] = new(big.Int).Mul(k, pc)
+	}
+	return normalizedInt(c)
+}
+
+// Negates a polynomial.
+// Returns -p.
+func (p IntPoly) Neg() IntPoly {
+	pco := p.co()
+	c := make([]*big.Int, len(pco))
+	for i, pc := range pco {
+		c[i] = new(big.Int).Neg(pc)
+	}
+	return normalizedInt(c)
+}
+
+// Multiplies a polynomial by another polynomial.
+// Returns p*q.
+func (p IntPoly) Mul(q IntPoly) IntPoly {
+	pco, qco := p.co(), q.co()
+	c := make([]*big.Int, len(pco)+len(qco)-1)
+	for i := range c {
+		c[i] = new(big.Int)
+	}
+	var t big.Int
+	for i, pc := range pco {
+		for j, qc := range qco {
+			c[i+j].Add(c[i+j], t.Mul(pc, qc))
+		}
+	}
+	return normalizedInt(c)
+}
+
+// Computes the derivative of a polynomial.
+func (p IntPoly) Der() IntPoly {
+	pco := p.co()
+	c := make([]*big.Int, len(pco)-1)
+	for i := range c {
+		c[i] = new(big.Int).Mul(pco[i+1], big.NewInt(int64(i+1)))
+	}
+	return normalizedInt(c)
+}
+
+// Divides a polynomial by another polynomial using pseudo-division, which
+// avoids fractions by first multiplying p by a power of the leading
+// coefficient of q.
+// Returns quo and rem such that lc(q)^(deg(p)-deg(q)+1) * p = quo*q + rem and
+// the degree of rem is less than the degree of q, where lc(q) is the leading
+// coefficient of q. If deg(p) < deg(q), the quotient is zero and the remainder
+// is p.
+// Panics if q is zero.
+func (p IntPoly) PseudoDivMod(q IntPoly) (quo, rem IntPoly) {
+	if q.isZero() {
+		panic("poly: division by zero")
+	}
+	qco := q.co()
+	d := len(qco) - 1
+	lead := qco[d]
+	pco := p.co()
+	if len(pco) <= d {
+		return IntPoly{}, p
+	}
+	r := make([]*big.Int, len(pco))
+	for i, pc := range pco {
+		r[i] = new(big.Int).Set(pc)
+	}
+	c := make([]*big.Int, len(pco)-d)
+	for i := range c {
+		c[i] = new(big.Int)
+	}
+	var t big.Int
+	for i := len(r) - 1; i >= d; i-- {
+		// Multiply everything so far by lead, then cancel the term of degree i.
+		for _, ci := range c {
+			ci.Mul(ci, lead)
+		}
+		for j := 0; j < i; j++ {
+			r[j].Mul(r[j], lead)
+		}
+		k := r[i]
+		c[i-d].Set(k)
+		for j := 0; j < d; j++ {
+			r[i-d+j].Sub(r[i-d+j], t.Mul(k, qco[j]))
+		}
+	}
+	return normalizedInt(c), normalizedInt(r[:d])
+}
+
+// Returns the content of the polynomial, which is the greatest common divisor
+// of its coefficients. The content has the sign of the leading coefficient,
+// so that the primitive part has a positive leading coefficient.
+// The content of the zero polynomial is zero.
+func (p IntPoly) Content() *big.Int {
+	g := new(big.Int)
+	for _, pc := range p.co() {
+		g.GCD(nil, nil, g, new(big.Int).Abs(pc))
+	}
+	if p.co()[p.Deg()].Sign() < 0 {
+		g.Neg(g)
+	}
+	return g
+}
+
+// Returns the primitive part of the polynomial, which is p divided by its
+// content. The primitive part of the zero polynomial is zero.
+func (p IntPoly) PrimitivePart() IntPoly {
+	g := p.Content()
+	if g.Sign() == 0 {
+		return IntPoly{}
+	}
+	pco := p.co()
+	c := make([]*big.Int, len(pco))
+	for i, pc := range pco {
+		c[i] = new(big.Int).Quo(pc, g)
+	}
+	return normalizedInt(c)
+}
+
+// Computes the resultant of two polynomials, which is the determinant of
+// their Sylvester matrix. The resultant is zero exactly when p and q have a
+// common root, or when either is zero.
+func (p IntPoly) Resultant(q IntPoly) *big.Int {
+	if p.isZero() || q.isZero() {
+		return new(big.Int)
+	}
+	m, n := p.Deg(), q.Deg()
+	s := m + n
+	a := make([][]*big.Int, s)
+	for i := range a {
+		a[i] = make([]*big.Int, s)
+		for j := range a[i] {
+			a[i][j] = new(big.Int)
+		}
+	}
+	// The first n rows hold shifted copies of p, and the last m rows hold
+	// shifted copies of q, each with the highest order coefficient first.
+	for i := 0; i < n; i++ {
+		for j := 0; j <= m; j++ {
+			a[i][i+j].Set(p.co()[m-j])
+		}
+	}
+	for i := 0; i < m; i++ {
+		for j := 0; j <= n; j++ {
+			a[n+i][i+j].Set(q.co()[n-j])
+		}
+	}
+	return bareiss(a)
+}
+
+// Computes the determinant of a square integer matrix using the Bareiss
+// algorithm, which keeps every intermediate value an integer. The matrix is
+// overwritten.
+func bareiss(a [][]*big.Int) *big.Int {
+	n := len(a)
+	sign := 1
+	prev := big.NewInt(1)
+	var t big.Int
+	for k := 0; k < n-1; k++ {
+		if a[k][k].Sign() == 0 {
+			i := k + 1
+			for i < n && a[i][k].Sign() == 0 {
+				i++
+			}
+			if i == n {
+				return new(big.Int)
+			}
+			a[k], a[i] = a[i], a[k]
+			sign = -sign
+		}
+		for i := k + 1; i < n; i++ {
+			for j := k + 1; j < n; j++ {
+				a[i][j].Mul(a[i][j], a[k][k])
+				a[i][j].Sub(a[i][j], t.Mul(a[i][k], a[k][j]))
+				a[i][j].Quo(a[i][j], prev)
+			}
+		}
+		prev = a[k][k]
+	}
+	if n == 0 {
+		return big.NewInt(1)
+	}
+	d := new(big.Int).Set(a[n-1][n-1])
+	if sign < 0 {
+		d.Neg(d)
+	}
+	return d
+}
+
+// Returns a printable string representing the polynomial.
+func (p IntPoly) String() string {
+	var b strings.Builder
+	pco := p.co()
+	for i := len(pco) - 1; i >= 0; i-- {
+		if pco[i].Sign() == 0 && (i > 0 || b.Len() > 0) {
+			continue
+		}
+		s := pco[i].String()
+		switch {
+		case b.Len() == 0:
+		case strings.HasPrefix(s, "-"):
+			b.WriteString(" - ")
+			s = s[1:]
+		default:
+			b.WriteString(" + ")
+		}
+		if i > 0 && s == "1" {
+			s = ""
+		} else if i > 0 && s == "-1" {
+			s = "-"
+		}
+		b.WriteString(s)
+		switch {
+		case i == 1:
+			b.WriteString("x")
+		case i > 1:
+			fmt.Fprintf(&b, "x^%d", i)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,122 @@
+package poly
+
+import (
+	"math/big"
+	"testing"
+)
+
+// Tests exact arithmetic on integer polynomials.
+func TestIntPoly(t *testing.T) {
+	p := NewInt(1, -2, 3)
+	q := NewInt(-1, 1)
+	cases := []struct {
+		name      string
+		got, want IntPoly
+	}{
+		{"Add", p.Add(q), NewInt(0, -1, 3)},
+		{"Sub", p.Sub(p), IntPoly{}},
+		{"Neg", q.Neg(), NewInt(1, -1)},
+		{"Scale", p.Scale(big.NewInt(-2)), NewInt(-2, 4, -6)},
+		{"Mul", p.Mul(q), NewInt(-1, 3, -5, 3)},
+		{"Der", p.Der(), NewInt(-2, 6)},
+	}
+	for _, c := range cases {
+		if c.got.String() != c.want.String() {
+			t.Errorf("%s == %q, want %q", c.name, c.got, c.want)
+		}
+	}
+	if got, want := p.String(), "3x^2 - 2x + 1"; got != want {
+		t.Errorf("String() == %q, want %q", got, want)
+	}
+	if got, want := p.Eval(big.NewInt(-3)), big.NewInt(34); got.Cmp(want) != 0 {
+		t.Errorf("Eval(-3) == %v, want %v", got, want)
+	}
+	// Products exceeding the range of int64 are exact.
+	big := NewInt(1<<62, 1).Mul(NewInt(1<<62, 1))
+	if got, want := big.Coeff(0).String(), "21267647932558653966460912964485513216"; got != want {
+		t.Errorf("Coeff(0) of square == %s, want %s", got, want)
+	}
+}
+
+// Tests that pseudo-division satisfies lc(q)^(m-n+1) p = quo*q + rem.
+func TestIntPolyPseudoDivMod(t *testing.T) {
+	cases := []struct {
+		p, q     IntPoly
+		quo, rem IntPoly
+	}{
+		{NewInt(1, 2, 3), NewInt(1, 2), NewInt(1, 6), NewInt(3)},
+		{NewInt(-1, 0, 1), NewInt(-1, 1), NewInt(1, 1), IntPoly{}},
+		{NewInt(5), NewInt(1, 1), IntPoly{}, NewInt(5)},
+		{NewInt(1, 0, 0, 2), NewInt(3, 0, 3), NewInt(0, 6), NewInt(9, -18)},
+	}
+	for i, c := range cases {
+		quo, rem := c.p.PseudoDivMod(c.q)
+		if quo.String() != c.quo.String() || rem.String() != c.rem.String() {
+			t.Errorf("case %d: PseudoDivMod(%q) on %q == %q, %q, want %q, %q", i, c.q, c.p, quo, rem, c.quo, c.rem)
+		}
+		k := new(big.Int).Exp(c.q.Coeff(c.q.Deg()), big.NewInt(int64(max(c.p.Deg()-c.q.Deg()+1, 0))), nil)
+		if got, want := quo.Mul(c.q).Add(rem), c.p.Scale(k); got.String() != want.String() {
+			t.Errorf("case %d: quo*q + rem == %q, want %q", i, got, want)
+		}
+	}
+}
+
+// Tests the content and primitive part of integer polynomials.
+func TestIntPolyContent(t *testing.T) {
+	cases := []struct {
+		p         IntPoly
+		content   int64
+		primitive IntPoly
+	}{
+		{IntPoly{}, 0, IntPoly{}},
+		{NewInt(6), 6, NewInt(1)},
+		{NewInt(4, -6, 2), 2, NewInt(2, -3, 1)},
+		{NewInt(4, 0, -6), -2, NewInt(-2, 0, 3)},
+		{NewInt(3, 5), 1, NewInt(3, 5)},
+	}
+	for i, c := range cases {
+		if got := c.p.Content(); got.Int64() != c.content {
+			t.Errorf("case %d: Content() on %q == %v, want %v", i, c.p, got, c.content)
+		}
+		if got := c.p.PrimitivePart(); got.String() != c.primitive.String() {
+			t.Errorf("case %d: PrimitivePart() on %q == %q, want %q", i, c.p, got, c.primitive)
+		}
+	}
+}
+
+// Tests that resultants are computed exactly.
+func TestIntPolyResultant(t *testing.T) {
+	cases := []struct {
+		p, q IntPoly
+		want int64
+	}{
+		{IntPoly{}, NewInt(1, 1), 0},
+		{NewInt(3), NewInt(5), 1},
+		{NewInt(3), NewInt(1, 2, 1), 9},
+		// Common root x = 1.
+		{NewInt(-1, 0, 1), NewInt(-1, 1), 0},
+		// Res(x - a, q) is q(a).
+		{NewInt(-2, 1), NewInt(-5, 1), -3},
+		// Res(p, p') of x^2 + bx + c is -(b^2 - 4c).
+		{NewInt(1, 3, 1), NewInt(3, 2), -5},
+		// The leading coefficient of the Sylvester matrix is zero, forcing
+		// a pivot.
+		{NewInt(1, 0, 1), NewInt(1, 1, 0, 1), 1},
+	}
+	for i, c := range cases {
+		if got := c.p.Resultant(c.q); got.Int64() != c.want {
+			t.Errorf("case %d: Resultant(%q) on %q == %v, want %v", i, c.q, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests conversion of integer polynomials to other types.
+func TestIntPolyConvert(t *testing.T) {
+	p := NewInt(4, 0, -6)
+	if got, want := p.Poly(), New(4, 0, -6); !equalCoeffs(got, want) {
+		t.Errorf("Poly() on %q == %q, want %q", p, got, want)
+	}
+	if got, want := p.Rat().String(), "-6x^2 + 4"; got != want {
+		t.Errorf("Rat() on %q == %q, want %q", p, got, want)
+	}
+}
@@ -0,0 +1,74 @@
+package poly
+
+import "math/big"
+
+// Reports whether a polynomial is irreducible over GF(p), using Rabin's test.
+// A polynomial f of degree n is irreducible exactly when f divides
+// x^(p^n) - x, and f is coprime to x^(p^(n/r)) - x for every prime r dividing
+// n. Constants are not irreducible.
+func (f GFPoly) IsIrreducible() bool {
+	n := f.Deg()
+	if n < 1 {
+		return false
+	}
+	x := NewGF(f.p, 0, 1)
+	p := new(big.Int).SetUint64(f.p)
+	// frob[k] is x^(p^k) mod f, computed by repeatedly applying the
+	// Frobenius map h -> h^p.
+	frob := make([]GFPoly, n+1)
+	frob[0] = x.Mod(f)
+	for k := 1; k <= n; k++ {
+		frob[k] = frob[k-1].PowMod(p, f)
+	}
+	if !frob[n].Sub(x).Mod(f).isZero() {
+		return false
+	}
+	for _, r := range primeFactors(n) {
+		if g := frob[n/r].Sub(x).GCD(f); g.Deg() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Reports whether a polynomial is irreducible over GF(2), using Rabin's test.
+// An irreducible polynomial of degree m defines the field GF(2^m).
+// Constants are not irreducible.
+func (f GF2Poly) IsIrreducible() bool {
+	n := f.deg()
+	if n < 1 {
+		return false
+	}
+	x := NewGF2(1)
+	frob := make([]GF2Poly, n+1)
+	frob[0] = x.Mod(f)
+	for k := 1; k <= n; k++ {
+		frob[k] = frob[k-1].MulMod(frob[k-1], f)
+	}
+	if !frob[n].Add(x).Mod(f).isZero() {
+		return false
+	}
+	for _, r := range primeFactors(n) {
+		if g := frob[n/r].Add(x).GCD(f); g.deg() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the distinct prime factors of n > 0 in increasing order.
+func primeFactors(n int) []int {
+	var r []int
+	for d := 2; d*d <= n; d++ {
+		if n%d == 0 {
+			r = append(r, d)
+			for n%d == 0 {
+				n /= d
+			}
+		}
+	}
+	if n > 1 {
+		r = append(r, n)
+	}
+	return r
+}
@@ -0,0 +1,70 @@
+package poly
+
+import "testing"
+
+// Tests irreducibility of polynomials over GF(p).
+func TestGFPolyIsIrreducible(t *testing.T) {
+	cases := []struct {
+		f    GFPoly
+		want bool
+	}{
+		{GFPoly{}, false},
+		{NewGF(5, 3), false},
+		{NewGF(5, 3, 2), true},
+		// x^2 + 1 has roots 2 and 3 over GF(5) but none over GF(7).
+		{NewGF(5, 1, 0, 1), false},
+		{NewGF(7, 1, 0, 1), true},
+		{NewGF(3, 2, 2, 0, 1), true},
+		// (x^2 + 1)^2 over GF(7) has no roots but is reducible.
+		{NewGF(7, 1, 0, 1).Pow(2), false},
+		// Product of irreducible quadratic and cubic over GF(3).
+		{NewGF(3, 1, 0, 1).Mul(NewGF(3, 2, 2, 0, 1)), false},
+		// x^4 + x + 2 over GF(3) is irreducible.
+		{NewGF(3, 2, 1, 0, 0, 1), true},
+		// Irreducible over a large prime field: x^2 - 3 where 3 is a
+		// quadratic non-residue modulo 1000003.
+		{NewGF(1000003, 1000000, 0, 1), true},
+	}
+	for i, c := range cases {
+		if got := c.f.IsIrreducible(); got != c.want {
+			t.Errorf("case %d: IsIrreducible() on %q == %v, want %v", i, c.f, got, c.want)
+		}
+	}
+}
+
+// Tests irreducibility of polynomials over GF(2).
+func TestGF2PolyIsIrreducible(t *testing.T) {
+	cases := []struct {
+		f    GF2Poly
+		want bool
+	}{
+		{GF2Poly{}, false},
+		{NewGF2(0), false},
+		{NewGF2(1), true},
+		{NewGF2(2, 1, 0), true},
+		{NewGF2(2, 0), false},
+		{aesPoly, true},
+		{NewGF2(8, 4, 3, 2, 0), true},
+		// (x^2 + x + 1)^2 has no roots but is reducible.
+		{NewGF2(4, 2, 0), false},
+		// (x^2 + x + 1)(x^3 + x + 1) has no roots or quadratic repeats.
+		{NewGF2(2, 1, 0).Mul(NewGF2(3, 1, 0)), false},
+		{NewGF2(128, 7, 2, 1, 0), true},
+		{NewGF2(128, 7, 2, 1), false},
+	}
+	for i, c := range cases {
+		if got := c.f.IsIrreducible(); got != c.want {
+			t.Errorf("case %d: IsIrreducible() on %q == %v, want %v", i, c.f, got, c.want)
+		}
+	}
+	// There are 30 irreducible polynomials of degree 8 over GF(2).
+	n := 0
+	for v := uint64(256); v < 512; v++ {
+		if GF2FromUint(v).IsIrreducible() {
+			n++
+		}
+	}
+	if n != 30 {
+		t.Errorf("found %d irreducible polynomials of degree 8, want 30", n)
+	}
+}
@@ -0,0 +1,211 @@
+package poly
+
+import (
+	"fmt"
+	"math"
+)
+
+// LaurentPoly represents a Laurent polynomial, a finite sum of terms c*x^e
+// in which the exponents e may be negative, such as the z-transform
+// 1 + 0.5z^-1 + 0.25z^-2 of a finite impulse response.
+// A zero valued LaurentPoly is equivalent to 0.
+type LaurentPoly struct {
+	// The exponent of the first coefficient.
+	low   int
+	coeff []float64
+}
+
+// Creates a new Laurent polynomial whose lowest order term is x^low.
+// The ith coefficient parameter represents the coefficient of x^(low+i).
+// Example:
+//
+//	p := poly.NewLaurent(-2, 3, 0, 1, 2)
+//
+//	This represents 2x + 1 + 3x^-2
+func NewLaurent(low int, c ...float64) LaurentPoly {
+	a := make([]float64, len(c))
+	copy(a, c)
+	return normalizedLaurent(low, a)
+}
+
+// Converts a polynomial to a Laurent polynomial.
+func (p Poly) Laurent() LaurentPoly {
+	return NewLaurent(0, p.co()...)
+}
+
+// Returns a Laurent polynomial with the given coefficients, removing zero
+// coefficients from both ends.
+func normalizedLaurent(low int, c []float64) LaurentPoly {
+	i, j := 0, len(c)
+	for i < j && c[i] == 0 {
+		i++
+	}
+	for j > i && c[j-1] == 0 {
+		j--
+	}
+	if i == j {
+		return LaurentPoly{}
+	}
+	return LaurentPoly{low + i, c[i:j]}
+}
+
+// Returns the exponent of the highest order term, which is 0 for the zero
+// polynomial.
+func (l LaurentPoly) Deg() int {
+	return l.low + max(len(l.coeff)-1, 0)
+}
+
+// Returns the exponent of the lowest order term, which is 0 for the zero
+// polynomial.
+func (l LaurentPoly) MinDeg() int {
+	return l.low
+}
+
+// Returns the coefficient of x^e.
+func (l LaurentPoly) Coeff(e int) float64 {
+	i := e - l.low
+	if i < 0 || i >= len(l.coeff) {
+		return 0
+	}
+	return l.coeff[i]
+}
+
+// Evaluates a Laurent polynomial at the given point x, which must be nonzero
+// if there are terms of negative degree.
+func (l LaurentPoly) Eval(x float64) float64 {
+	var n float64
+	for i := len(l.coeff) - 1; i >= 0; i-- {
+		n = n*x + l.coeff[i]
+	}
+	return n * math.Pow(x, float64(l.low))
+}
+
+// Evaluates a Laurent polynomial at the given complex point z, which must be
+// nonzero if there are terms of negative degree. For a z-transform in z^-1,
+// the frequency response at w radians per sample is EvalC(cmplx.Rect(1, w)).
+func (l LaurentPoly) EvalC(z complex128) complex128 {
+	var n complex128
+	for i := len(l.coeff) - 1; i >= 0; i-- {
+		n = n*z + complex(l.coeff[i], 0)
+	}
+	if l.low >= 0 {
+		for i := 0; i < l.low; i++ {
+			n *= z
+		}
+		return n
+	}
+	for i := 0; i < -l.low; i++ {
+		n /= z
+	}
+	return n
+}
+
+// Adds a Laurent polynomial to another Laurent polynomial.
+// Returns l+m.
+func (l LaurentPoly) Add(m LaurentPoly) LaurentPoly {
+	if len(l.coeff) == 0 {
+		return m
+	}
+	if len(m.coeff) == 0 {
+		return l
+	}
+	low := min(l.low, m.low)
+	c := make([]float64, max(l.Deg(), m.Deg())-low+1)
+	for i, lc := range l.coeff {
+		c[l.low-low+i] += lc
+	}
+	for i, mc := range m.coeff {
+		c[m.low-low+i] += mc
+	}
+	return normalizedLaurent(low, c)
+}
+
+// Subtracts a Laurent polynomial from another Laurent polynomial.
+// Returns l-m.
+func (l LaurentPoly) Sub(m LaurentPoly) LaurentPoly {
+	return l.Add(m.Neg())
+}
+
+// Multiplies a Laurent polynomial by a scalar.
+// Returns k*l.
+func (l LaurentPoly) Scale(k float64) LaurentPoly {
+	c := make([]float64, len(l.coeff))
+	for i, lc := range l.coeff {
+		c[i] = k * lc
+	}
+	return normalizedLaurent(l.low, c)
+}
+
+// Negates a Laurent polynomial.
+// Returns -l.
+func (l LaurentPoly) Neg() LaurentPoly {
+	return l.Scale(-1)
+}
+
+// Multiplies a Laurent polynomial by another Laurent polynomial.
+// Returns l*m.
+func (l LaurentPoly) Mul(m LaurentPoly) LaurentPoly {
+	if len(l.coeff) == 0 || len(m.coeff) == 0 {
+		return LaurentPoly{}
+	}
+	c := make([]float64, len(l.coeff)+len(m.coeff)-1)
+	for i, lc := range l.coeff {
+		for j, mc := range m.coeff {
+			c[i+j] += lc * mc
+		}
+	}
+	return normalizedLaurent(l.low+m.low, c)
+}
+
+// Multiplies a Laurent polynomial by x^k, shifting every exponent by k.
+// For a z-transform in z^-1, MulX(-k) delays the signal by k samples.
+func (l LaurentPoly) MulX(k int) LaurentPoly {
+	return NewLaurent(l.low+k, l.coeff...)
+}
+
+// Computes the derivative of a Laurent polynomial. Unlike the derivative of a
+// polynomial, the x^-1 term is not the derivative of any Laurent polynomial,
+// so Laurent polynomials have no general integral.
+func (l LaurentPoly) Der() LaurentPoly {
+	c := make([]float64, len(l.coeff))
+	for i, lc := range l.coeff {
+		c[i] = lc * float64(l.low+i)
+	}
+	return normalizedLaurent(l.low-1, c)
+}
+
+// Converts a Laurent polynomial to an ordinary polynomial.
+// Returns the polynomial, and false if there are terms of negative degree,
+// in which case the polynomial is zero.
+func (l LaurentPoly) Poly() (Poly, bool) {
+	if l.low < 0 {
+		return Poly{}, false
+	}
+	c := make([]float64, l.low+len(l.coeff))
+	copy(c[l.low:], l.coeff)
+	return normalized(c), true
+}
+
+// Writes a Laurent polynomial as a ratio of ordinary polynomials.
+// Returns num and den such that l = num/den, where den is x^k for the
+// smallest k >= 0 that makes num a polynomial. The pair may be passed to
+// NewTransferFunction.
+func (l LaurentPoly) Rational() (num, den Poly) {
+	k := max(-l.low, 0)
+	num, _ = l.MulX(k).Poly()
+	d := make([]float64, k+1)
+	d[k] = 1
+	return num, normalized(d)
+}
+
+// Returns a printable string representing the Laurent polynomial, with terms
+// written highest order first as for Poly.
+func (l LaurentPoly) String() string {
+	return termFormat{
+		coeff: func(c float64) string {
+			return fmt.Sprintf("%.3f", c)
+		},
+		min:   0.0001,
+		shift: l.low,
+	}.format(Poly{l.coeff})
+}
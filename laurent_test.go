@@ -0,0 +1,100 @@
+package poly
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// Tests Laurent polynomial construction and string representation.
+func TestLaurentString(t *testing.T) {
+	cases := []struct {
+		l    LaurentPoly
+		want string
+	}{
+		{LaurentPoly{}, "0.000"},
+		{NewLaurent(-2, 3, 0, 1, 2), "2.000x + 1.000 + 3.000x^-2"},
+		{NewLaurent(-1, 1), "x^-1"},
+		{NewLaurent(-3, 0, -1, 0.5, 0), "0.500x^-1 - x^-2"},
+		{NewLaurent(2, 1, 0, 0), "x^2"},
+		{New(1, 2).Laurent(), "2.000x + 1.000"},
+	}
+	for i, c := range cases {
+		if got := c.l.String(); got != c.want {
+			t.Errorf("case %d: String() == %q, want %q", i, got, c.want)
+		}
+	}
+	l := NewLaurent(-3, 0, -1, 0.5, 0)
+	if l.Deg() != -1 || l.MinDeg() != -2 {
+		t.Errorf("Deg(), MinDeg() on %q == %d, %d, want -1, -2", l, l.Deg(), l.MinDeg())
+	}
+}
+
+// Tests arithmetic on Laurent polynomials.
+func TestLaurentArith(t *testing.T) {
+	l := NewLaurent(-1, 1, 2)     // 2 + x^-1
+	m := NewLaurent(-2, -1, 0, 1) // x^0 - x^-2
+	cases := []struct {
+		name      string
+		got, want LaurentPoly
+	}{
+		{"Add", l.Add(m), NewLaurent(-2, -1, 1, 3)},
+		{"Add zero", l.Add(LaurentPoly{}), l},
+		{"Sub", l.Sub(l), LaurentPoly{}},
+		{"Scale", l.Scale(2), NewLaurent(-1, 2, 4)},
+		{"Mul", l.Mul(m), NewLaurent(-3, -1, -2, 1, 2)},
+		// (x + x^-1)(x - x^-1) = x^2 - x^-2.
+		{"Mul cancel", NewLaurent(-1, 1, 0, 1).Mul(NewLaurent(-1, -1, 0, 1)), NewLaurent(-2, -1, 0, 0, 0, 1)},
+		{"MulX", l.MulX(3), NewLaurent(2, 1, 2)},
+		{"Der", NewLaurent(-2, 1, 1, 1, 1).Der(), NewLaurent(-3, -2, -1, 0, 1)},
+	}
+	for _, c := range cases {
+		if c.got.String() != c.want.String() || c.got.MinDeg() != c.want.MinDeg() {
+			t.Errorf("%s == %q, want %q", c.name, c.got, c.want)
+		}
+	}
+}
+
+// Tests evaluation of Laurent polynomials.
+func TestLaurentEval(t *testing.T) {
+	l := NewLaurent(-2, 3, 0, 1, 2)
+	if got, want := l.Eval(2), 5.75; math.Abs(got-want) > 0.00001 {
+		t.Errorf("Eval(2) on %q == %v, want %v", l, got, want)
+	}
+	if got, want := l.EvalC(1i), complex(-2, 2); cmplx.Abs(got-want) > 0.00001 {
+		t.Errorf("EvalC(i) on %q == %v, want %v", l, got, want)
+	}
+	// A two tap moving average has a zero at the Nyquist frequency.
+	h := NewLaurent(-1, 0.5, 0.5)
+	if got := h.EvalC(cmplx.Rect(1, math.Pi)); cmplx.Abs(got) > 0.00001 {
+		t.Errorf("EvalC(-1) on %q == %v, want 0", h, got)
+	}
+}
+
+// Tests conversion of Laurent polynomials to polynomials and ratios.
+func TestLaurentConvert(t *testing.T) {
+	if p, ok := NewLaurent(1, 2, 3).Poly(); !ok || !comparePoly(p, New(0, 2, 3)) {
+		t.Errorf("Poly() == %q, %v, want %q, true", p, ok, New(0, 2, 3))
+	}
+	if _, ok := NewLaurent(-1, 2, 3).Poly(); ok {
+		t.Errorf("Poly() with negative exponents succeeded")
+	}
+	cases := []struct {
+		l        LaurentPoly
+		num, den Poly
+	}{
+		{LaurentPoly{}, Poly{}, New(1)},
+		{NewLaurent(-2, 3, 0, 1, 2), New(3, 0, 1, 2), New(0, 0, 1)},
+		{NewLaurent(1, 1), New(0, 1), New(1)},
+	}
+	for i, c := range cases {
+		num, den := c.l.Rational()
+		if !comparePoly(num, c.num) || !comparePoly(den, c.den) {
+			t.Errorf("case %d: Rational() on %q == %q, %q, want %q, %q", i, c.l, num, den, c.num, c.den)
+		}
+		x := 1.7
+		if got, want := num.Eval(x)/den.Eval(x), c.l.Eval(x); math.Abs(got-want) > 0.00001 {
+			t.Errorf("case %d: num/den at %v == %v, want %v", i, x, got, want)
+		}
+	}
+}
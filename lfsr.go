@@ -0,0 +1,135 @@
+package poly
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// LFSRMode selects the configuration of a linear feedback shift register.
+type LFSRMode int
+
+const (
+	// Fibonacci configuration, in which the feedback bit is the xor of the
+	// tapped stages and is shifted into the register.
+	Fibonacci LFSRMode = iota
+
+	// Galois configuration, in which the output bit is xored into each
+	// tapped stage as the register shifts.
+	Galois
+)
+
+// LFSR is a binary linear feedback shift register of between 1 and 64
+// stages, driven by a feedback polynomial over GF(2).
+// For a feedback polynomial c = 1 + c[1]x + ... + c[n]x^n, the output
+// sequence satisfies s[k] = c[1]s[k-1] + ... + c[n]s[k-n] modulo 2 in either
+// configuration, so BerlekampMasseyGF2 recovers c from the output. When c is
+// primitive, every nonzero state has the maximal period 2^n - 1.
+type LFSR struct {
+	mode  LFSRMode
+	n     int
+	taps  uint64
+	state uint64
+}
+
+// Creates an LFSR with the feedback polynomial f in the given configuration,
+// starting from the given state. Only the low deg(f) bits of the state are
+// used.
+// Returns an error if the degree of f is not between 1 and 64, or if f has no
+// constant term, which would make the register singular.
+func NewLFSR(f GF2Poly, mode LFSRMode, state uint64) (*LFSR, error) {
+	n := f.deg()
+	if n < 1 || n > 64 {
+		return nil, fmt.Errorf("poly: feedback polynomial %v has degree %d, want 1 to 64", f, n)
+	}
+	if f.Coeff(0) == 0 {
+		return nil, fmt.Errorf("poly: feedback polynomial %v has no constant term", f)
+	}
+	var taps uint64
+	switch mode {
+	case Fibonacci:
+		// Bit j of the state is s[k+j], and the feedback bit s[k+n] is
+		// c[n-j] times bit j, summed over j.
+		taps = reverseBits(f.w[0]>>1, n)
+		if n == 64 {
+			taps |= 1
+		}
+	case Galois:
+		taps = f.w[0] >> 1
+		if n == 64 {
+			taps |= 1 << 63
+		}
+	default:
+		return nil, fmt.Errorf("poly: invalid LFSR mode %d", mode)
+	}
+	return &LFSR{mode, n, taps, state & crcMask(n)}, nil
+}
+
+// Returns the number of stages.
+func (r *LFSR) Len() int {
+	return r.n
+}
+
+// Returns the current state.
+func (r *LFSR) State() uint64 {
+	return r.state
+}
+
+// Sets the state. Only the low Len() bits are used.
+func (r *LFSR) SetState(state uint64) {
+	r.state = state & crcMask(r.n)
+}
+
+// Advances the register by one step and returns the output bit.
+func (r *LFSR) Step() uint {
+	out := r.state & 1
+	switch r.mode {
+	case Fibonacci:
+		fb := uint64(bits.OnesCount64(r.state&r.taps)) & 1
+		r.state = r.state>>1 | fb<<(r.n-1)
+	case Galois:
+		r.state >>= 1
+		if out == 1 {
+			r.state ^= r.taps
+		}
+	}
+	return uint(out)
+}
+
+// Advances the register by n steps and returns the output bits, one per
+// byte.
+func (r *LFSR) Bits(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(r.Step())
+	}
+	return b
+}
+
+// Advances the register by 8n steps and returns the output packed into bytes,
+// least significant bit first.
+func (r *LFSR) Bytes(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		for j := 0; j < 8; j++ {
+			b[i] |= byte(r.Step()) << j
+		}
+	}
+	return b
+}
+
+// Computes the period of the register from its current state, which is the
+// number of steps until the state repeats, by stepping at most limit times.
+// The register is left in its original state.
+// Returns the period, and false if it exceeds limit.
+func (r *LFSR) Period(limit uint64) (uint64, bool) {
+	start := r.state
+	for k := uint64(1); k <= limit; k++ {
+		r.Step()
+		if r.state == start {
+			return k, true
+		}
+	}
+	// Restore the state, since the cycle was not completed.
+	r.state = start
+	return 0, false
+}
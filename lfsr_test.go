@@ -0,0 +1,102 @@
+package poly
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Tests that both configurations generate sequences with the feedback
+// polynomial as their connection polynomial.
+func TestLFSR(t *testing.T) {
+	polys := []GF2Poly{
+		NewGF2(1, 0),
+		NewGF2(4, 1, 0),
+		NewGF2(5, 3, 0),
+		NewGF2(8, 4, 3, 2, 0),
+		NewGF2(16, 5, 3, 2, 0),
+		NewGF2(64, 4, 3, 1, 0),
+	}
+	for _, f := range polys {
+		for _, mode := range []LFSRMode{Fibonacci, Galois} {
+			r, err := NewLFSR(f, mode, 1)
+			if err != nil {
+				t.Fatalf("NewLFSR(%q, %d) returned error %v", f, mode, err)
+			}
+			s := r.Bits(3 * f.Deg())
+			c, l := BerlekampMasseyGF2(s)
+			if !c.Equal(f) || l != f.Deg() {
+				t.Errorf("mode %d: BerlekampMasseyGF2 of output of %q == %q, %d", mode, f, c, l)
+			}
+		}
+	}
+}
+
+// Tests the output of a small Fibonacci LFSR.
+func TestLFSRFibonacci(t *testing.T) {
+	r, _ := NewLFSR(NewGF2(4, 3, 0), Fibonacci, 0b0001)
+	if got, want := fmt.Sprint(r.Bits(15)), "[1 0 0 0 1 0 0 1 1 0 1 0 1 1 1]"; got != want {
+		t.Errorf("Bits(15) == %s, want %s", got, want)
+	}
+	if got, want := r.State(), uint64(0b0001); got != want {
+		t.Errorf("State() after a full period == %#b, want %#b", got, want)
+	}
+	r.SetState(0xff)
+	if got, want := r.State(), uint64(0xf); got != want {
+		t.Errorf("SetState(0xff) gives State() == %#x, want %#x", got, want)
+	}
+}
+
+// Tests LFSR period detection.
+func TestLFSRPeriod(t *testing.T) {
+	cases := []struct {
+		f     GF2Poly
+		mode  LFSRMode
+		state uint64
+		want  uint64
+	}{
+		{NewGF2(4, 1, 0), Fibonacci, 1, 15},
+		{NewGF2(4, 1, 0), Galois, 9, 15},
+		// Irreducible but not primitive, so the period divides 15.
+		{NewGF2(4, 3, 2, 1, 0), Fibonacci, 1, 5},
+		{NewGF2(4, 3, 2, 1, 0), Galois, 1, 5},
+		// The zero state is fixed.
+		{NewGF2(4, 1, 0), Galois, 0, 1},
+		{NewGF2(16, 5, 3, 2, 0), Galois, 0xace1, 65535},
+		{NewGF2(16, 5, 3, 2, 0), Fibonacci, 0xace1, 65535},
+	}
+	for i, c := range cases {
+		r, _ := NewLFSR(c.f, c.mode, c.state)
+		got, ok := r.Period(1 << 20)
+		if !ok || got != c.want {
+			t.Errorf("case %d: Period() of %q == %d, %v, want %d", i, c.f, got, ok, c.want)
+		}
+		if r.State() != c.state {
+			t.Errorf("case %d: State() after Period() == %#x, want %#x", i, r.State(), c.state)
+		}
+	}
+	r, _ := NewLFSR(NewGF2(32, 22, 2, 1, 0), Galois, 1)
+	if _, ok := r.Period(1000); ok {
+		t.Errorf("Period(1000) of 32 stage LFSR reported completion")
+	}
+	if r.State() != 1 {
+		t.Errorf("State() after incomplete Period() == %#x, want 1", r.State())
+	}
+}
+
+// Tests that invalid feedback polynomials are rejected.
+func TestNewLFSRError(t *testing.T) {
+	cases := []struct {
+		f    GF2Poly
+		mode LFSRMode
+	}{
+		{NewGF2(0), Fibonacci},
+		{NewGF2(4, 1), Fibonacci},
+		{NewGF2(65, 0), Galois},
+		{NewGF2(4, 1, 0), LFSRMode(2)},
+	}
+	for i, c := range cases {
+		if _, err := NewLFSR(c.f, c.mode, 1); err == nil {
+			t.Errorf("case %d: NewLFSR(%q, %d) succeeded, want error", i, c.f, c.mode)
+		}
+	}
+}
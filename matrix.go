@@ -0,0 +1,229 @@
+package poly
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Matrix is a matrix whose entries are polynomials, such as the matrix
+// sI - A of a linear system or the Sylvester matrix of two polynomials.
+// A zero valued Matrix has no rows or columns.
+type Matrix struct {
+	rows, cols int
+	// Entries in row major order.
+	e []Poly
+}
+
+// Creates a rows by cols matrix from its entries in row major order. Missing
+// entries are zero. Panics if there are more than rows*cols entries.
+func NewMatrix(rows, cols int, entries ...Poly) Matrix {
+	if rows < 0 || cols < 0 {
+		panic("poly: negative matrix dimension")
+	}
+	if len(entries) > rows*cols {
+		panic(fmt.Sprintf("poly: %d entries for %dx%d matrix", len(entries), rows, cols))
+	}
+	e := make([]Poly, rows*cols)
+	copy(e, entries)
+	return Matrix{rows, cols, e}
+}
+
+// Creates an n by n identity matrix.
+func IdentityMatrix(n int) Matrix {
+	m := NewMatrix(n, n)
+	for i := 0; i < n; i++ {
+		m.e[i*n+i] = New(1)
+	}
+	return m
+}
+
+// Creates the matrix of constant polynomials with the given values.
+// Panics if the rows have different lengths.
+func ConstMatrix(a [][]float64) Matrix {
+	rows := len(a)
+	cols := 0
+	if rows > 0 {
+		cols = len(a[0])
+	}
+	m := NewMatrix(rows, cols)
+	for i, r := range a {
+		if len(r) != cols {
+			panic("poly: ragged matrix")
+		}
+		for j, v := range r {
+			m.e[i*cols+j] = New(v)
+		}
+	}
+	return m
+}
+
+// Returns the number of rows.
+func (m Matrix) Rows() int {
+	return m.rows
+}
+
+// Returns the number of columns.
+func (m Matrix) Cols() int {
+	return m.cols
+}
+
+// Returns the entry in row i and column j.
+func (m Matrix) At(i, j int) Poly {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		panic("poly: matrix index out of range")
+	}
+	return m.e[i*m.cols+j]
+}
+
+// Returns a copy of the matrix with the entry in row i and column j set to p.
+func (m Matrix) With(i, j int, p Poly) Matrix {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		panic("poly: matrix index out of range")
+	}
+	r := NewMatrix(m.rows, m.cols, m.e...)
+	r.e[i*m.cols+j] = p
+	return r
+}
+
+// Panics unless m and n have the same dimensions.
+func (m Matrix) checkSame(n Matrix) {
+	if m.rows != n.rows || m.cols != n.cols {
+		panic(fmt.Sprintf("poly: mismatched matrix dimensions %dx%d and %dx%d", m.rows, m.cols, n.rows, n.cols))
+	}
+}
+
+// Adds a matrix to another matrix.
+// Returns m+n.
+func (m Matrix) Add(n Matrix) Matrix {
+	m.checkSame(n)
+	r := NewMatrix(m.rows, m.cols)
+	for i := range r.e {
+		r.e[i] = m.e[i].Add(n.e[i])
+	}
+	return r
+}
+
+// Subtracts a matrix from another matrix.
+// Returns m-n.
+func (m Matrix) Sub(n Matrix) Matrix {
+	m.checkSame(n)
+	r := NewMatrix(m.rows, m.cols)
+	for i := range r.e {
+		r.e[i] = m.e[i].Sub(n.e[i])
+	}
+	return r
+}
+
+// Multiplies each entry of a matrix by a polynomial.
+// Returns p*m.
+func (m Matrix) Scale(p Poly) Matrix {
+	r := NewMatrix(m.rows, m.cols)
+	for i := range r.e {
+		r.e[i] = p.Mul(m.e[i])
+	}
+	return r
+}
+
+// Multiplies a matrix by another matrix.
+// Returns m*n. Panics unless m has as many columns as n has rows.
+func (m Matrix) Mul(n Matrix) Matrix {
+	if m.cols != n.rows {
+		panic(fmt.Sprintf("poly: mismatched matrix dimensions %dx%d and %dx%d", m.rows, m.cols, n.rows, n.cols))
+	}
+	r := NewMatrix(m.rows, n.cols)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < n.cols; j++ {
+			var s Poly
+			for k := 0; k < m.cols; k++ {
+				s = s.Add(m.e[i*m.cols+k].Mul(n.e[k*n.cols+j]))
+			}
+			r.e[i*n.cols+j] = s
+		}
+	}
+	return r
+}
+
+// Returns the transpose of a matrix.
+func (m Matrix) Transpose() Matrix {
+	r := NewMatrix(m.cols, m.rows)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			r.e[j*m.rows+i] = m.e[i*m.cols+j]
+		}
+	}
+	return r
+}
+
+// Computes the determinant of a square matrix using Bareiss fraction-free
+// elimination, in which every division is exact, so that intermediate
+// entries remain polynomials rather than rational functions.
+// Panics if the matrix is not square.
+func (m Matrix) Det() Poly {
+	n := m.rows
+	if n != m.cols {
+		panic("poly: determinant of non-square matrix")
+	}
+	if n == 0 {
+		return New(1)
+	}
+	a := make([][]Poly, n)
+	for i := range a {
+		a[i] = append([]Poly(nil), m.e[i*n:(i+1)*n]...)
+	}
+	sign := 1.0
+	prev := New(1)
+	for k := 0; k < n-1; k++ {
+		if a[k][k].isZero() {
+			i := k + 1
+			for i < n && a[i][k].isZero() {
+				i++
+			}
+			if i == n {
+				return Poly{}
+			}
+			a[k], a[i] = a[i], a[k]
+			sign = -sign
+		}
+		for i := k + 1; i < n; i++ {
+			for j := k + 1; j < n; j++ {
+				num := a[i][j].Mul(a[k][k]).Sub(a[i][k].Mul(a[k][j]))
+				a[i][j] = num.Div(prev)
+			}
+		}
+		prev = a[k][k]
+	}
+	return a[n-1][n-1].Scale(sign)
+}
+
+// Evaluates every entry of a matrix at x.
+// Returns the numeric matrix of values, indexed by row and then column.
+func (m Matrix) Eval(x float64) [][]float64 {
+	v := make([][]float64, m.rows)
+	for i := range v {
+		v[i] = make([]float64, m.cols)
+		for j := range v[i] {
+			v[i][j] = m.e[i*m.cols+j].Eval(x)
+		}
+	}
+	return v
+}
+
+// Returns a printable string representing the matrix, with each row in
+// brackets on its own line.
+func (m Matrix) String() string {
+	var b strings.Builder
+	for i := 0; i < m.rows; i++ {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("[")
+		for j := 0; j < m.cols; j++ {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(m.e[i*m.cols+j].String())
+		}
+		b.WriteString("]")
+	}
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package poly
+
+import "testing"
+
+// Tests arithmetic on polynomial matrices.
+func TestMatrixArith(t *testing.T) {
+	x := New(0, 1)
+	m := NewMatrix(2, 2, x, New(1), New(2), x.Mul(x))
+	n := NewMatrix(2, 2, New(1), x, Poly{}, New(3))
+	cases := []struct {
+		name      string
+		got, want Matrix
+	}{
+		{"Add", m.Add(n), NewMatrix(2, 2, New(1, 1), New(1, 1), New(2), New(3, 0, 1))},
+		{"Sub", m.Sub(m), NewMatrix(2, 2)},
+		{"Mul", m.Mul(n), NewMatrix(2, 2, x, New(3, 0, 1), New(2), New(0, 2, 3))},
+		{"Scale", n.Scale(x), NewMatrix(2, 2, x, x.Mul(x), Poly{}, New(0, 3))},
+		{"Transpose", NewMatrix(1, 2, x, New(1)).Transpose(), NewMatrix(2, 1, x, New(1))},
+		{"Identity", IdentityMatrix(2).Mul(m), m},
+		{"With", n.With(1, 0, x), NewMatrix(2, 2, New(1), x, x, New(3))},
+	}
+	for _, c := range cases {
+		if c.got.String() != c.want.String() {
+			t.Errorf("%s ==\n%v\nwant\n%v", c.name, c.got, c.want)
+		}
+	}
+	if got, want := m.String(), "[x, 1.000]\n[2.000, x^2]"; got != want {
+		t.Errorf("String() == %q, want %q", got, want)
+	}
+	v := m.Eval(3)
+	if !compareVec(v[0], []float64{3, 1}) || !compareVec(v[1], []float64{2, 9}) {
+		t.Errorf("Eval(3) == %v, want [[3 1] [2 9]]", v)
+	}
+}
+
+// Tests determinants of polynomial matrices.
+func TestMatrixDet(t *testing.T) {
+	s := New(0, 1)
+	// The characteristic matrix sI - A of a companion matrix.
+	a := ConstMatrix([][]float64{{0, 1}, {-2, -3}})
+	cases := []struct {
+		m    Matrix
+		want Poly
+	}{
+		{NewMatrix(0, 0), New(1)},
+		{NewMatrix(1, 1, New(1, 2)), New(1, 2)},
+		{IdentityMatrix(2).Scale(s).Sub(a), New(2, 3, 1)},
+		// A zero pivot forces a row exchange.
+		{ConstMatrix([][]float64{{0, 1}, {1, 0}}), New(-1)},
+		{NewMatrix(3, 3, Poly{}, s, New(1), s, Poly{}, New(2), New(1), New(2), Poly{}), New(0, 4)},
+		{NewMatrix(2, 2, s, s, s, s), Poly{}},
+		{ConstMatrix([][]float64{{2, 0, 1}, {1, 3, 2}, {1, 1, 2}}), New(6)},
+	}
+	for i, c := range cases {
+		if got := c.m.Det(); !comparePoly(got, c.want) {
+			t.Errorf("case %d: Det() of\n%v\n== %q, want %q", i, c.m, got, c.want)
+		}
+	}
+	// det(AB) = det(A) det(B).
+	m := NewMatrix(3, 3, New(1, 1), New(2), New(0, 0, 1), New(0, 1), New(-1, 2), New(3), New(1), New(0, -1), New(2, 0, 1))
+	n := NewMatrix(3, 3, New(2), New(0, 1), New(1), New(1, 1), New(3), New(0, 2), New(-1), New(1), New(1, 0, 1))
+	if got, want := m.Mul(n).Det(), m.Det().Mul(n.Det()); !got.Sub(want).chop(0.00001).isZero() {
+		t.Errorf("det(mn) == %q, want %q", got, want)
+	}
+}
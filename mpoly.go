@@ -0,0 +1,431 @@
+package poly
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// MTerm is a single term c*x[0]^e[0]*x[1]^e[1]*... of a multivariate
+// polynomial.
+type MTerm struct {
+	Coeff float64
+	Exp   []int
+}
+
+// Returns the total degree of the term, the sum of its exponents.
+func (t MTerm) deg() int {
+	d := 0
+	for _, e := range t.Exp {
+		d += e
+	}
+	return d
+}
+
+// MPoly represents a polynomial in a fixed number of variables, stored
+// sparsely as a list of terms with nonzero coefficients.
+// Binary operations require both operands to have the same number of
+// variables, except that a polynomial in no variables, such as the zero
+// value, is treated as a constant in any number of variables.
+// A zero valued MPoly is equivalent to 0.
+type MPoly struct {
+	nvar int
+	// Terms sorted in decreasing graded lexicographic order of their
+	// exponents, with distinct exponents and nonzero coefficients.
+	terms []MTerm
+}
+
+// Creates a new polynomial in nvar variables as the sum of the given terms.
+// Terms with equal exponents are combined. Panics if a term does not have
+// nvar non-negative exponents.
+// Example:
+//
+//	p := poly.NewMPoly(2,
+//		poly.MTerm{Coeff: 3, Exp: []int{2, 0}},
+//		poly.MTerm{Coeff: -1, Exp: []int{1, 1}})
+//
+//	This represents 3x^2 - xy
+func NewMPoly(nvar int, terms ...MTerm) MPoly {
+	if nvar < 0 {
+		panic("poly: negative number of variables")
+	}
+	m := make(map[string]*MTerm, len(terms))
+	for _, t := range terms {
+		if len(t.Exp) != nvar {
+			panic(fmt.Sprintf("poly: term has %d exponents, want %d", len(t.Exp), nvar))
+		}
+		for _, e := range t.Exp {
+			if e < 0 {
+				panic("poly: negative exponent")
+			}
+		}
+		addTerm(m, t.Coeff, t.Exp)
+	}
+	return collectTerms(nvar, m)
+}
+
+// Creates the polynomial x[i] in nvar variables.
+// Panics unless 0 <= i < nvar.
+func MVar(nvar, i int) MPoly {
+	if i < 0 || i >= nvar {
+		panic("poly: variable index out of range")
+	}
+	e := make([]int, nvar)
+	e[i] = 1
+	return MPoly{nvar, []MTerm{{1, e}}}
+}
+
+// Creates the constant polynomial c in nvar variables.
+func MConst(nvar int, c float64) MPoly {
+	return NewMPoly(nvar, MTerm{c, make([]int, nvar)})
+}
+
+// Converts a polynomial in one variable to a polynomial in nvar variables,
+// in which the variable is x[i].
+// Panics unless 0 <= i < nvar.
+func (p Poly) Multi(nvar, i int) MPoly {
+	if i < 0 || i >= nvar {
+		panic("poly: variable index out of range")
+	}
+	var terms []MTerm
+	for e, c := range p.co() {
+		exp := make([]int, nvar)
+		exp[i] = e
+		terms = append(terms, MTerm{c, exp})
+	}
+	return NewMPoly(nvar, terms...)
+}
+
+// Returns a map key identifying an exponent vector.
+func expKey(exp []int) string {
+	b := make([]byte, 0, len(exp))
+	for _, e := range exp {
+		b = binary.AppendUvarint(b, uint64(e))
+	}
+	return string(b)
+}
+
+// Adds c*x^exp to the terms in m, copying exp if it is new.
+func addTerm(m map[string]*MTerm, c float64, exp []int) {
+	k := expKey(exp)
+	if t, ok := m[k]; ok {
+		t.Coeff += c
+		return
+	}
+	m[k] = &MTerm{c, append([]int(nil), exp...)}
+}
+
+// Returns a polynomial from a map of terms, dropping zero coefficients and
+// sorting the rest.
+func collectTerms(nvar int, m map[string]*MTerm) MPoly {
+	terms := make([]MTerm, 0, len(m))
+	for _, t := range m {
+		if t.Coeff != 0 {
+			terms = append(terms, *t)
+		}
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		return grlexLess(terms[j].Exp, terms[i].Exp)
+	})
+	return MPoly{nvar, terms}
+}
+
+// Reports whether exponent vector a precedes b in graded lexicographic
+// order, which compares total degree and then exponents from the first.
+func grlexLess(a, b []int) bool {
+	da, db := MTerm{Exp: a}.deg(), MTerm{Exp: b}.deg()
+	if da != db {
+		return da < db
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// Returns the number of variables.
+func (p MPoly) NumVars() int {
+	return p.nvar
+}
+
+// Returns a copy of the terms with nonzero coefficients, in decreasing
+// graded lexicographic order. The zero polynomial has no terms.
+func (p MPoly) Terms() []MTerm {
+	terms := make([]MTerm, len(p.terms))
+	for i, t := range p.terms {
+		terms[i] = MTerm{t.Coeff, append([]int(nil), t.Exp...)}
+	}
+	return terms
+}
+
+// Returns the total degree, the largest total degree of any term. The zero
+// polynomial has degree 0.
+func (p MPoly) Deg() int {
+	if len(p.terms) == 0 {
+		return 0
+	}
+	return p.terms[0].deg()
+}
+
+// Returns the coefficient of the term with the given exponents.
+func (p MPoly) Coeff(exp ...int) float64 {
+	if len(exp) != p.nvar {
+		return 0
+	}
+	k := expKey(exp)
+	for _, t := range p.terms {
+		if expKey(t.Exp) == k {
+			return t.Coeff
+		}
+	}
+	return 0
+}
+
+// Returns the common number of variables of p and q, panicking if they
+// differ.
+func (p MPoly) vars(q MPoly) int {
+	switch {
+	case p.nvar == q.nvar || q.nvar == 0:
+		return p.nvar
+	case p.nvar == 0:
+		return q.nvar
+	}
+	panic(fmt.Sprintf("poly: mismatched number of variables %d and %d", p.nvar, q.nvar))
+}
+
+// Returns the exponents of a term, padded with zeros to nvar variables.
+func padExp(exp []int, nvar int) []int {
+	if len(exp) == nvar {
+		return exp
+	}
+	return make([]int, nvar)
+}
+
+// Evaluates a polynomial at the point x, which must have one coordinate per
+// variable.
+func (p MPoly) Eval(x ...float64) float64 {
+	if len(x) != p.nvar {
+		panic(fmt.Sprintf("poly: evaluating polynomial in %d variables at %d coordinates", p.nvar, len(x)))
+	}
+	var v float64
+	for _, t := range p.terms {
+		m := t.Coeff
+		for i, e := range t.Exp {
+			if e != 0 {
+				m *= math.Pow(x[i], float64(e))
+			}
+		}
+		v += m
+	}
+	return v
+}
+
+// Adds a polynomial to another polynomial.
+// Returns p+q.
+func (p MPoly) Add(q MPoly) MPoly {
+	n := p.vars(q)
+	m := make(map[string]*MTerm, len(p.terms)+len(q.terms))
+	for _, t := range p.terms {
+		addTerm(m, t.Coeff, padExp(t.Exp, n))
+	}
+	for _, t := range q.terms {
+		addTerm(m, t.Coeff, padExp(t.Exp, n))
+	}
+	return collectTerms(n, m)
+}
+
+// Subtracts a polynomial from another polynomial.
+// Returns p-q.
+func (p MPoly) Sub(q MPoly) MPoly {
+	return p.Add(q.Neg())
+}
+
+// Multiplies a polynomial by a scalar.
+// Returns k*p.
+func (p MPoly) Scale(k float64) MPoly {
+	if k == 0 {
+		return MPoly{nvar: p.nvar}
+	}
+	terms := p.Terms()
+	for i := range terms {
+		terms[i].Coeff *= k
+	}
+	return MPoly{p.nvar, terms}
+}
+
+// Negates a polynomial.
+// Returns -p.
+func (p MPoly) Neg() MPoly {
+	return p.Scale(-1)
+}
+
+// Multiplies a polynomial by another polynomial.
+// Returns p*q.
+func (p MPoly) Mul(q MPoly) MPoly {
+	n := p.vars(q)
+	m := make(map[string]*MTerm)
+	exp := make([]int, n)
+	for _, s := range p.terms {
+		se := padExp(s.Exp, n)
+		for _, t := range q.terms {
+			te := padExp(t.Exp, n)
+			for i := range exp {
+				exp[i] = se[i] + te[i]
+			}
+			addTerm(m, s.Coeff*t.Coeff, exp)
+		}
+	}
+	return collectTerms(n, m)
+}
+
+// Raises a polynomial to a non-negative integer power by repeated squaring.
+// Returns p^n.
+func (p MPoly) Pow(n int) MPoly {
+	if n < 0 {
+		panic("poly: negative exponent")
+	}
+	r := MConst(p.nvar, 1)
+	for b := p; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			r = r.Mul(b)
+		}
+		if n > 1 {
+			b = b.Mul(b)
+		}
+	}
+	return r
+}
+
+// Computes the partial derivative with respect to variable x[i].
+// Panics unless 0 <= i < NumVars().
+func (p MPoly) Der(i int) MPoly {
+	if i < 0 || i >= p.nvar {
+		panic("poly: variable index out of range")
+	}
+	m := make(map[string]*MTerm, len(p.terms))
+	for _, t := range p.terms {
+		if t.Exp[i] == 0 {
+			continue
+		}
+		exp := append([]int(nil), t.Exp...)
+		exp[i]--
+		addTerm(m, t.Coeff*float64(t.Exp[i]), exp)
+	}
+	return collectTerms(p.nvar, m)
+}
+
+// Computes the gradient, the vector of partial derivatives with respect to
+// each variable.
+func (p MPoly) Gradient() []MPoly {
+	g := make([]MPoly, p.nvar)
+	for i := range g {
+		g[i] = p.Der(i)
+	}
+	return g
+}
+
+// Computes the Hessian, the symmetric matrix of second partial derivatives,
+// whose element [i][j] is the derivative with respect to x[i] and x[j].
+func (p MPoly) Hessian() [][]MPoly {
+	g := p.Gradient()
+	h := make([][]MPoly, p.nvar)
+	for i := range h {
+		h[i] = make([]MPoly, p.nvar)
+	}
+	for i := range h {
+		for j := i; j < p.nvar; j++ {
+			h[i][j] = g[i].Der(j)
+			h[j][i] = h[i][j]
+		}
+	}
+	return h
+}
+
+// Evaluates the gradient at the point x.
+func (p MPoly) GradientAt(x ...float64) []float64 {
+	g := p.Gradient()
+	v := make([]float64, len(g))
+	for i, gi := range g {
+		v[i] = gi.Eval(x...)
+	}
+	return v
+}
+
+// Evaluates the Hessian at the point x.
+func (p MPoly) HessianAt(x ...float64) [][]float64 {
+	h := p.Hessian()
+	v := make([][]float64, len(h))
+	for i, hi := range h {
+		v[i] = make([]float64, len(hi))
+		for j, hij := range hi {
+			v[i][j] = hij.Eval(x...)
+		}
+	}
+	return v
+}
+
+// Returns the default names of the variables: x, y and z for up to three
+// variables, and x1, x2, ... otherwise.
+func (p MPoly) varNames() []string {
+	if p.nvar <= 3 {
+		return []string{"x", "y", "z"}[:p.nvar]
+	}
+	names := make([]string, p.nvar)
+	for i := range names {
+		names[i] = fmt.Sprintf("x%d", i+1)
+	}
+	return names
+}
+
+// Returns a printable string representing the polynomial, with terms in
+// decreasing graded lexicographic order and coefficients written as for
+// Poly. The variables are named x, y and z, or x1, x2, ... for more than
+// three variables.
+func (p MPoly) String() string {
+	return p.Format(p.varNames()...)
+}
+
+// Returns a printable string representing the polynomial, using the given
+// names for the variables. Panics if the number of names differs from the
+// number of variables.
+func (p MPoly) Format(names ...string) string {
+	if len(names) != p.nvar {
+		panic(fmt.Sprintf("poly: %d names for %d variables", len(names), p.nvar))
+	}
+	if len(p.terms) == 0 {
+		return fmt.Sprintf("%.3f", 0.0)
+	}
+	var b strings.Builder
+	for i, t := range p.terms {
+		c := t.Coeff
+		if i > 0 {
+			if c < 0 {
+				b.WriteString(" - ")
+			} else {
+				b.WriteString(" + ")
+			}
+			c = math.Abs(c)
+		}
+		constant := t.deg() == 0
+		switch {
+		case math.Abs(c) != 1 || constant:
+			fmt.Fprintf(&b, "%.3f", c)
+		case c == -1:
+			b.WriteString("-")
+		}
+		for j, e := range t.Exp {
+			if e == 0 {
+				continue
+			}
+			b.WriteString(names[j])
+			if e != 1 {
+				fmt.Fprintf(&b, "^%d", e)
+			}
+		}
+	}
+	return b.String()
+}
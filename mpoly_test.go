@@ -0,0 +1,152 @@
+package poly
+
+import (
+	"math"
+	"testing"
+)
+
+// Tests construction and string representation of multivariate polynomials.
+func TestMPolyString(t *testing.T) {
+	x, y, z := MVar(3, 0), MVar(3, 1), MVar(3, 2)
+	cases := []struct {
+		p    MPoly
+		want string
+	}{
+		{MPoly{}, "0.000"},
+		{MConst(2, 1.5), "1.500"},
+		{NewMPoly(2, MTerm{3, []int{2, 0}}, MTerm{-1, []int{1, 1}}), "3.000x^2 - xy"},
+		{NewMPoly(2, MTerm{1, []int{1, 0}}, MTerm{2, []int{1, 0}}), "3.000x"},
+		{NewMPoly(2, MTerm{1, []int{1, 0}}, MTerm{-1, []int{1, 0}}), "0.000"},
+		{x.Add(y).Add(z).Add(MConst(3, -1)), "x + y + z - 1.000"},
+		{z.Mul(z).Sub(x.Mul(y)).Neg(), "xy - z^2"},
+		{MVar(4, 3).Add(MVar(4, 0)).Scale(2), "2.000x1 + 2.000x4"},
+		{New(1, -2, 1).Multi(2, 1), "y^2 - 2.000y + 1.000"},
+	}
+	for i, c := range cases {
+		if got := c.p.String(); got != c.want {
+			t.Errorf("case %d: String() == %q, want %q", i, got, c.want)
+		}
+	}
+	p := x.Mul(y).Sub(z.Pow(3))
+	if got, want := p.Format("a", "b", "c"), "-c^3 + ab"; got != want {
+		t.Errorf("Format() == %q, want %q", got, want)
+	}
+}
+
+// Tests arithmetic on multivariate polynomials.
+func TestMPolyArith(t *testing.T) {
+	x, y := MVar(2, 0), MVar(2, 1)
+	one := MConst(2, 1)
+	cases := []struct {
+		name      string
+		got, want MPoly
+	}{
+		// (x + y)(x - y) = x^2 - y^2.
+		{"Mul", x.Add(y).Mul(x.Sub(y)), x.Mul(x).Sub(y.Mul(y))},
+		{"Pow", x.Add(y).Pow(2), x.Pow(2).Add(x.Mul(y).Scale(2)).Add(y.Pow(2))},
+		{"Pow 0", x.Pow(0), one},
+		{"Sub self", x.Sub(x), MPoly{nvar: 2}},
+		{"zero value", MPoly{}.Add(x), x},
+		{"const mul", MConst(0, 3).Mul(y), y.Scale(3)},
+	}
+	for _, c := range cases {
+		if c.got.String() != c.want.String() || c.got.NumVars() != c.want.NumVars() {
+			t.Errorf("%s == %q, want %q", c.name, c.got, c.want)
+		}
+	}
+	p := x.Add(y).Pow(3)
+	if got, want := p.Coeff(2, 1), 3.0; got != want {
+		t.Errorf("Coeff(2, 1) of %q == %v, want %v", p, got, want)
+	}
+	if got := p.Deg(); got != 3 {
+		t.Errorf("Deg() of %q == %d, want 3", p, got)
+	}
+	if got := len(p.Terms()); got != 4 {
+		t.Errorf("len(Terms()) of %q == %d, want 4", p, got)
+	}
+}
+
+// Tests evaluation and partial derivatives of multivariate polynomials.
+func TestMPolyEvalDer(t *testing.T) {
+	x, y, z := MVar(3, 0), MVar(3, 1), MVar(3, 2)
+	// f = x^2 y + 3yz^3 - 2.
+	f := x.Pow(2).Mul(y).Add(y.Mul(z.Pow(3)).Scale(3)).Sub(MConst(3, 2))
+	if got, want := f.Eval(2, -1, 0.5), -6.375; math.Abs(got-want) > 0.00001 {
+		t.Errorf("Eval(2, -1, 0.5) == %v, want %v", got, want)
+	}
+	cases := []struct {
+		i    int
+		want MPoly
+	}{
+		{0, x.Mul(y).Scale(2)},
+		{1, x.Pow(2).Add(z.Pow(3).Scale(3))},
+		{2, y.Mul(z.Pow(2)).Scale(9)},
+	}
+	for _, c := range cases {
+		if got := f.Der(c.i); got.String() != c.want.String() {
+			t.Errorf("Der(%d) of %q == %q, want %q", c.i, f, got, c.want)
+		}
+	}
+	if got := MConst(3, 4).Der(1); got.String() != "0.000" {
+		t.Errorf("Der(1) of constant == %q, want 0", got)
+	}
+}
+
+// Tests that invalid arguments panic.
+func TestMPolyPanics(t *testing.T) {
+	cases := []func(){
+		func() { NewMPoly(2, MTerm{1, []int{1}}) },
+		func() { NewMPoly(1, MTerm{1, []int{-1}}) },
+		func() { MVar(2, 2) },
+		func() { MVar(2, 0).Add(MVar(3, 0)) },
+		func() { MVar(2, 0).Eval(1) },
+		func() { MVar(2, 0).Der(2) },
+	}
+	for i, f := range cases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("case %d did not panic", i)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// Tests gradients and Hessians of multivariate polynomials.
+func TestMPolyGradientHessian(t *testing.T) {
+	x, y := MVar(2, 0), MVar(2, 1)
+	// The Rosenbrock function (1 - x)^2 + 100(y - x^2)^2.
+	one := MConst(2, 1)
+	f := one.Sub(x).Pow(2).Add(y.Sub(x.Pow(2)).Pow(2).Scale(100))
+	g := f.Gradient()
+	wantG := []MPoly{
+		x.Pow(3).Scale(400).Sub(x.Mul(y).Scale(400)).Add(x.Scale(2)).Sub(MConst(2, 2)),
+		y.Scale(200).Sub(x.Pow(2).Scale(200)),
+	}
+	for i := range g {
+		if g[i].String() != wantG[i].String() {
+			t.Errorf("Gradient()[%d] == %q, want %q", i, g[i], wantG[i])
+		}
+	}
+	h := f.Hessian()
+	if got, want := h[0][1].String(), x.Scale(-400).String(); got != want || h[1][0].String() != want {
+		t.Errorf("Hessian()[0][1], [1][0] == %q, %q, want %q", got, h[1][0], want)
+	}
+	// The minimum at (1, 1) has zero gradient and a positive definite
+	// Hessian.
+	if got := f.GradientAt(1, 1); !compareVec(got, []float64{0, 0}) {
+		t.Errorf("GradientAt(1, 1) == %v, want [0 0]", got)
+	}
+	ha := f.HessianAt(1, 1)
+	want := [][]float64{{802, -400}, {-400, 200}}
+	for i := range want {
+		if !compareVec(ha[i], want[i]) {
+			t.Errorf("HessianAt(1, 1)[%d] == %v, want %v", i, ha[i], want[i])
+		}
+	}
+	if got := f.GradientAt(0, 0); !compareVec(got, []float64{-2, 0}) {
+		t.Errorf("GradientAt(0, 0) == %v, want [-2 0]", got)
+	}
+}
@@ -0,0 +1,368 @@
+package poly
+
+import (
+	"math"
+	"math/bits"
+	"math/cmplx"
+	"runtime"
+	"sync"
+)
+
+// Lengths of the shorter operand at or above which Mul switches from the
+// schoolbook algorithm to Karatsuba's algorithm, and from Karatsuba's
+// algorithm to multiplication by FFT. The crossovers were chosen with the
+// BenchmarkMul benchmarks.
+const (
+	karatsubaThreshold = 96
+	fftMulThreshold    = 768
+)
+
+// Multiplies the coefficient slices a and b, accumulating the product into c,
+// which must have length at least len(a)+len(b)-1. Chooses between the
+// schoolbook method, Karatsuba's algorithm and the FFT based on the length of
+// the shorter operand.
+func mulInto(c, a, b []float64) {
+	switch n := min(len(a), len(b)); {
+	case n >= fftMulThreshold && a[len(a)-1] != 0 && b[len(b)-1] != 0:
+		for i, x := range mulFFT(a, b, 1) {
+			c[i] += x
+		}
+	case n >= karatsubaThreshold:
+		mulKaratsubaBalanced(c, a, b)
+	default:
+		mulSchoolbook(c, a, b)
+	}
+}
+
+// Multiplies the coefficient slices a and b by Karatsuba's algorithm after
+// the balancing change of variable described for mulFFT, accumulating the
+// product into c, which must have length at least len(a)+len(b)-1.
+// Without it, the error of the middle product would be relative to the
+// largest coefficients, as for the FFT. If the scaling would overflow or
+// underflow, or if a or b has a zero last element, the product is computed by
+// the schoolbook method instead.
+func mulKaratsubaBalanced(c, a, b []float64) {
+	ka, kb, ls, ok := balance(a, b)
+	if !ok {
+		mulSchoolbook(c, a, b)
+		return
+	}
+	a, b = a[ka:], b[kb:]
+	bufs := [...]*[]float64{
+		getFloats(len(a)),
+		getFloats(len(b)),
+		getFloats(len(a) + len(b) - 1),
+	}
+	defer func() {
+		for _, buf := range bufs {
+			putFloats(buf)
+		}
+	}()
+	sa, sb, z := *bufs[0], *bufs[1], *bufs[2]
+	for i, ac := range a {
+		sa[i] = ac * math.Exp(float64(i)*ls)
+	}
+	for i, bc := range b {
+		sb[i] = bc * math.Exp(float64(i)*ls)
+	}
+	mulKaratsuba(z, sa, sb)
+	for i, x := range z {
+		c[ka+kb+i] += x * math.Exp(-float64(i)*ls)
+	}
+}
+
+// Multiplies the coefficient slices a and b by Karatsuba's algorithm,
+// accumulating the product into c, which must have length at least
+// len(a)+len(b)-1.
+// Splitting each operand into halves, a = a0 + x^h a1 and b = b0 + x^h b1,
+// the product is a0b0 + x^h ((a0+a1)(b0+b1) - a0b0 - a1b1) + x^2h a1b1, which
+// takes three half size products instead of four, for O(n^1.585) operations
+// in all. The subtraction loses some accuracy relative to the schoolbook
+// method when the halves differ greatly in magnitude. An operand much longer
+// than the other is split into pieces of the length of the shorter one.
+func mulKaratsuba(c, a, b []float64) {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	if len(b) < karatsubaThreshold {
+		mulSchoolbook(c, a, b)
+		return
+	}
+	h := (len(a) + 1) / 2
+	if len(b) <= h {
+		for i := 0; i < len(a); i += len(b) {
+			mulKaratsuba(c[i:], a[i:min(i+len(b), len(a))], b)
+		}
+		return
+	}
+	a0, a1, b0, b1 := a[:h], a[h:], b[:h], b[h:]
+	bufs := [...]*[]float64{
+		getFloats(2*h - 1),
+		getFloats(len(a1) + len(b1) - 1),
+		getFloats(2*h - 1),
+		getFloats(h),
+		getFloats(h),
+	}
+	defer func() {
+		for _, buf := range bufs {
+			putFloats(buf)
+		}
+	}()
+	z0, z2, z1, sa, sb := *bufs[0], *bufs[1], *bufs[2], *bufs[3], *bufs[4]
+	mulKaratsuba(z0, a0, b0)
+	mulKaratsuba(z2, a1, b1)
+	copy(sa, a0)
+	for i, x := range a1 {
+		sa[i] += x
+	}
+	copy(sb, b0)
+	for i, x := range b1 {
+		sb[i] += x
+	}
+	mulKaratsuba(z1, sa, sb)
+	for i, x := range z0 {
+		c[i] += x
+		z1[i] -= x
+	}
+	for i, x := range z2 {
+		c[2*h+i] += x
+		z1[i] -= x
+	}
+	for i, x := range z1 {
+		c[h+i] += x
+	}
+}
+
+// Largest magnitude of the natural logarithm of the scale factor applied to
+// any coefficient by the balancing change of variable, keeping the factors
+// well within the range of a float64.
+const fftMaxExp = 600
+
+// Multiplies the coefficient slices a and b by the schoolbook method,
+// accumulating the product into c, which must have length at least
+// len(a)+len(b)-1.
+func mulSchoolbook(c, a, b []float64) {
+	for i, ac := range a {
+		if ac == 0 {
+			continue
+		}
+		for j, bc := range b {
+			c[i+j] += ac * bc
+		}
+	}
+}
+
+// Multiplies the coefficient slices a and b, each with a nonzero last
+// element, using the fast Fourier transform in O(n log n) operations, with
+// the transforms split across up to the given number of goroutines.
+// Returns the coefficients of the product.
+// The error of an FFT convolution is relative to the largest coefficients,
+// so small coefficients would be swamped. To avoid this, powers of x that
+// divide a or b are split off, and the variable is scaled so that the
+// constant and leading coefficients of the product have equal magnitude.
+// This balances the coefficients when those of both operands vary roughly
+// geometrically at similar rates, as for example those of p and p^k do.
+// Each operand is then scaled to a largest coefficient of 1, so that neither
+// is swamped by the other when they share a transform. If the scaling would
+// overflow or underflow, the product is computed by the schoolbook method
+// instead.
+func mulFFT(a, b []float64, workers int) []float64 {
+	c := make([]float64, len(a)+len(b)-1)
+	ka, kb, ls, ok := balance(a, b)
+	if !ok {
+		mulSchoolbook(c, a, b)
+		return c
+	}
+	a, b = a[ka:], b[kb:]
+	n, m := len(a)-1, len(b)-1
+	var maxA, maxB float64
+	for i, ac := range a {
+		maxA = math.Max(maxA, math.Abs(ac*math.Exp(float64(i)*ls)))
+	}
+	for i, bc := range b {
+		maxB = math.Max(maxB, math.Abs(bc*math.Exp(float64(i)*ls)))
+	}
+	la, lb := math.Log(maxA), math.Log(maxB)
+	if math.IsInf(la, 0) || math.IsInf(lb, 0) || math.IsInf(maxA*maxB*float64(n+m+1), 0) {
+		mulSchoolbook(c[ka+kb:], a, b)
+		return c
+	}
+	size := 1 << bits.Len(uint(n+m))
+	// Pack a into the real parts and b into the imaginary parts, so that a
+	// single transform yields both.
+	zbuf := getComplex(size)
+	defer putComplex(zbuf)
+	z := *zbuf
+	for i, ac := range a {
+		z[i] = complex(ac*math.Exp(float64(i)*ls-la), 0)
+	}
+	for i, bc := range b {
+		z[i] += complex(0, bc*math.Exp(float64(i)*ls-lb))
+	}
+	fft(z, false, workers)
+	// With z = a + ib, the transforms are A_k = (Z_k + conj(Z_{N-k}))/2
+	// and B_k = (Z_k - conj(Z_{N-k}))/2i, and their product is
+	// (Z_k^2 - conj(Z_{N-k})^2)/4i.
+	wbuf := getComplex(size)
+	defer putComplex(wbuf)
+	w := *wbuf
+	for k := range z {
+		zk, zn := z[k], cmplx.Conj(z[(size-k)%size])
+		w[k] = (zk*zk - zn*zn) / complex(0, 4)
+	}
+	fft(w, true, workers)
+	for i := 0; i <= n+m; i++ {
+		c[ka+kb+i] = real(w[i]) / float64(size) * math.Exp(la+lb-float64(i)*ls)
+	}
+	return c
+}
+
+// Computes the balancing change of variable for the product of the
+// coefficient slices a and b. Returns the powers ka and kb of x that divide a
+// and b, and ls such that scaling the variable by s = exp(ls), multiplying
+// coefficient i of a[ka:] and b[kb:] by s^i, gives the constant and leading
+// coefficients of their product equal magnitude. Reports false if the scaling
+// would overflow or underflow, or if a or b has a zero last element.
+func balance(a, b []float64) (ka, kb int, ls float64, ok bool) {
+	if a[len(a)-1] == 0 || b[len(b)-1] == 0 {
+		return 0, 0, 0, false
+	}
+	for a[ka] == 0 {
+		ka++
+	}
+	for b[kb] == 0 {
+		kb++
+	}
+	n, m := len(a)-1-ka, len(b)-1-kb
+	if n+m > 0 {
+		ls = (math.Log(math.Abs(a[ka])) + math.Log(math.Abs(b[kb])) -
+			math.Log(math.Abs(a[ka+n])) - math.Log(math.Abs(b[kb+m]))) / float64(n+m)
+	}
+	if !(math.Abs(ls)*float64(n+m) <= fftMaxExp) {
+		return 0, 0, 0, false
+	}
+	return ka, kb, ls, true
+}
+
+// Computes the discrete Fourier transform of x in place, where the length of
+// x is a power of two, using the iterative radix-2 Cooley-Tukey algorithm.
+// If inverse is true, computes the inverse transform without the 1/N factor.
+// The twiddle factors are each computed directly, rather than by repeated
+// multiplication, so that their errors do not accumulate. The butterflies of
+// each stage are independent, and are split across up to the given number of
+// goroutines.
+func fft(x []complex128, inverse bool, workers int) {
+	n := len(x)
+	if n <= 1 {
+		return
+	}
+	shift := 64 - bits.Len(uint(n-1))
+	for i := range x {
+		if j := int(bits.Reverse64(uint64(i)) >> shift); i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	sign := -1.0
+	if inverse {
+		sign = 1
+	}
+	twbuf := getComplex(n / 2)
+	defer putComplex(twbuf)
+	tw := *twbuf
+	parallelFor(n/2, workers, func(lo, hi int) {
+		for k := lo; k < hi; k++ {
+			s, c := math.Sincos(sign * 2 * math.Pi * float64(k) / float64(n))
+			tw[k] = complex(c, s)
+		}
+	})
+	for size := 2; size <= n; size <<= 1 {
+		half, step := size/2, n/size
+		// Butterfly t pairs elements start+k and start+k+half, where
+		// start is t/half*size and k is t%half.
+		parallelFor(n/2, workers, func(lo, hi int) {
+			start, k := lo/half*size, lo%half
+			for t := lo; t < hi; t++ {
+				u := tw[k*step] * x[start+k+half]
+				x[start+k+half] = x[start+k] - u
+				x[start+k] += u
+				if k++; k == half {
+					start, k = start+size, 0
+				}
+			}
+		})
+	}
+}
+
+// Minimum number of loop iterations given to each goroutine by parallelFor.
+const parallelGrain = 1 << 12
+
+// Calls f on consecutive subranges [lo, hi) covering [0, n), running up to
+// the given number of them concurrently and returning once all are done.
+// Ranges of fewer than parallelGrain iterations are not split.
+func parallelFor(n, workers int, f func(lo, hi int)) {
+	workers = min(workers, n/parallelGrain)
+	if workers <= 1 {
+		f(0, n)
+		return
+	}
+	chunk := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	for lo := 0; lo < n; lo += chunk {
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			f(lo, hi)
+		}(lo, min(lo+chunk, n))
+	}
+	wg.Wait()
+}
+
+// Amount of work, in terms of p times terms of q, below which MulParallel
+// multiplies on the calling goroutine.
+const parallelMulWork = 1 << 20
+
+// Multiplies a polynomial by another polynomial, splitting the work across
+// up to the given number of goroutines, or GOMAXPROCS if workers is not
+// positive.
+// Returns p*q, computed as by Mul. When both polynomials have many terms the
+// stages of the FFT are each split across the goroutines. Otherwise the
+// longer polynomial is split into blocks, each multiplied by the shorter one
+// on its own goroutine, and the partial products are added. Small products
+// are computed on the calling goroutine.
+func (p Poly) MulParallel(q Poly, workers int) Poly {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	a, b := p.co(), q.co()
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	if workers == 1 || len(a)*len(b) < parallelMulWork {
+		return p.Mul(q)
+	}
+	if len(b) >= fftMulThreshold {
+		return normalized(mulFFT(a, b, workers))
+	}
+	c := make([]float64, len(a)+len(b)-1)
+	chunk := (len(a) + workers - 1) / workers
+	partial := make([][]float64, 0, workers)
+	for lo := 0; lo < len(a); lo += chunk {
+		partial = append(partial, make([]float64, min(chunk, len(a)-lo)+len(b)-1))
+	}
+	var wg sync.WaitGroup
+	for i, d := range partial {
+		wg.Add(1)
+		go func(i int, d []float64) {
+			defer wg.Done()
+			lo := i * chunk
+			mulInto(d, a[lo:min(lo+chunk, len(a))], b)
+		}(i, d)
+	}
+	wg.Wait()
+	for i, d := range partial {
+		for j, x := range d {
+			c[i*chunk+j] += x
+		}
+	}
+	return normalized(c)
+}
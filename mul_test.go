@@ -0,0 +1,292 @@
+package poly
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Returns a polynomial of degree n with random coefficients in [-1, 1).
+func randomPoly(rnd *rand.Rand, n int) Poly {
+	c := make([]float64, n+1)
+	for i := range c {
+		c[i] = 2*rnd.Float64() - 1
+	}
+	c[n] = 1
+	return New(c...)
+}
+
+// Returns the schoolbook product of two polynomials.
+func mulSlow(p, q Poly) Poly {
+	c := make([]float64, len(p.co())+len(q.co())-1)
+	mulSchoolbook(c, p.co(), q.co())
+	return normalized(c)
+}
+
+// Returns the product of two polynomials computed by FFT.
+func mulFFTPoly(p, q Poly) Poly {
+	return normalized(mulFFT(p.co(), q.co(), 1))
+}
+
+// Tests that multiplication by FFT agrees with the schoolbook method.
+func TestMulFFT(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	cases := []struct{ n, m int }{
+		{1, 1},
+		{fftMulThreshold, fftMulThreshold},
+		{100, 1000},
+		{1000, 100},
+		{1023, 1025},
+		{4000, 3000},
+	}
+	for i, c := range cases {
+		p, q := randomPoly(rnd, c.n), randomPoly(rnd, c.m)
+		got, want := normalized(mulFFT(p.co(), q.co(), 1)), mulSlow(p, q)
+		if got.Deg() != want.Deg() {
+			t.Errorf("case %d: mulFFT() degree == %d, want %d", i, got.Deg(), want.Deg())
+			continue
+		}
+		for j := 0; j <= want.Deg(); j++ {
+			if math.Abs(got.Coeff(j)-want.Coeff(j)) > 1e-10*float64(c.n+c.m) {
+				t.Errorf("case %d: mulFFT() coefficient %d == %v, want %v", i, j, got.Coeff(j), want.Coeff(j))
+				break
+			}
+		}
+	}
+}
+
+// Tests that Karatsuba's algorithm agrees with the schoolbook method for
+// balanced and unbalanced operands.
+func TestMulKaratsuba(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	cases := []struct{ n, m int }{
+		{1, 1},
+		{karatsubaThreshold, karatsubaThreshold},
+		{karatsubaThreshold + 1, karatsubaThreshold},
+		{3 * karatsubaThreshold, 2 * karatsubaThreshold},
+		{4*karatsubaThreshold + 1, 3 * karatsubaThreshold},
+		{10 * karatsubaThreshold, karatsubaThreshold + 7},
+		{karatsubaThreshold + 7, 10 * karatsubaThreshold},
+		{1025, 1024},
+	}
+	for i, c := range cases {
+		p, q := randomPoly(rnd, c.n), randomPoly(rnd, c.m)
+		want := mulSlow(p, q)
+		got := make([]float64, c.n+c.m+1)
+		mulKaratsuba(got, p.co(), q.co())
+		for j, w := range want.co() {
+			if math.Abs(got[j]-w) > 1e-12*float64(c.n+c.m) {
+				t.Errorf("case %d: mulKaratsuba() coefficient %d == %v, want %v", i, j, got[j], w)
+				break
+			}
+		}
+	}
+}
+
+// Tests that Mul keeps small coefficients accurate when Karatsuba's
+// algorithm is used for operands whose coefficients vary geometrically.
+func TestMulKaratsubaScaling(t *testing.T) {
+	cases := []struct {
+		n, ka int
+		r     float64
+	}{
+		{300, 0, 0.9},
+		{300, 0, 1.1},
+		{karatsubaThreshold, 0, 0.5},
+		{400, 5, 0.8},
+	}
+	for i, c := range cases {
+		a := make([]float64, c.ka+c.n+1)
+		for j := c.ka; j < len(a); j++ {
+			a[j] = math.Pow(c.r, float64(j-c.ka))
+		}
+		p := New(a...)
+		got, want := p.Mul(p), mulSlow(p, p)
+		for j := 0; j <= want.Deg(); j++ {
+			if g, w := got.Coeff(j), want.Coeff(j); math.Abs(g-w) > 1e-12*math.Abs(w) {
+				t.Errorf("case %d: Mul() coefficient %d == %v, want %v", i, j, g, w)
+				break
+			}
+		}
+	}
+}
+
+// Tests that Mul agrees with the schoolbook method on either side of the
+// crossovers between algorithms.
+func TestMulLarge(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	for _, n := range []int{karatsubaThreshold - 1, karatsubaThreshold, fftMulThreshold - 1, fftMulThreshold} {
+		p, q := randomPoly(rnd, n), randomPoly(rnd, n+5)
+		got, want := p.Mul(q), mulSlow(p, q)
+		if got.Deg() != want.Deg() || got.Sub(want).maxAbs() > 1e-10 {
+			t.Errorf("Mul() of degrees %d and %d differs from schoolbook by %g", n, n+5, got.Sub(want).maxAbs())
+		}
+	}
+}
+
+// Tests that multiplication by FFT keeps small coefficients accurate when
+// the coefficients of both operands vary geometrically over many orders of
+// magnitude, and handles factors of x.
+func TestMulFFTScaling(t *testing.T) {
+	cases := []struct {
+		ka, kb int
+		ra, rb float64
+	}{
+		{0, 0, 0.5, 0.5},
+		{0, 0, 0.25, 0.25},
+		{3, 0, 2, 2},
+		{10, 7, 0.1, 0.1},
+	}
+	for i, c := range cases {
+		a := make([]float64, c.ka+200)
+		b := make([]float64, c.kb+150)
+		for j := c.ka; j < len(a); j++ {
+			a[j] = math.Pow(c.ra, float64(j-c.ka)) * float64(1+j%3)
+		}
+		for j := c.kb; j < len(b); j++ {
+			b[j] = math.Pow(c.rb, float64(j-c.kb)) * float64(2-j%2)
+		}
+		p, q := New(a...), New(b...)
+		got, want := mulFFTPoly(p, q), mulSlow(p, q)
+		for j := 0; j <= want.Deg(); j++ {
+			if g, w := got.Coeff(j), want.Coeff(j); math.Abs(g-w) > 1e-9*math.Abs(w) {
+				t.Errorf("case %d: mulFFT() coefficient %d == %v, want %v", i, j, g, w)
+				break
+			}
+		}
+	}
+}
+
+// Tests that Mul keeps the product accurate when the operands differ greatly
+// in magnitude, so that neither is swamped by the other in a shared
+// transform.
+func TestMulFFTMagnitudes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(3))
+	p, q := randomPoly(rnd, 999), randomPoly(rnd, 999)
+	for _, s := range []float64{1, 1e-16, 1e-24, 1e100, 1e-200} {
+		qs := q.Scale(s)
+		got, want := p.Mul(qs), mulSlow(p, qs)
+		if err := got.Sub(want).maxAbs(); err > 1e-10*want.maxAbs() {
+			t.Errorf("Mul() with operands scaled by %g differs from schoolbook by %g, largest coefficient %g", s, err, want.maxAbs())
+		}
+		got, want = qs.Mul(p), mulSlow(qs, p)
+		if err := got.Sub(want).maxAbs(); err > 1e-10*want.maxAbs() {
+			t.Errorf("Mul() with operands scaled by %g differs from schoolbook by %g, largest coefficient %g", s, err, want.maxAbs())
+		}
+	}
+}
+
+// Tests that mulFFT falls back to the schoolbook method if scaling
+// overflows.
+func TestMulFFTOverflow(t *testing.T) {
+	a := make([]float64, 100)
+	b := make([]float64, 100)
+	a[0], a[99] = 1e300, 1e-300
+	b[0], b[99] = 1, 1
+	p, q := New(a...), New(b...)
+	if got, want := mulFFTPoly(p, q), mulSlow(p, q); !equalCoeffs(got, want) {
+		t.Errorf("mulFFT(%q, %q) == %q, want %q", p, q, got, want)
+	}
+}
+
+// Tests the FFT against the definition of the discrete Fourier transform.
+func TestFFT(t *testing.T) {
+	for _, n := range []int{1, 2, 8, 64} {
+		x := make([]complex128, n)
+		for i := range x {
+			x[i] = complex(float64(i%5)-2, float64(i%3))
+		}
+		got := append([]complex128(nil), x...)
+		fft(got, false, 1)
+		for k := range got {
+			var want complex128
+			for j, xj := range x {
+				s, c := math.Sincos(-2 * math.Pi * float64(j*k) / float64(n))
+				want += xj * complex(c, s)
+			}
+			if d := got[k] - want; math.Hypot(real(d), imag(d)) > 1e-9 {
+				t.Errorf("fft() of length %d at %d == %v, want %v", n, k, got[k], want)
+			}
+		}
+		fft(got, true, 1)
+		for k := range got {
+			if d := got[k]/complex(float64(n), 0) - x[k]; math.Hypot(real(d), imag(d)) > 1e-12 {
+				t.Errorf("inverse fft() of length %d at %d == %v, want %v", n, k, got[k], x[k])
+			}
+		}
+	}
+}
+
+// Tests that parallel multiplication agrees with Mul.
+func TestMulParallel(t *testing.T) {
+	rnd := rand.New(rand.NewSource(3))
+	cases := []struct{ n, m, workers int }{
+		{10, 10, 4},
+		{20000, 30000, 4},
+		{100000, 50, 3},
+		{50, 100000, 0},
+		{5000, 300, 1},
+	}
+	for i, c := range cases {
+		p, q := randomPoly(rnd, c.n), randomPoly(rnd, c.m)
+		got, want := p.MulParallel(q, c.workers), p.Mul(q)
+		if got.Deg() != want.Deg() || got.Sub(want).maxAbs() > 1e-8 {
+			t.Errorf("case %d: MulParallel() of degrees %d and %d with %d workers differs from Mul by %g", i, c.n, c.m, c.workers, got.Sub(want).maxAbs())
+		}
+	}
+}
+
+// Tests that the parallel FFT agrees with the serial one.
+func TestFFTParallel(t *testing.T) {
+	n := 1 << 15
+	x := make([]complex128, n)
+	for i := range x {
+		x[i] = complex(float64(i%7)-3, float64(i%11)-5)
+	}
+	want := append([]complex128(nil), x...)
+	fft(want, false, 1)
+	fft(x, false, 4)
+	for k := range x {
+		if x[k] != want[k] {
+			t.Errorf("parallel fft() at %d == %v, want %v", k, x[k], want[k])
+			break
+		}
+	}
+}
+
+func benchmarkMul(b *testing.B, n int, mul func(c, a, b []float64)) {
+	rnd := rand.New(rand.NewSource(1))
+	p, q := randomPoly(rnd, n), randomPoly(rnd, n)
+	c := make([]float64, 2*n+1)
+	for i := 0; i < b.N; i++ {
+		mul(c, p.co(), q.co())
+	}
+}
+
+func mulFFTInto(c, a, b []float64) { copy(c, mulFFT(a, b, 1)) }
+
+func BenchmarkMulSchoolbook16(b *testing.B)  { benchmarkMul(b, 16, mulSchoolbook) }
+func BenchmarkMulSchoolbook32(b *testing.B)  { benchmarkMul(b, 32, mulSchoolbook) }
+func BenchmarkMulSchoolbook64(b *testing.B)  { benchmarkMul(b, 64, mulSchoolbook) }
+func BenchmarkMulSchoolbook128(b *testing.B) { benchmarkMul(b, 128, mulSchoolbook) }
+func BenchmarkMulKaratsuba16(b *testing.B)   { benchmarkMul(b, 16, mulKaratsuba) }
+func BenchmarkMulKaratsuba32(b *testing.B)   { benchmarkMul(b, 32, mulKaratsuba) }
+func BenchmarkMulKaratsuba64(b *testing.B)   { benchmarkMul(b, 64, mulKaratsuba) }
+func BenchmarkMulKaratsuba128(b *testing.B)  { benchmarkMul(b, 128, mulKaratsuba) }
+func BenchmarkMulKaratsuba256(b *testing.B)  { benchmarkMul(b, 256, mulKaratsuba) }
+func BenchmarkMulKaratsuba512(b *testing.B)  { benchmarkMul(b, 512, mulKaratsuba) }
+func BenchmarkMulKaratsuba1024(b *testing.B) { benchmarkMul(b, 1024, mulKaratsuba) }
+func BenchmarkMulFFT256(b *testing.B)        { benchmarkMul(b, 256, mulFFTInto) }
+func BenchmarkMulFFT512(b *testing.B)        { benchmarkMul(b, 512, mulFFTInto) }
+func BenchmarkMulFFT1024(b *testing.B)       { benchmarkMul(b, 1024, mulFFTInto) }
+func BenchmarkMulKaratsuba2048(b *testing.B) { benchmarkMul(b, 2048, mulKaratsuba) }
+func BenchmarkMulFFT2048(b *testing.B)       { benchmarkMul(b, 2048, mulFFTInto) }
+func BenchmarkMulFFT100000(b *testing.B)     { benchmarkMul(b, 100000, mulFFTInto) }
+
+func BenchmarkMulParallel100000(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	p, q := randomPoly(rnd, 100000), randomPoly(rnd, 100000)
+	for i := 0; i < b.N; i++ {
+		p.MulParallel(q, 0)
+	}
+}
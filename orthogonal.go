@@ -0,0 +1,139 @@
+package poly
+
+// Creates the Chebyshev polynomial of the first kind T_n, defined by
+// T_n(cos t) = cos(n t).
+// It is computed by the recurrence T_(k+1) = 2x T_k - T_(k-1), in which every
+// coefficient is an integer, so the result is exact while the coefficients
+// are below 2^53, which holds through degree 50.
+// Panics if n is negative.
+func ChebyshevT(n int) Poly {
+	return threeTerm(n, New(0, 1), func(k int) (a, b, c float64) {
+		return 2, 0, 1
+	})
+}
+
+// Creates the Chebyshev polynomial of the second kind U_n, defined by
+// U_n(cos t) sin t = sin((n+1) t).
+// It is computed by the recurrence U_(k+1) = 2x U_k - U_(k-1) with U_1 = 2x,
+// and is exact under the same conditions as ChebyshevT.
+// Panics if n is negative.
+func ChebyshevU(n int) Poly {
+	return threeTerm(n, New(0, 2), func(k int) (a, b, c float64) {
+		return 2, 0, 1
+	})
+}
+
+// Creates the Legendre polynomial P_n, the polynomials orthogonal on [-1, 1]
+// with unit weight and normalized so that P_n(1) = 1.
+// It is computed by the recurrence
+// (k+1) P_(k+1) = (2k+1) x P_k - k P_(k-1).
+// Panics if n is negative.
+func Legendre(n int) Poly {
+	return threeTerm(n, New(0, 1), func(k int) (a, b, c float64) {
+		return float64(2*k+1) / float64(k+1), 0, float64(k) / float64(k+1)
+	})
+}
+
+// Creates the physicists' Hermite polynomial H_n, orthogonal on the real
+// line with weight exp(-x^2).
+// It is computed by the recurrence H_(k+1) = 2x H_k - 2k H_(k-1), in which
+// every coefficient is an integer.
+// Panics if n is negative.
+func HermiteH(n int) Poly {
+	return threeTerm(n, New(0, 2), func(k int) (a, b, c float64) {
+		return 2, 0, float64(2 * k)
+	})
+}
+
+// Creates the probabilists' Hermite polynomial He_n, orthogonal on the real
+// line with weight exp(-x^2/2), so that He_n(x) = 2^(-n/2) H_n(x/sqrt(2)).
+// It is computed by the recurrence He_(k+1) = x He_k - k He_(k-1), in which
+// every coefficient is an integer.
+// Panics if n is negative.
+func HermiteHe(n int) Poly {
+	return threeTerm(n, New(0, 1), func(k int) (a, b, c float64) {
+		return 1, 0, float64(k)
+	})
+}
+
+// Creates the Laguerre polynomial L_n, orthogonal on [0, inf) with weight
+// exp(-x) and normalized so that L_n(0) = 1.
+// It is computed by the recurrence
+// (k+1) L_(k+1) = (2k+1-x) L_k - k L_(k-1).
+// Panics if n is negative.
+func Laguerre(n int) Poly {
+	return threeTerm(n, New(1, -1), func(k int) (a, b, c float64) {
+		k1 := float64(k + 1)
+		return -1 / k1, float64(2*k+1) / k1, float64(k) / k1
+	})
+}
+
+// Creates the Jacobi polynomial P_n^(alpha,beta), orthogonal on [-1, 1] with
+// weight (1-x)^alpha (1+x)^beta and normalized so that P_n(1) is the binomial
+// coefficient C(n+alpha, n).
+// Legendre polynomials are the case alpha = beta = 0, and Gegenbauer
+// polynomials are multiples of the case alpha = beta.
+// Panics if n is negative or if alpha or beta is not greater than -1.
+func Jacobi(n int, alpha, beta float64) Poly {
+	if !(alpha > -1 && beta > -1) {
+		panic("poly: Jacobi parameters must exceed -1")
+	}
+	ab := alpha + beta
+	p1 := New((alpha-beta)/2, (ab+2)/2)
+	return threeTerm(n, p1, func(k int) (a, b, c float64) {
+		m := float64(k + 1)
+		d := 2 * m * (m + ab) * (2*m + ab - 2)
+		a = (2*m + ab - 1) * (2*m + ab) * (2*m + ab - 2) / d
+		b = (2*m + ab - 1) * (alpha*alpha - beta*beta) / d
+		c = 2 * (m + alpha - 1) * (m + beta - 1) * (2*m + ab) / d
+		return a, b, c
+	})
+}
+
+// Creates the Gegenbauer (ultraspherical) polynomial C_n^(lambda), orthogonal
+// on [-1, 1] with weight (1-x^2)^(lambda-1/2).
+// It is computed by the recurrence
+// (k+1) C_(k+1) = 2(k+lambda) x C_k - (k+2lambda-1) C_(k-1), with
+// C_1 = 2 lambda x. Legendre polynomials are the case lambda = 1/2 and
+// Chebyshev polynomials of the second kind the case lambda = 1. For
+// lambda = 0 every polynomial of positive degree is zero.
+// Panics if n is negative or if lambda is not greater than -1/2.
+func Gegenbauer(n int, lambda float64) Poly {
+	if !(lambda > -0.5) {
+		panic("poly: Gegenbauer parameter must exceed -1/2")
+	}
+	return threeTerm(n, New(0, 2*lambda), func(k int) (a, b, c float64) {
+		k1 := float64(k + 1)
+		return 2 * (float64(k) + lambda) / k1, 0, (float64(k) + 2*lambda - 1) / k1
+	})
+}
+
+// Computes P_n from the three-term recurrence
+// P_(k+1) = (a_k x + b_k) P_k - c_k P_(k-1), with P_0 = 1 and the given P_1,
+// where coef returns a_k, b_k and c_k for k >= 1. Panics if n is negative.
+func threeTerm(n int, p1 Poly, coef func(k int) (a, b, c float64)) Poly {
+	if n < 0 {
+		panic("poly: negative degree")
+	}
+	if n == 0 {
+		return New(1)
+	}
+	prev := make([]float64, n+1)
+	cur := make([]float64, n+1)
+	prev[0] = 1
+	copy(cur, p1.co())
+	for k := 1; k < n; k++ {
+		a, b, c := coef(k)
+		// Overwrite prev with P_(k+1), which has degree k+1.
+		for i := k + 1; i >= 0; i-- {
+			v := -c * prev[i]
+			if i > 0 {
+				v += a * cur[i-1]
+			}
+			v += b * cur[i]
+			prev[i] = v
+		}
+		prev, cur = cur, prev
+	}
+	return normalized(cur)
+}
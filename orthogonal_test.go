@@ -0,0 +1,261 @@
+package poly
+
+import (
+	"math"
+	"testing"
+)
+
+// Tests that Chebyshev polynomials of the first kind are generated correctly.
+func TestChebyshevT(t *testing.T) {
+	cases := []struct {
+		n    int
+		want Poly
+	}{
+		{0, New(1)},
+		{1, New(0, 1)},
+		{2, New(-1, 0, 2)},
+		{3, New(0, -3, 0, 4)},
+		{4, New(1, 0, -8, 0, 8)},
+		{5, New(0, 5, 0, -20, 0, 16)},
+		{6, New(-1, 0, 18, 0, -48, 0, 32)},
+	}
+	for i, c := range cases {
+		if got := ChebyshevT(c.n); !equalCoeffs(got, c.want) {
+			t.Errorf("case %d: ChebyshevT(%d) == %q, want %q", i, c.n, got, c.want)
+		}
+	}
+}
+
+// Tests that Chebyshev polynomials of the second kind are generated
+// correctly.
+func TestChebyshevU(t *testing.T) {
+	cases := []struct {
+		n    int
+		want Poly
+	}{
+		{0, New(1)},
+		{1, New(0, 2)},
+		{2, New(-1, 0, 4)},
+		{3, New(0, -4, 0, 8)},
+		{4, New(1, 0, -12, 0, 16)},
+		{5, New(0, 6, 0, -32, 0, 32)},
+	}
+	for i, c := range cases {
+		if got := ChebyshevU(c.n); !equalCoeffs(got, c.want) {
+			t.Errorf("case %d: ChebyshevU(%d) == %q, want %q", i, c.n, got, c.want)
+		}
+	}
+}
+
+// Tests the trigonometric definitions of the Chebyshev polynomials.
+func TestChebyshevTrig(t *testing.T) {
+	for n := 0; n <= 20; n++ {
+		tn, un := ChebyshevT(n), ChebyshevU(n)
+		for _, th := range []float64{0.1, 0.7, 1.3, 2.9} {
+			x := math.Cos(th)
+			if got, want := tn.Eval(x), math.Cos(float64(n)*th); math.Abs(got-want) > 1e-6 {
+				t.Errorf("ChebyshevT(%d).Eval(%f) == %f, want %f", n, x, got, want)
+			}
+			if got, want := un.Eval(x), math.Sin(float64(n+1)*th)/math.Sin(th); math.Abs(got-want) > 1e-6 {
+				t.Errorf("ChebyshevU(%d).Eval(%f) == %f, want %f", n, x, got, want)
+			}
+		}
+	}
+}
+
+// Tests that Legendre polynomials are generated correctly.
+func TestLegendre(t *testing.T) {
+	cases := []struct {
+		n    int
+		want Poly
+	}{
+		{0, New(1)},
+		{1, New(0, 1)},
+		{2, New(-0.5, 0, 1.5)},
+		{3, New(0, -1.5, 0, 2.5)},
+		{4, New(3.0/8, 0, -30.0/8, 0, 35.0/8)},
+		{5, New(0, 15.0/8, 0, -70.0/8, 0, 63.0/8)},
+	}
+	for i, c := range cases {
+		if got := Legendre(c.n); !comparePoly(got, c.want) {
+			t.Errorf("case %d: Legendre(%d) == %q, want %q", i, c.n, got, c.want)
+		}
+	}
+}
+
+// Tests that Legendre polynomials are orthogonal on [-1, 1].
+func TestLegendreOrthogonal(t *testing.T) {
+	for m := 0; m <= 8; m++ {
+		for n := 0; n <= 8; n++ {
+			q := Legendre(m).Mul(Legendre(n)).Int(0)
+			got := q.Eval(1) - q.Eval(-1)
+			want := 0.0
+			if m == n {
+				want = 2 / float64(2*n+1)
+			}
+			if math.Abs(got-want) > 0.00001 {
+				t.Errorf("integral of Legendre(%d)*Legendre(%d) == %f, want %f", m, n, got, want)
+			}
+		}
+	}
+}
+
+// Tests that Hermite polynomials are generated correctly.
+func TestHermite(t *testing.T) {
+	cases := []struct {
+		n     int
+		h, he Poly
+	}{
+		{0, New(1), New(1)},
+		{1, New(0, 2), New(0, 1)},
+		{2, New(-2, 0, 4), New(-1, 0, 1)},
+		{3, New(0, -12, 0, 8), New(0, -3, 0, 1)},
+		{4, New(12, 0, -48, 0, 16), New(3, 0, -6, 0, 1)},
+		{5, New(0, 120, 0, -160, 0, 32), New(0, 15, 0, -10, 0, 1)},
+	}
+	for i, c := range cases {
+		if got := HermiteH(c.n); !equalCoeffs(got, c.h) {
+			t.Errorf("case %d: HermiteH(%d) == %q, want %q", i, c.n, got, c.h)
+		}
+		if got := HermiteHe(c.n); !equalCoeffs(got, c.he) {
+			t.Errorf("case %d: HermiteHe(%d) == %q, want %q", i, c.n, got, c.he)
+		}
+	}
+}
+
+// Tests the scaling relation between the two kinds of Hermite polynomials.
+func TestHermiteScaling(t *testing.T) {
+	for n := 0; n <= 12; n++ {
+		got := HermiteH(n).ScaleVar(1 / math.Sqrt2).Scale(math.Pow(2, -float64(n)/2))
+		if want := HermiteHe(n); !comparePoly(got, want) {
+			t.Errorf("scaled HermiteH(%d) == %q, want %q", n, got, want)
+		}
+	}
+}
+
+// Tests that Laguerre polynomials are generated correctly.
+func TestLaguerre(t *testing.T) {
+	cases := []struct {
+		n    int
+		want Poly
+	}{
+		{0, New(1)},
+		{1, New(1, -1)},
+		{2, New(1, -2, 0.5)},
+		{3, New(6, -18, 9, -1).Scale(1.0 / 6)},
+		{4, New(24, -96, 72, -16, 1).Scale(1.0 / 24)},
+	}
+	for i, c := range cases {
+		if got := Laguerre(c.n); !comparePoly(got, c.want) {
+			t.Errorf("case %d: Laguerre(%d) == %q, want %q", i, c.n, got, c.want)
+		}
+	}
+}
+
+// Tests that Jacobi polynomials are generated correctly.
+func TestJacobi(t *testing.T) {
+	cases := []struct {
+		n           int
+		alpha, beta float64
+		want        Poly
+	}{
+		{0, 1, 2, New(1)},
+		{1, 0, 0, New(0, 1)},
+		{1, 1, 1, New(0, 2)},
+		{1, 2, 0, New(1, 2)},
+		{2, 1, 1, New(-0.75, 0, 3.75)},
+		{3, 0, 0, Legendre(3)},
+		{5, 0, 0, Legendre(5)},
+	}
+	for i, c := range cases {
+		if got := Jacobi(c.n, c.alpha, c.beta); !comparePoly(got, c.want) {
+			t.Errorf("case %d: Jacobi(%d, %.3f, %.3f) == %q, want %q", i, c.n, c.alpha, c.beta, got, c.want)
+		}
+	}
+}
+
+// Tests the normalization and orthogonality of Jacobi polynomials.
+func TestJacobiOrthogonal(t *testing.T) {
+	params := []struct{ alpha, beta int }{{0, 0}, {1, 0}, {0, 2}, {2, 3}}
+	for _, ab := range params {
+		w := New(1, -1).Pow(ab.alpha).Mul(New(1, 1).Pow(ab.beta))
+		alpha, beta := float64(ab.alpha), float64(ab.beta)
+		for n := 0; n <= 6; n++ {
+			pn := Jacobi(n, alpha, beta)
+			want := math.Gamma(float64(n)+alpha+1) / (math.Gamma(float64(n)+1) * math.Gamma(alpha+1))
+			if got := pn.Eval(1); math.Abs(got-want) > 0.00001*want {
+				t.Errorf("Jacobi(%d, %.3f, %.3f).Eval(1) == %f, want %f", n, alpha, beta, got, want)
+			}
+			for m := 0; m < n; m++ {
+				q := pn.Mul(Jacobi(m, alpha, beta)).Mul(w).Int(0)
+				if got := q.Eval(1) - q.Eval(-1); math.Abs(got) > 0.00001 {
+					t.Errorf("integral of Jacobi(%d)*Jacobi(%d) with (%.3f, %.3f) == %f, want 0", n, m, alpha, beta, got)
+				}
+			}
+		}
+	}
+}
+
+// Tests that Gegenbauer polynomials are generated correctly.
+func TestGegenbauer(t *testing.T) {
+	for n := 0; n <= 8; n++ {
+		if got, want := Gegenbauer(n, 0.5), Legendre(n); !comparePoly(got, want) {
+			t.Errorf("Gegenbauer(%d, 0.5) == %q, want %q", n, got, want)
+		}
+		if got, want := Gegenbauer(n, 1), ChebyshevU(n); !comparePoly(got, want) {
+			t.Errorf("Gegenbauer(%d, 1) == %q, want %q", n, got, want)
+		}
+		// C_n^(lambda) is a multiple of P_n^(lambda-1/2, lambda-1/2).
+		g, j := Gegenbauer(n, 2.5), Jacobi(n, 2, 2)
+		if got, want := g, j.Scale(g.Coeff(n)/j.Coeff(n)); !comparePoly(got, want) {
+			t.Errorf("Gegenbauer(%d, 2.5) == %q, want %q", n, got, want)
+		}
+	}
+	if got, want := Gegenbauer(2, 2), New(-2, 0, 12); !comparePoly(got, want) {
+		t.Errorf("Gegenbauer(2, 2) == %q, want %q", got, want)
+	}
+}
+
+// Tests that the parametrized generators panic on invalid parameters.
+func TestJacobiGegenbauerPanic(t *testing.T) {
+	cases := []func(){
+		func() { Jacobi(-1, 0, 0) },
+		func() { Jacobi(2, -1, 0) },
+		func() { Jacobi(2, 0, -1.5) },
+		func() { Jacobi(2, math.NaN(), 0) },
+		func() { Gegenbauer(-1, 1) },
+		func() { Gegenbauer(2, -0.5) },
+	}
+	for i, f := range cases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("case %d: did not panic", i)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// Tests that the generators panic on negative degrees.
+func TestOrthogonalPanic(t *testing.T) {
+	gens := map[string]func(int) Poly{
+		"ChebyshevT": ChebyshevT,
+		"ChebyshevU": ChebyshevU,
+		"Legendre":   Legendre,
+		"HermiteH":   HermiteH,
+		"HermiteHe":  HermiteHe,
+		"Laguerre":   Laguerre,
+	}
+	for name, gen := range gens {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s(-1) did not panic", name)
+				}
+			}()
+			gen(-1)
+		}()
+	}
+}
@@ -0,0 +1,200 @@
+package poly
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Parses a polynomial from its string representation.
+// The accepted syntax is a sum of terms in the variable x, such as
+// "3x^2 - 2x + 1" or "-1.5e-3*x^4 + x". Spaces are optional, the first term
+// may have a leading sign, and terms may appear in any order. Terms with the
+// same exponent are summed. The output of String is always accepted.
+// Exponents larger than MaxParseDegree are rejected, so that a short string
+// cannot allocate an arbitrarily large polynomial.
+func Parse(s string) (Poly, error) {
+	ps := parser{s: s}
+	m, err := ps.parse()
+	if err != nil {
+		return Poly{}, err
+	}
+	return FromMap(m), nil
+}
+
+// The largest exponent accepted by Parse.
+const MaxParseDegree = 1 << 20
+
+// Parses a polynomial like Parse, but panics if the string cannot be parsed.
+// It simplifies the initialization of package level variables.
+func MustParse(s string) Poly {
+	p, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Flag wraps a Poly to implement flag.Value, so that polynomials can be
+// accepted as command line flags.
+// Example:
+//
+//	var filter poly.Flag
+//	flag.Var(&filter, "filter", "filter polynomial")
+//
+//	Running with -filter="x^2+0.5x" sets filter.Poly to x^2 + 0.5x.
+type Flag struct {
+	Poly
+}
+
+// Sets the flag by parsing s as a polynomial.
+func (f *Flag) Set(s string) error {
+	p, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	f.Poly = p
+	return nil
+}
+
+// Holds the state of a polynomial being parsed.
+type parser struct {
+	s   string
+	pos int
+}
+
+// Returns an error describing a problem at the current position.
+func (ps *parser) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("poly: parsing %q: offset %d: %s", ps.s, ps.pos, fmt.Sprintf(format, a...))
+}
+
+func (ps *parser) skipSpace() {
+	for ps.pos < len(ps.s) && (ps.s[ps.pos] == ' ' || ps.s[ps.pos] == '\t') {
+		ps.pos++
+	}
+}
+
+// Consumes the byte b if it is next, reporting whether it was.
+func (ps *parser) accept(b byte) bool {
+	if ps.pos < len(ps.s) && ps.s[ps.pos] == b {
+		ps.pos++
+		return true
+	}
+	return false
+}
+
+func (ps *parser) parse() (map[int]float64, error) {
+	m := map[int]float64{}
+	ps.skipSpace()
+	if ps.pos == len(ps.s) {
+		return nil, ps.errorf("empty polynomial")
+	}
+	for first := true; ; first = false {
+		ps.skipSpace()
+		if ps.pos == len(ps.s) {
+			return m, nil
+		}
+		sign := 1.0
+		switch {
+		case ps.accept('+'):
+		case ps.accept('-'):
+			sign = -1
+		default:
+			if !first {
+				return nil, ps.errorf("expected + or -, found %q", ps.s[ps.pos])
+			}
+		}
+		ps.skipSpace()
+		c, e, err := ps.term()
+		if err != nil {
+			return nil, err
+		}
+		m[e] += sign * c
+	}
+}
+
+// Parses a single unsigned term, returning its coefficient and exponent.
+func (ps *parser) term() (float64, int, error) {
+	c := 1.0
+	hasCoeff := false
+	if n := ps.number(); n != "" {
+		v, err := strconv.ParseFloat(n, 64)
+		if err != nil {
+			return 0, 0, ps.errorf("invalid coefficient %q", n)
+		}
+		c = v
+		hasCoeff = true
+		ps.skipSpace()
+		if ps.accept('*') {
+			ps.skipSpace()
+			if ps.pos == len(ps.s) || ps.s[ps.pos] != 'x' {
+				return 0, 0, ps.errorf("expected x after *")
+			}
+		}
+	}
+	if !ps.accept('x') {
+		if !hasCoeff {
+			if ps.pos == len(ps.s) {
+				return 0, 0, ps.errorf("expected term")
+			}
+			return 0, 0, ps.errorf("unexpected %q", ps.s[ps.pos])
+		}
+		return c, 0, nil
+	}
+	ps.skipSpace()
+	if !ps.accept('^') {
+		return c, 1, nil
+	}
+	ps.skipSpace()
+	start := ps.pos
+	for ps.pos < len(ps.s) && isDigit(ps.s[ps.pos]) {
+		ps.pos++
+	}
+	if start == ps.pos {
+		return 0, 0, ps.errorf("expected exponent")
+	}
+	e, err := strconv.Atoi(ps.s[start:ps.pos])
+	if err != nil || e > MaxParseDegree {
+		return 0, 0, ps.errorf("exponent %s too large", ps.s[start:ps.pos])
+	}
+	return c, e, nil
+}
+
+// Scans an unsigned floating point literal, returning it or "" if there is
+// none at the current position.
+func (ps *parser) number() string {
+	start := ps.pos
+	digits := 0
+	for ps.pos < len(ps.s) && isDigit(ps.s[ps.pos]) {
+		ps.pos++
+		digits++
+	}
+	if ps.accept('.') {
+		for ps.pos < len(ps.s) && isDigit(ps.s[ps.pos]) {
+			ps.pos++
+			digits++
+		}
+	}
+	if digits == 0 {
+		ps.pos = start
+		return ""
+	}
+	if ps.pos < len(ps.s) && (ps.s[ps.pos] == 'e' || ps.s[ps.pos] == 'E') {
+		mark := ps.pos
+		ps.pos++
+		if !ps.accept('+') {
+			ps.accept('-')
+		}
+		expStart := ps.pos
+		for ps.pos < len(ps.s) && isDigit(ps.s[ps.pos]) {
+			ps.pos++
+		}
+		if expStart == ps.pos {
+			ps.pos = mark
+		}
+	}
+	return ps.s[start:ps.pos]
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
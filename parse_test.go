@@ -0,0 +1,126 @@
+package poly
+
+import (
+	"flag"
+	"testing"
+)
+
+// Tests that polynomials are parsed from strings.
+func TestParse(t *testing.T) {
+	cases := []struct {
+		s    string
+		want Poly
+	}{
+		{"0", Poly{}},
+		{"1.234", New(1.234)},
+		{"-1.234", New(-1.234)},
+		{"x", New(0, 1)},
+		{"-x", New(0, -1)},
+		{"+x", New(0, 1)},
+		{"2x", New(0, 2)},
+		{"2*x", New(0, 2)},
+		{"2 * x ^ 3", New(0, 0, 0, 2)},
+		{"3x^2 - 2x + 1", New(1, -2, 3)},
+		{"3x^2-2x+1", New(1, -2, 3)},
+		{"  1 + x + x^2  ", New(1, 1, 1)},
+		{"1.5e-3x^2", New(0, 0, 0.0015)},
+		{"1.5E+2 + .5x", New(150, 0.5)},
+		{"2. - x^0", New(1)},
+		{"x + x + x^2 - x^2", New(0, 2)},
+		{"4.000x^4 + 2.000x^2 - x - 3.000", New(-3, -1, 2, 0, 4)},
+	}
+	for i, c := range cases {
+		got, err := Parse(c.s)
+		if err != nil {
+			t.Errorf("case %d: Parse(%q) returned error %v", i, c.s, err)
+			continue
+		}
+		if !comparePoly(got, c.want) {
+			t.Errorf("case %d: Parse(%q) == %q, want %q", i, c.s, got, c.want)
+		}
+	}
+}
+
+// Tests that malformed strings are rejected.
+func TestParseError(t *testing.T) {
+	cases := []string{
+		"",
+		"   ",
+		"+",
+		"x -",
+		"3x^",
+		"3x^-2",
+		"3 x 2",
+		"2*",
+		"2*3",
+		"y",
+		"x^2 x",
+		"1..5",
+		"--x",
+		"x^9223372036854775807",
+		"x^99999999999999999999",
+		"x^99999999999999",
+		"x^1000000000",
+		"0x^1048577",
+	}
+	for i, s := range cases {
+		if got, err := Parse(s); err == nil {
+			t.Errorf("case %d: Parse(%q) == %q, want error", i, s, got)
+		}
+	}
+}
+
+// Tests that the string representation parses back to the same polynomial.
+func TestParseString(t *testing.T) {
+	cases := []Poly{
+		Poly{},
+		New(-1.5),
+		New(0, -1, -1),
+		New(-3, -1, 2, 0, 4),
+		New(0.125, 0, -2, 7),
+	}
+	for i, p := range cases {
+		got, err := Parse(p.String())
+		if err != nil {
+			t.Errorf("case %d: Parse(%q) returned error %v", i, p.String(), err)
+			continue
+		}
+		if !comparePoly(got, p) {
+			t.Errorf("case %d: Parse(%q) == %q, want %q", i, p.String(), got, p)
+		}
+	}
+}
+
+// Tests that MustParse returns parsed polynomials and panics on errors.
+func TestMustParse(t *testing.T) {
+	if got, want := MustParse("x^2 + 0.5x"), New(0, 0.5, 1); !comparePoly(got, want) {
+		t.Errorf("MustParse() == %q, want %q", got, want)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustParse() on invalid input did not panic")
+		}
+	}()
+	MustParse("x^")
+}
+
+// Tests that Flag can be used with the flag package.
+func TestFlag(t *testing.T) {
+	var f Flag
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&f, "filter", "filter polynomial")
+	if err := fs.Parse([]string{"-filter=x^2+0.5x"}); err != nil {
+		t.Fatalf("Parse() returned error %v", err)
+	}
+	if want := New(0, 0.5, 1); !comparePoly(f.Poly, want) {
+		t.Errorf("flag value == %q, want %q", f.Poly, want)
+	}
+	if got, want := f.String(), "x^2 + 0.500x"; got != want {
+		t.Errorf("String() == %q, want %q", got, want)
+	}
+	for _, s := range []string{"x^", "x^99999999999999"} {
+		if err := f.Set(s); err == nil {
+			t.Errorf("Set(%q) succeeded, want error", s)
+		}
+	}
+}
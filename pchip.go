@@ -0,0 +1,64 @@
+package poly
+
+import (
+	"fmt"
+	"math"
+)
+
+// Creates the monotone piecewise cubic Hermite interpolant through the points
+// (xs[i], ys[i]), using the method of Fritsch and Carlson.
+// The slopes at the data points start as the average of the adjacent
+// secants, are set to zero where the data has a local extremum or is flat,
+// and are then reduced where needed so that each piece is monotone between
+// its end points. The result is continuous with a continuous first
+// derivative, and never overshoots the data: on each interval it lies
+// between the values at the ends. With two points it is the line through
+// them. Each piece is in terms of the local variable x - xs[k], as for
+// NewPiecewiseLocal, so data far from the origin loses no accuracy.
+// Returns an error if xs and ys differ in length, if there are fewer than two
+// points, or if the xs are not strictly increasing.
+func PCHIP(xs, ys []float64) (Piecewise, error) {
+	if len(xs) != len(ys) {
+		return Piecewise{}, fmt.Errorf("poly: %d x values but %d y values", len(xs), len(ys))
+	}
+	if len(xs) < 2 {
+		return Piecewise{}, fmt.Errorf("poly: need at least two points")
+	}
+	for i := 1; i < len(xs); i++ {
+		if !(xs[i] > xs[i-1]) {
+			return Piecewise{}, fmt.Errorf("poly: x values not strictly increasing at index %d", i)
+		}
+	}
+	n := len(xs) - 1
+	h := make([]float64, n)
+	delta := make([]float64, n)
+	for k := range h {
+		h[k] = xs[k+1] - xs[k]
+		delta[k] = (ys[k+1] - ys[k]) / h[k]
+	}
+	d := make([]float64, n+1)
+	d[0], d[n] = delta[0], delta[n-1]
+	for k := 1; k < n; k++ {
+		if delta[k-1]*delta[k] > 0 {
+			d[k] = (delta[k-1] + delta[k]) / 2
+		}
+	}
+	for k, dk := range delta {
+		if dk == 0 {
+			d[k], d[k+1] = 0, 0
+			continue
+		}
+		a, b := d[k]/dk, d[k+1]/dk
+		if s := a*a + b*b; s > 9 {
+			tau := 3 / math.Sqrt(s)
+			d[k], d[k+1] = tau*a*dk, tau*b*dk
+		}
+	}
+	pieces := make([]Poly, n)
+	for k := range pieces {
+		c2 := (3*delta[k] - 2*d[k] - d[k+1]) / h[k]
+		c3 := (d[k] + d[k+1] - 2*delta[k]) / (h[k] * h[k])
+		pieces[k] = New(ys[k], d[k], c2, c3)
+	}
+	return NewPiecewiseLocal(xs, pieces)
+}
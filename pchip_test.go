@@ -0,0 +1,121 @@
+package poly
+
+import (
+	"math"
+	"testing"
+)
+
+// Tests that PCHIP interpolates without overshooting the data.
+func TestPCHIP(t *testing.T) {
+	cases := []struct {
+		xs, ys []float64
+	}{
+		{[]float64{0, 1}, []float64{1, 3}},
+		{[]float64{0, 1, 2, 3, 4}, []float64{0, 0, 0, 1, 1}},
+		{[]float64{0, 0.1, 0.5, 2, 10}, []float64{0, 0.01, 0.4, 0.95, 1}},
+		{[]float64{-2, -1, 0, 1, 2, 3}, []float64{3, 1, 2, 2, -1, 0}},
+		{[]float64{1, 2, 3, 4}, []float64{1, 100, 101, 1000}},
+	}
+	for i, c := range cases {
+		pw, err := PCHIP(c.xs, c.ys)
+		if err != nil {
+			t.Errorf("case %d: PCHIP(%v, %v) returned error %v", i, c.xs, c.ys, err)
+			continue
+		}
+		for k, x := range c.xs {
+			if got := pw.Eval(x); math.Abs(got-c.ys[k]) > 0.00001 {
+				t.Errorf("case %d: PCHIP(%v, %v).Eval(%f) == %f, want %f", i, c.xs, c.ys, x, got, c.ys[k])
+			}
+		}
+		for k := 0; k+1 < len(c.xs); k++ {
+			lo, hi := math.Min(c.ys[k], c.ys[k+1]), math.Max(c.ys[k], c.ys[k+1])
+			prev := pw.Eval(c.xs[k])
+			for j := 1; j <= 100; j++ {
+				x := c.xs[k] + (c.xs[k+1]-c.xs[k])*float64(j)/100
+				y := pw.Eval(x)
+				if y < lo-1e-9 || y > hi+1e-9 {
+					t.Errorf("case %d: PCHIP(%v, %v).Eval(%f) == %f, outside [%f, %f]", i, c.xs, c.ys, x, y, lo, hi)
+					break
+				}
+				if (c.ys[k+1] >= c.ys[k] && y < prev-1e-9) || (c.ys[k+1] <= c.ys[k] && y > prev+1e-9) {
+					t.Errorf("case %d: PCHIP(%v, %v) not monotone at %f", i, c.xs, c.ys, x)
+					break
+				}
+				prev = y
+			}
+			if k > 0 {
+				x := c.xs[k]
+				l, r := pw.Piece(k-1).Der().Eval(x), pw.Piece(k).Der().Eval(x)
+				if math.Abs(l-r) > 0.00001 {
+					t.Errorf("case %d: PCHIP(%v, %v) derivative jumps at %f: %f != %f", i, c.xs, c.ys, x, l, r)
+				}
+			}
+		}
+	}
+}
+
+// Tests that PCHIP reproduces linear data.
+func TestPCHIPLinear(t *testing.T) {
+	xs := []float64{-1, 0, 0.5, 3}
+	pw, err := PCHIP(xs, New(2, -3).EvalAll(xs))
+	if err != nil {
+		t.Fatalf("PCHIP() returned error %v", err)
+	}
+	for i := 0; i < pw.Len(); i++ {
+		if got, want := pw.Piece(i), New(2, -3); !comparePoly(got, want) {
+			t.Errorf("PCHIP().Piece(%d) == %q, want %q", i, got, want)
+		}
+	}
+}
+
+// Tests that PCHIP is as accurate for data far from the origin as for the
+// same data shifted to it.
+func TestPCHIPOffset(t *testing.T) {
+	xs := []float64{0, 0.5, 1.25, 2, 3}
+	ys := []float64{1, 2, 2.5, 2.25, 4}
+	ref, err := PCHIP(xs, ys)
+	if err != nil {
+		t.Fatalf("PCHIP() returned error %v", err)
+	}
+	for _, x0 := range []float64{1e5, 1e6, -1e6} {
+		shifted := make([]float64, len(xs))
+		for i, x := range xs {
+			shifted[i] = x + x0
+		}
+		pw, err := PCHIP(shifted, ys)
+		if err != nil {
+			t.Fatalf("PCHIP() returned error %v", err)
+		}
+		for j := 0; j <= 30; j++ {
+			x := float64(j) / 10
+			if got, want := pw.Eval(x+x0), ref.Eval(x); math.Abs(got-want) > 1e-9 {
+				t.Errorf("PCHIP() offset by %g: Eval(%f) == %.12f, want %.12f", x0, x+x0, got, want)
+			}
+		}
+		for i := 0; i < pw.Len(); i++ {
+			got, off := pw.LocalPiece(i)
+			want, _ := ref.LocalPiece(i)
+			if off != shifted[i] || !comparePoly(got, want) {
+				t.Errorf("PCHIP() offset by %g: LocalPiece(%d) == %q, %g, want %q, %g", x0, i, got, off, want, shifted[i])
+			}
+		}
+	}
+}
+
+// Tests that invalid data is rejected.
+func TestPCHIPError(t *testing.T) {
+	cases := []struct {
+		xs, ys []float64
+	}{
+		{nil, nil},
+		{[]float64{1}, []float64{1}},
+		{[]float64{1, 2}, []float64{1}},
+		{[]float64{1, 1}, []float64{1, 2}},
+		{[]float64{2, 1}, []float64{1, 2}},
+	}
+	for i, c := range cases {
+		if got, err := PCHIP(c.xs, c.ys); err == nil {
+			t.Errorf("case %d: PCHIP(%v, %v) == %q, want error", i, c.xs, c.ys, got)
+		}
+	}
+}
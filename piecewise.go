@@ -0,0 +1,213 @@
+package poly
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Piecewise is a function of one variable given by a different polynomial on
+// each of a sequence of intervals.
+// Piece i applies on [breaks[i], breaks[i+1]), except that the last piece
+// also includes its right end. The first and last pieces are extended below
+// and above the breakpoints, and infinite breakpoints may be used to cover
+// the whole real line. The zero value has no pieces and evaluates to zero.
+// Pieces may be given in terms of x, or, with NewPiecewiseLocal, in terms of
+// the local variable x - breaks[i], which keeps their coefficients well
+// conditioned far from the origin.
+// Example:
+//
+//	// The rectified linear unit max(0, x).
+//	relu, _ := poly.NewPiecewise(
+//		[]float64{math.Inf(-1), 0, math.Inf(1)},
+//		[]poly.Poly{poly.Poly{}, poly.New(0, 1)})
+type Piecewise struct {
+	breaks []float64
+	pieces []Poly
+	// Piece i is in terms of x - offsets[i], or of x if offsets is nil.
+	offsets []float64
+}
+
+// Creates a piecewise polynomial from its breakpoints and pieces.
+// Returns an error unless there is one more breakpoint than pieces and the
+// breakpoints are strictly increasing.
+func NewPiecewise(breaks []float64, pieces []Poly) (Piecewise, error) {
+	if len(breaks) != len(pieces)+1 {
+		return Piecewise{}, fmt.Errorf("poly: %d breakpoints for %d pieces", len(breaks), len(pieces))
+	}
+	for i := 1; i < len(breaks); i++ {
+		if !(breaks[i] > breaks[i-1]) {
+			return Piecewise{}, fmt.Errorf("poly: breakpoints not strictly increasing at index %d", i)
+		}
+	}
+	return Piecewise{
+		breaks: append([]float64(nil), breaks...),
+		pieces: append([]Poly(nil), pieces...),
+	}, nil
+}
+
+// Creates a piecewise polynomial from its breakpoints and pieces, where piece
+// i is given in terms of the local variable x - breaks[i].
+// Returns an error unless there is one more breakpoint than pieces, the
+// breakpoints are strictly increasing, and all but the last are finite.
+func NewPiecewiseLocal(breaks []float64, pieces []Poly) (Piecewise, error) {
+	pw, err := NewPiecewise(breaks, pieces)
+	if err != nil {
+		return Piecewise{}, err
+	}
+	for i := range pieces {
+		if math.IsInf(breaks[i], 0) {
+			return Piecewise{}, fmt.Errorf("poly: infinite breakpoint at index %d", i)
+		}
+	}
+	pw.offsets = pw.breaks[:len(pieces):len(pieces)]
+	return pw, nil
+}
+
+// Returns the number of pieces.
+func (pw Piecewise) Len() int {
+	return len(pw.pieces)
+}
+
+// Returns the breakpoints, which must not be modified.
+func (pw Piecewise) Breaks() []float64 {
+	return pw.breaks
+}
+
+// Returns piece i in terms of x.
+func (pw Piecewise) Piece(i int) Poly {
+	return pw.pieces[i].Shift(-pw.offset(i))
+}
+
+// Returns piece i in terms of the local variable x - offset.
+func (pw Piecewise) LocalPiece(i int) (p Poly, offset float64) {
+	return pw.pieces[i], pw.offset(i)
+}
+
+// Returns the offset of the variable of piece i.
+func (pw Piecewise) offset(i int) float64 {
+	if pw.offsets == nil {
+		return 0
+	}
+	return pw.offsets[i]
+}
+
+// Returns the index of the piece that applies at x, which must not be
+// called with no pieces.
+func (pw Piecewise) find(x float64) int {
+	i := sort.Search(len(pw.breaks), func(i int) bool { return pw.breaks[i] > x }) - 1
+	if i < 0 {
+		return 0
+	}
+	if i >= len(pw.pieces) {
+		return len(pw.pieces) - 1
+	}
+	return i
+}
+
+// Evaluates the piecewise polynomial at x.
+func (pw Piecewise) Eval(x float64) float64 {
+	if len(pw.pieces) == 0 {
+		return 0
+	}
+	i := pw.find(x)
+	return pw.pieces[i].Eval(x - pw.offset(i))
+}
+
+// Adds a piecewise polynomial to another piecewise polynomial.
+// The breakpoints of the result are the union of the breakpoints of both,
+// with each operand extended beyond its own breakpoints as by Eval. If
+// either operand has local pieces, so does the result, each in terms of a
+// finite end of its interval.
+func (pw Piecewise) Add(q Piecewise) Piecewise {
+	if len(pw.pieces) == 0 {
+		return q.Scale(1)
+	}
+	if len(q.pieces) == 0 {
+		return pw.Scale(1)
+	}
+	breaks := mergeBreaks(pw.breaks, q.breaks)
+	r := Piecewise{breaks: breaks, pieces: make([]Poly, len(breaks)-1)}
+	if pw.offsets != nil || q.offsets != nil {
+		r.offsets = make([]float64, len(r.pieces))
+		for i := range r.offsets {
+			switch {
+			case !math.IsInf(breaks[i], 0):
+				r.offsets[i] = breaks[i]
+			case !math.IsInf(breaks[i+1], 0):
+				r.offsets[i] = breaks[i+1]
+			}
+		}
+	}
+	for i := range r.pieces {
+		x := representative(breaks[i], breaks[i+1])
+		j, k := pw.find(x), q.find(x)
+		o := r.offset(i)
+		r.pieces[i] = pw.pieces[j].Shift(o - pw.offset(j)).Add(q.pieces[k].Shift(o - q.offset(k)))
+	}
+	return r
+}
+
+// Multiplies a piecewise polynomial by a scalar.
+func (pw Piecewise) Scale(k float64) Piecewise {
+	r := Piecewise{
+		breaks:  append([]float64(nil), pw.breaks...),
+		pieces:  make([]Poly, len(pw.pieces)),
+		offsets: append([]float64(nil), pw.offsets...),
+	}
+	for i, p := range pw.pieces {
+		r.pieces[i] = p.Scale(k)
+	}
+	return r
+}
+
+// Returns the string representation of the piecewise polynomial, listing
+// each interval and its piece in terms of x.
+func (pw Piecewise) String() string {
+	var b strings.Builder
+	for i := range pw.pieces {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		fmt.Fprintf(&b, "[%g, %g]: %v", pw.breaks[i], pw.breaks[i+1], pw.Piece(i))
+	}
+	return b.String()
+}
+
+// Merges two ascending lists of breakpoints, dropping duplicates.
+func mergeBreaks(a, b []float64) []float64 {
+	m := make([]float64, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		var x float64
+		switch {
+		case j == len(b) || (i < len(a) && a[i] < b[j]):
+			x = a[i]
+			i++
+		case i == len(a) || b[j] < a[i]:
+			x = b[j]
+			j++
+		default:
+			x = a[i]
+			i++
+			j++
+		}
+		m = append(m, x)
+	}
+	return m
+}
+
+// Returns a point strictly inside the interval (lo, hi), either endpoint of
+// which may be infinite.
+func representative(lo, hi float64) float64 {
+	switch {
+	case math.IsInf(lo, -1) && math.IsInf(hi, 1):
+		return 0
+	case math.IsInf(lo, -1):
+		return hi - math.Max(1, math.Abs(hi))
+	case math.IsInf(hi, 1):
+		return lo + math.Max(1, math.Abs(lo))
+	}
+	return lo + (hi-lo)/2
+}
@@ -0,0 +1,131 @@
+package poly
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// Tests that invalid breakpoints are rejected.
+func TestNewPiecewiseError(t *testing.T) {
+	cases := []struct {
+		breaks []float64
+		pieces []Poly
+	}{
+		{nil, nil},
+		{[]float64{0, 1}, nil},
+		{[]float64{0, 1}, []Poly{New(1), New(2)}},
+		{[]float64{0, 0}, []Poly{New(1)}},
+		{[]float64{0, 2, 1}, []Poly{New(1), New(2)}},
+		{[]float64{0, math.NaN()}, []Poly{New(1)}},
+	}
+	for i, c := range cases {
+		if _, err := NewPiecewise(c.breaks, c.pieces); err == nil {
+			t.Errorf("case %d: NewPiecewise(%v, %v) succeeded, want error", i, c.breaks, c.pieces)
+		}
+	}
+}
+
+// Tests that piecewise polynomials are evaluated correctly.
+func TestPiecewiseEval(t *testing.T) {
+	inf := math.Inf(1)
+	relu, _ := NewPiecewise([]float64{-inf, 0, inf}, []Poly{Poly{}, New(0, 1)})
+	hat, _ := NewPiecewise([]float64{-1, 0, 1}, []Poly{New(1, 1), New(1, -1)})
+	cases := []struct {
+		pw   Piecewise
+		x    float64
+		want float64
+	}{
+		{Piecewise{}, 3, 0},
+		{relu, -2, 0},
+		{relu, 0, 0},
+		{relu, 2.5, 2.5},
+		{hat, -0.5, 0.5},
+		{hat, 0, 1},
+		{hat, 0.25, 0.75},
+		{hat, 1, 0},
+		// Outside the breakpoints the end pieces are extended.
+		{hat, -3, -2},
+		{hat, 2, -1},
+	}
+	for i, c := range cases {
+		if got := c.pw.Eval(c.x); math.Abs(got-c.want) > 0.00001 {
+			t.Errorf("case %d: Eval(%f) on %q == %f, want %f", i, c.x, c.pw, got, c.want)
+		}
+	}
+}
+
+// Tests that piecewise polynomials are added and scaled correctly.
+func TestPiecewiseAddScale(t *testing.T) {
+	inf := math.Inf(1)
+	relu, _ := NewPiecewise([]float64{-inf, 0, inf}, []Poly{Poly{}, New(0, 1)})
+	hat, _ := NewPiecewise([]float64{-1, 0, 1}, []Poly{New(1, 1), New(1, -1)})
+	sum := relu.Add(hat.Scale(2))
+	if got, want := sum.Breaks(), []float64{-inf, -1, 0, 1, inf}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Add() breaks == %v, want %v", got, want)
+	}
+	want := []Poly{New(2, 2), New(2, 2), New(2, -1), New(2, -1)}
+	if sum.Len() != len(want) {
+		t.Fatalf("Add().Len() == %d, want %d", sum.Len(), len(want))
+	}
+	for i, w := range want {
+		if got := sum.Piece(i); !comparePoly(got, w) {
+			t.Errorf("Add().Piece(%d) == %q, want %q", i, got, w)
+		}
+	}
+	for _, x := range []float64{-5, -1, -0.5, 0, 0.5, 1, 3} {
+		if got, want := sum.Eval(x), relu.Eval(x)+2*hat.Eval(x); math.Abs(got-want) > 0.00001 {
+			t.Errorf("Add().Eval(%f) == %f, want %f", x, got, want)
+		}
+	}
+	if got := (Piecewise{}).Add(hat); got.String() != hat.String() {
+		t.Errorf("Add() to zero value == %q, want %q", got, hat)
+	}
+}
+
+// Tests piecewise polynomials with pieces in terms of local variables.
+func TestPiecewiseLocal(t *testing.T) {
+	inf := math.Inf(1)
+	if _, err := NewPiecewiseLocal([]float64{-inf, 0, 1}, []Poly{New(1), New(2)}); err == nil {
+		t.Errorf("NewPiecewiseLocal() with infinite offset succeeded, want error")
+	}
+	if _, err := NewPiecewiseLocal([]float64{0, 1}, nil); err == nil {
+		t.Errorf("NewPiecewiseLocal() with no pieces succeeded, want error")
+	}
+	// The hat function, with pieces 1 + (x+1) and 1 - x.
+	hat, err := NewPiecewiseLocal([]float64{-1, 0, 1, inf}, []Poly{New(0, 1), New(1, -1), Poly{}})
+	if err != nil {
+		t.Fatalf("NewPiecewiseLocal() returned error %v", err)
+	}
+	if got, want := hat.Piece(0), New(1, 1); !comparePoly(got, want) {
+		t.Errorf("Piece(0) == %q, want %q", got, want)
+	}
+	if got, off := hat.LocalPiece(0); !comparePoly(got, New(0, 1)) || off != -1 {
+		t.Errorf("LocalPiece(0) == %q, %g, want %q, -1", got, off, New(0, 1))
+	}
+	relu, _ := NewPiecewise([]float64{-inf, 0, inf}, []Poly{Poly{}, New(0, 1)})
+	sum := relu.Add(hat.Scale(2))
+	for _, x := range []float64{-5, -1, -0.5, 0, 0.5, 1, 3} {
+		want := 1 - math.Abs(x)
+		if x >= 1 {
+			want = 0
+		}
+		if got := hat.Eval(x); math.Abs(got-want) > 0.00001 {
+			t.Errorf("Eval(%f) == %f, want %f", x, got, want)
+		}
+		if got, want := sum.Eval(x), relu.Eval(x)+2*want; math.Abs(got-want) > 0.00001 {
+			t.Errorf("Add().Eval(%f) == %f, want %f", x, got, want)
+		}
+	}
+	if got, off := sum.LocalPiece(0); !comparePoly(got, New(0, 2)) || off != -1 {
+		t.Errorf("Add().LocalPiece(0) == %q, %g, want %q, -1", got, off, New(0, 2))
+	}
+}
+
+// Tests the string representation of piecewise polynomials.
+func TestPiecewiseString(t *testing.T) {
+	hat, _ := NewPiecewise([]float64{-1, 0, 1}, []Poly{New(1, 1), New(1, -1)})
+	if got, want := hat.String(), "[-1, 0]: x + 1.000; [0, 1]: -x + 1.000"; got != want {
+		t.Errorf("String() == %q, want %q", got, want)
+	}
+}
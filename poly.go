@@ -2,7 +2,6 @@
 package poly
 
 import (
-	"bytes"
 	"fmt"
 	"math"
 )
@@ -48,6 +47,71 @@ func New(c ...float64) Poly {
 	return normalized(a)
 }
 
+// Creates a new Poly with the single term c*x^n.
+// Panics if n is negative.
+func Monomial(c float64, n int) Poly {
+	if n < 0 {
+		panic("poly: negative exponent")
+	}
+	a := make([]float64, n+1)
+	a[n] = c
+	return normalized(a)
+}
+
+// Creates a new Poly from a map of exponents to coefficients.
+// Exponents missing from the map have a coefficient of zero.
+// Panics if any exponent is negative.
+func FromMap(m map[int]float64) Poly {
+	n := 0
+	for e := range m {
+		if e < 0 {
+			panic("poly: negative exponent")
+		}
+		if e > n {
+			n = e
+		}
+	}
+	c := make([]float64, n+1)
+	for e, k := range m {
+		c[e] = k
+	}
+	return normalized(c)
+}
+
+// Creates a new monic Poly with the given roots.
+// Returns the expansion of (x - r[0])*(x - r[1])*...*(x - r[n-1]). With no
+// roots the result is the constant 1.
+func FromRoots(r ...float64) Poly {
+	c := make([]float64, len(r)+1)
+	c[0] = 1
+	for i, ri := range r {
+		// Multiply the degree i polynomial in c by (x - ri) in place.
+		for j := i + 1; j > 0; j-- {
+			c[j] = c[j-1] - ri*c[j]
+		}
+		c[0] *= -ri
+	}
+	return normalized(c)
+}
+
+// Creates a new Poly that is the binomial expansion of (x + a)^n.
+// Each coefficient C(n,k) a^(n-k) is computed directly, so unlike repeated
+// multiplication the rounding error does not accumulate with n. The binomial
+// coefficients are exact while they are below 2^53.
+// Panics if n is negative.
+func Binomial(a float64, n int) Poly {
+	if n < 0 {
+		panic("poly: negative exponent")
+	}
+	c := make([]float64, n+1)
+	binom := 1.0
+	for k := 0; k <= n; k++ {
+		c[k] = binom * math.Pow(a, float64(n-k))
+		binom = binom * float64(n-k) / float64(k+1)
+	}
+	return normalized(c)
+}
+
 // Returns the highest degree of the polynomial's highest order term.
 func (p Poly) Deg() int {
 	return len(p.co()) - 1
@@ -61,11 +125,24 @@ func (p Poly) Coeff(i int) float64 {
 	return p.co()[i]
 }
 
+// Returns a copy of the coefficients, where the ith element is the
+// coefficient of the ith order term. The result has length Deg()+1, and is
+// {0} for the zero polynomial.
+func (p Poly) Coefficients() []float64 {
+	pco := p.co()
+	c := make([]float64, len(pco))
+	copy(c, pco)
+	return c
+}
+
 // Evaluates a polynomial at the given point x.
+// Horner's method is used, requiring one multiplication and one addition per
+// term.
 func (p Poly) Eval(x float64) float64 {
+	pco := p.co()
 	var n float64
-	for i, c := range p.co() {
-		n += c * math.Pow(x, float64(i))
+	for i := len(pco) - 1; i >= 0; i-- {
+		n = n*x + pco[i]
 	}
 	return n
 }
@@ -98,43 +175,151 @@ func (p Poly) Add(q Poly) Poly {
 // Subtracts a polynomial from another polynomial.
 // Returns p-q.
 func (p Poly) Sub(q Poly) Poly {
+	pco := p.co()
 	qco := q.co()
-	qlen := len(qco)
-	c := make([]float64, qlen)
+	c := make([]float64, max(len(pco), len(qco)))
+	copy(c, pco)
 	for i, qc := range qco {
-		c[i] = -qc
+		c[i] -= qc
 	}
-	return p.Add(Poly{c})
+	return normalized(c)
+}
+
+// Multiplies a polynomial by a scalar.
+// Returns k*p.
+func (p Poly) Scale(k float64) Poly {
+	pco := p.co()
+	c := make([]float64, len(pco))
+	for i, pc := range pco {
+		c[i] = k * pc
+	}
+	return normalized(c)
+}
+
+// Negates a polynomial.
+// Returns -p.
+func (p Poly) Neg() Poly {
+	return p.Scale(-1)
+}
+
+// Adds a scalar to a polynomial.
+// Returns p+k.
+func (p Poly) AddScalar(k float64) Poly {
+	pco := p.co()
+	c := make([]float64, len(pco))
+	copy(c, pco)
+	c[0] += k
+	return normalized(c)
 }
 
 // Multiplies a polynomial by another polynomial.
 // Returns p*q.
+// When both polynomials have many terms the product is computed by
+// Karatsuba's algorithm, or for high degrees by FFT, which is much faster.
+// Both are applied after a balancing change of variable, so that the result
+// is accurate to a small multiple of the rounding error relative to the size
+// of the coefficients after scaling, even when they vary geometrically over
+// many orders of magnitude.
 func (p Poly) Mul(q Poly) Poly {
 	pco := p.co()
 	plen := len(pco)
 	qco := q.co()
 	qlen := len(qco)
 	c := make([]float64, plen+qlen-1)
-	for i, pc := range pco {
-		for j, qc := range qco {
-			c[i+j] += pc * qc
+	mulInto(c, pco, qco)
+	return normalized(c)
+}
+
+// Raises a polynomial to a non-negative integer power using binary
+// exponentiation.
+// Returns p^n. Panics if n is negative.
+func (p Poly) Pow(n int) Poly {
+	if n < 0 {
+		panic("poly: negative exponent")
+	}
+	r := New(1)
+	b := p
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			r = r.Mul(b)
+		}
+		if n > 1 {
+			b = b.Mul(b)
 		}
 	}
-	return normalized(c)
+	return r
 }
 
-// use Euclidean division algorithm to find remainder (the mod)
+// Divides a polynomial by another polynomial using Euclidean long division.
+// Returns the quotient and remainder of p/q, such that p = quo*q + rem and the
+// degree of rem is less than the degree of q.
+// If q is zero, the quotient is zero and the remainder is p.
+func (p Poly) DivMod(q Poly) (quo, rem Poly) {
+	qco := q.co()
+	d := len(qco) - 1
+	lead := qco[d]
+	pco := p.co()
+	if lead == 0.0 || len(pco) <= d {
+		return Poly{}, p
+	}
+
+	r := getFloats(len(pco))
+	defer putFloats(r)
+	copy(*r, pco)
+	c := make([]float64, len(pco)-d)
+	longDiv(c, *r, qco)
+	if d == 0 {
+		return normalized(c), Poly{}
+	}
+	return normalized(c), New((*r)[:d]...)
+}
+
+// Divides the coefficients r by the coefficients q, whose last element must
+// be nonzero, using Euclidean long division.
+// Stores the quotient in c, which must have length len(r)-len(q)+1, and
+// leaves the remainder in the first len(q)-1 elements of r.
+func longDiv(c, r, qco []float64) {
+	d := len(qco) - 1
+	lead := qco[d]
+	for i := len(r) - 1; i >= d; i-- {
+		k := r[i] / lead
+		c[i-d] = k
+		for j := 0; j < d; j++ {
+			r[i-d+j] -= k * qco[j]
+		}
+		// Cancelled exactly by construction, regardless of rounding.
+		r[i] = 0
+	}
+}
+
+// Divides a polynomial by another polynomial.
+// Returns the quotient of p/q, discarding any remainder.
+func (p Poly) Div(q Poly) Poly {
+	quo, _ := p.DivMod(q)
+	return quo
+}
+
+// Computes the remainder of dividing a polynomial by another polynomial.
+// The reduction continues until the degree of the remainder is strictly less
+// than the degree of q.
+// Returns p mod q.
 func (p Poly) Mod(q Poly) Poly {
-	r := p
-	d := q.Deg()
-	c := q.Coeff(q.Deg())
-	for r.Deg() >= d {
-		sT := make([]float64, r.Deg()-d+1)
-		sT[len(sT)-1] = r.Coeff(r.Deg()) / c
-		s := New(sT...)
-		r = r.Sub(s.Mul(q))
+	qco := q.co()
+	d := len(qco) - 1
+	pco := p.co()
+	if qco[d] == 0.0 || len(pco) <= d {
+		return p
 	}
-	return r
+	if d == 0 {
+		return Poly{}
+	}
+	r := getFloats(len(pco))
+	defer putFloats(r)
+	c := getFloats(len(pco) - d)
+	defer putFloats(c)
+	copy(*r, pco)
+	longDiv(*c, *r, qco)
+	return New((*r)[:d]...)
 }
 
 // Computes the derivative of a polynomial.
@@ -165,40 +350,10 @@ func (p Poly) Int(k float64) Poly {
 
 // Returns a printable string representing the polynomial value.
 func (p Poly) String() string {
-	var buffer bytes.Buffer
-
-	pco := p.co()
-	plen := len(pco)
-
-	first := true
-	for i := plen; i > 0; i-- {
-		e := i - 1
-		absc := math.Abs(pco[e])
-		if absc < 0.0001 && !(first && e == 0) {
-			continue
-		}
-
-		c := pco[e]
-		if !first {
-			if c < 0 {
-				buffer.WriteString(" - ")
-			} else {
-				buffer.WriteString(" + ")
-			}
-			c = absc
-		}
-		if absc != 1.0 || e == 0 {
-			buffer.WriteString(fmt.Sprintf("%.3f", c))
-		} else if c == -1.0 && first {
-			buffer.WriteString("-")
-		}
-		if e != 0 {
-			buffer.WriteString("x")
-			if e != 1 {
-				buffer.WriteString(fmt.Sprintf("^%d", e))
-			}
-		}
-		first = false
-	}
-	return buffer.String()
+	return termFormat{
+		coeff: func(c float64) string {
+			return fmt.Sprintf("%.3f", c)
+		},
+		min: 0.0001,
+	}.format(p)
 }
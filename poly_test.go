@@ -17,6 +17,98 @@ func comparePoly(p, q Poly) bool {
 	return true
 }
 
+// Tests that single term polynomials are constructed correctly.
+func TestMonomial(t *testing.T) {
+	cases := []struct {
+		c    float64
+		n    int
+		want Poly
+	}{
+		{0, 0, Poly{}},
+		{0, 5, Poly{}},
+		{3, 0, New(3)},
+		{1, 1, New(0, 1)},
+		{-2.5, 4, New(0, 0, 0, 0, -2.5)},
+	}
+	for i, c := range cases {
+		if got := Monomial(c.c, c.n); !comparePoly(got, c.want) {
+			t.Errorf("case %d: Monomial(%.3f, %d) == %q, want %q", i, c.c, c.n, got, c.want)
+		}
+	}
+}
+
+// Tests that polynomials are constructed from exponent maps.
+func TestFromMap(t *testing.T) {
+	cases := []struct {
+		m    map[int]float64
+		want Poly
+	}{
+		{nil, Poly{}},
+		{map[int]float64{0: 2}, New(2)},
+		{map[int]float64{2: 3, 0: 1}, New(1, 0, 3)},
+		{map[int]float64{4: 0, 1: 1}, New(0, 1)},
+		{map[int]float64{1000: 1, 500: -2, 0: 3}, Monomial(1, 1000).Add(Monomial(-2, 500)).AddScalar(3)},
+	}
+	for i, c := range cases {
+		if got := FromMap(c.m); !comparePoly(got, c.want) {
+			t.Errorf("case %d: FromMap(%v) == %q, want %q", i, c.m, got, c.want)
+		}
+	}
+}
+
+// Tests that polynomials are constructed from their roots.
+func TestFromRoots(t *testing.T) {
+	cases := []struct {
+		r    []float64
+		want Poly
+	}{
+		{nil, New(1)},
+		{[]float64{0}, New(0, 1)},
+		{[]float64{2}, New(-2, 1)},
+		{[]float64{1, -1}, New(-1, 0, 1)},
+		{[]float64{1, 1}, New(1, -2, 1)},
+		{[]float64{1, 2, 3}, New(-6, 11, -6, 1)},
+		{[]float64{0.5, -2, 0, 4}, New(0, 4, -7, -2.5, 1)},
+	}
+	for i, c := range cases {
+		if got := FromRoots(c.r...); !comparePoly(got, c.want) {
+			t.Errorf("case %d: FromRoots(%v) == %q, want %q", i, c.r, got, c.want)
+		}
+	}
+}
+
+// Tests that binomial expansions are computed correctly.
+func TestBinomial(t *testing.T) {
+	cases := []struct {
+		a    float64
+		n    int
+		want Poly
+	}{
+		{2, 0, New(1)},
+		{2, 1, New(2, 1)},
+		{0, 3, New(0, 0, 0, 1)},
+		{1, 2, New(1, 2, 1)},
+		{-1, 3, New(-1, 3, -3, 1)},
+		{0.5, 4, New(0.0625, 0.5, 1.5, 2, 1)},
+		{-3, 5, New(-3, 1).Pow(5)},
+	}
+	for i, c := range cases {
+		if got := Binomial(c.a, c.n); !comparePoly(got, c.want) {
+			t.Errorf("case %d: Binomial(%.3f, %d) == %q, want %q", i, c.a, c.n, got, c.want)
+		}
+	}
+	// Row 50 of Pascal's triangle is below 2^53.
+	if got, want := Binomial(1, 50).Coeff(25), 126410606437752.0; got != want {
+		t.Errorf("Binomial(1, 50).Coeff(25) == %f, want %f", got, want)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Binomial(1, -1) did not panic")
+		}
+	}()
+	Binomial(1, -1)
+}
+
 // Tests that the degree of various polynomials is reported as expected.
 func TestDeg(t *testing.T) {
 	cases := []struct {
@@ -87,6 +179,29 @@ func TestCoeffOutOfRange(t *testing.T) {
 	}
 }
 
+// Tests that all coefficients are returned as a copy.
+func TestCoefficients(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		want []float64
+	}{
+		{Poly{}, []float64{0}},
+		{New(), []float64{0}},
+		{New(5), []float64{5}},
+		{New(1, 0, 3, 0, 0), []float64{1, 0, 3}},
+	}
+	for i, c := range cases {
+		got := c.p.Coefficients()
+		if !compareVec(got, c.want) {
+			t.Errorf("case %d: Coefficients() on %q == %v, want %v", i, c.p, got, c.want)
+		}
+		got[0] = 42
+		if c.p.Coeff(0) == 42 {
+			t.Errorf("case %d: modifying Coefficients() changed %q", i, c.p)
+		}
+	}
+}
+
 // Tests that function evaluation produces correct results.
 func TestEval(t *testing.T) {
 	cases := []struct {
@@ -107,6 +222,57 @@ func TestEval(t *testing.T) {
 	}
 }
 
+// Tests that evaluation of a high degree polynomial is accurate where the
+// powers of x overflow or underflow but the terms do not.
+func TestEvalHighDegree(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		x    float64
+		want float64
+	}{
+		// x^40 overflows, so summing c*x^i gives NaN from the zero terms.
+		{FromMap(map[int]float64{40: 1e-300, 0: 1}), 1e10, 1e100},
+		{FromMap(map[int]float64{40: 1e-300, 39: -1e-300}), 1e10, 1e100 * (1 - 1e-10)},
+		// x^40 underflows to zero, so summing c*x^i gives zero.
+		{FromMap(map[int]float64{40: 1e300}), 1e-10, 1e-100},
+		{FromMap(map[int]float64{40: 1e300, 41: 1e300}), -1e-10, 1e-100 * (1 - 1e-10)},
+		{New(1, 1).Pow(40), 0.75, math.Pow(1.75, 40)},
+	}
+	for i, c := range cases {
+		if got := c.p.Eval(c.x); math.Abs(got-c.want) > 1e-12*math.Abs(c.want) {
+			t.Errorf("case %d: Eval(%g) on %q == %g, want %g", i, c.x, c.p, got, c.want)
+		}
+	}
+}
+
+// Evaluates a polynomial by summing c*x^i, as Eval did before using Horner's
+// method. It serves as the baseline for benchmarks.
+func evalPow(p Poly, x float64) float64 {
+	var n float64
+	for i := 0; i <= p.Deg(); i++ {
+		n += p.Coeff(i) * math.Pow(x, float64(i))
+	}
+	return n
+}
+
+func benchmarkEval(b *testing.B, deg int, eval func(Poly, float64) float64) {
+	c := make([]float64, deg+1)
+	for i := range c {
+		c[i] = 1 / float64(i+1)
+	}
+	p := New(c...)
+	for i := 0; i < b.N; i++ {
+		eval(p, 0.999)
+	}
+}
+
+func BenchmarkEval10(b *testing.B)      { benchmarkEval(b, 10, Poly.Eval) }
+func BenchmarkEval100(b *testing.B)     { benchmarkEval(b, 100, Poly.Eval) }
+func BenchmarkEval1000(b *testing.B)    { benchmarkEval(b, 1000, Poly.Eval) }
+func BenchmarkEvalPow10(b *testing.B)   { benchmarkEval(b, 10, evalPow) }
+func BenchmarkEvalPow100(b *testing.B)  { benchmarkEval(b, 100, evalPow) }
+func BenchmarkEvalPow1000(b *testing.B) { benchmarkEval(b, 1000, evalPow) }
+
 // Tests that polynomials add correctly.
 func TestAdd(t *testing.T) {
 	cases := []struct {
@@ -153,6 +319,62 @@ func TestSub(t *testing.T) {
 	}
 }
 
+// Tests that polynomials are scaled correctly.
+func TestScale(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		k    float64
+		want Poly
+	}{
+		{Poly{}, 2, Poly{}},
+		{New(1, 2), 0, New()},
+		{New(1, 2), 1, New(1, 2)},
+		{New(1, -2, 3), 2, New(2, -4, 6)},
+		{New(1, -2, 3), -0.5, New(-0.5, 1, -1.5)},
+	}
+	for i, c := range cases {
+		if got := c.p.Scale(c.k); !comparePoly(got, c.want) {
+			t.Errorf("case %d: Scale(%.3f) on %q == %q, want %q", i, c.k, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests that polynomials are negated correctly.
+func TestNeg(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		want Poly
+	}{
+		{Poly{}, Poly{}},
+		{New(1), New(-1)},
+		{New(1, -2, 3), New(-1, 2, -3)},
+	}
+	for i, c := range cases {
+		if got := c.p.Neg(); !comparePoly(got, c.want) {
+			t.Errorf("case %d: Neg() on %q == %q, want %q", i, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests that scalars are added to the constant term.
+func TestAddScalar(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		k    float64
+		want Poly
+	}{
+		{Poly{}, 0, Poly{}},
+		{Poly{}, 3, New(3)},
+		{New(1, 2), -1, New(0, 2)},
+		{New(1, -2, 3), 2.5, New(3.5, -2, 3)},
+	}
+	for i, c := range cases {
+		if got := c.p.AddScalar(c.k); !comparePoly(got, c.want) {
+			t.Errorf("case %d: AddScalar(%.3f) on %q == %q, want %q", i, c.k, c.p, got, c.want)
+		}
+	}
+}
+
 // Tests that polynomials multiply correctly.
 func TestMul(t *testing.T) {
 	cases := []struct {
@@ -176,6 +398,99 @@ func TestMul(t *testing.T) {
 	}
 }
 
+// Tests that polynomials are raised to integer powers correctly.
+func TestPow(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		n    int
+		want Poly
+	}{
+		{Poly{}, 0, New(1)},
+		{Poly{}, 3, Poly{}},
+		{New(1, 2), 0, New(1)},
+		{New(1, 2), 1, New(1, 2)},
+		{New(1, 1), 2, New(1, 2, 1)},
+		{New(1, 1), 5, New(1, 5, 10, 10, 5, 1)},
+		{New(-1, 0, 2), 3, New(-1, 0, 6, 0, -12, 0, 8)},
+		{New(2), 10, New(1024)},
+	}
+	for i, c := range cases {
+		if got := c.p.Pow(c.n); !comparePoly(got, c.want) {
+			t.Errorf("case %d: Pow(%d) on %q == %q, want %q", i, c.n, c.p, got, c.want)
+		}
+	}
+}
+
+func BenchmarkPow(b *testing.B) {
+	p := New(0.5, -0.25, 0.125, 1)
+	for i := 0; i < b.N; i++ {
+		p.Pow(64)
+	}
+}
+
+func BenchmarkPowMulLoop(b *testing.B) {
+	p := New(0.5, -0.25, 0.125, 1)
+	for i := 0; i < b.N; i++ {
+		r := New(1)
+		for j := 0; j < 64; j++ {
+			r = r.Mul(p)
+		}
+	}
+}
+
+// Tests that polynomials divide correctly.
+func TestDiv(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		q    Poly
+		want Poly
+	}{
+		{Poly{}, New(1, 2), Poly{}},
+		{New(3, 4), New(1, 2, 3), Poly{}},
+		{New(2, 4), New(2), New(1, 2)},
+		{New(-4, 0, 1), New(-2, 1), New(2, 1)},
+		{New(1, 0, 0, 1), New(1, 1), New(1, -1, 1)},
+		{New(1, 2, 3), New(3, 4), New(-0.0625, 0.75)},
+		{New(3, 10, 17, 12), New(1, 2, 3), New(3, 4)},
+	}
+	for i, c := range cases {
+		if got := c.p.Div(c.q); !comparePoly(got, c.want) {
+			t.Errorf("case %d: Div(%q) on %q == %q, want %q", i, c.q, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests that the quotient and remainder reconstruct the dividend.
+func TestDivMod(t *testing.T) {
+	cases := []struct {
+		p       Poly
+		q       Poly
+		wantQuo Poly
+		wantRem Poly
+	}{
+		{Poly{}, Poly{}, Poly{}, Poly{}},
+		{New(1, 2), Poly{}, Poly{}, New(1, 2)},
+		{New(1, 2, 3), New(3, 4), New(-0.0625, 0.75), New(1.1875)},
+		{New(1, 0, 0, 1), New(1, 1), New(1, -1, 1), New(0)},
+		{New(1, 0, 0, 2), New(1, 1), New(2, -2, 2), New(-1)},
+		{New(5, 4, 3, 2, 1), New(1, 0, 1), New(2, 2, 1), New(3, 2)},
+		{New(3, 4), New(1, 2, 3), Poly{}, New(3, 4)},
+	}
+	for i, c := range cases {
+		quo, rem := c.p.DivMod(c.q)
+		if !comparePoly(quo, c.wantQuo) || !comparePoly(rem, c.wantRem) {
+			t.Errorf("case %d: DivMod(%q) on %q == %q, %q, want %q, %q",
+				i, c.q, c.p, quo, rem, c.wantQuo, c.wantRem)
+		}
+		if c.q.Deg() > 0 || c.q.Coeff(0) != 0 {
+			if got := quo.Mul(c.q).Add(rem); !comparePoly(got, c.p) {
+				t.Errorf("case %d: quo*q+rem == %q, want %q", i, got, c.p)
+			}
+		}
+	}
+}
+
+// Tests that remainders are fully reduced below the degree of the divisor.
 func TestMod(t *testing.T) {
 	cases := []struct {
 		p    Poly
@@ -187,13 +502,22 @@ func TestMod(t *testing.T) {
 		{Poly{}, New(1, 2), Poly{}},
 		{New(2, 1), New(-2, 1), New(4)},
 		{New(3, 4), New(1, 2), New(1)},
-		{New(1, 2, 3), New(3, 4), New(1, -0.25)},
+		{New(1, 2, 3), New(3, 4), New(1.1875)},
 		{New(3, 4), New(1, 2, 3), New(3, 4)},
+		{New(1, 0, 0, 1), New(1, 1), New(0)},
+		{New(2, 0, 0, 1), New(1, 1), New(1)},
+		{New(5, 4, 3, 2, 1), New(1, 0, 1), New(3, 2)},
+		{New(1, 1, 1, 1, 1, 1), New(1, 1, 1), New(0)},
+		{New(-1, 0, 0, 0, 0, 0, 0, 1), New(-1, 1), New(0)},
+		{New(7, -3, 0, 0, 0, 2), New(1, 0, 0, 1), New(7, -3, -2)},
 	}
 	for i, c := range cases {
 		if got := c.p.Mod(c.q); !comparePoly(got, c.want) {
 			t.Errorf("case %d: Mod(%q) on %q == %q, want %q", i, c.q, c.p, got, c.want)
 		}
+		if got := c.p.Mod(c.q); c.q.Deg() > 0 && got.Deg() >= c.q.Deg() {
+			t.Errorf("case %d: Mod(%q) on %q has degree %d, want less than %d", i, c.q, c.p, got.Deg(), c.q.Deg())
+		}
 	}
 }
 
@@ -0,0 +1,75 @@
+package poly
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// Pools of scratch slices for intermediate results that do not outlive the
+// call creating them, such as the working remainder of Mod, the temporaries
+// of Karatsuba's algorithm and the buffers of the FFT. Reusing them avoids
+// garbage collector pressure when polynomials are combined in tight loops.
+// Pointers to slices are pooled so that putting one back does not allocate.
+// There is a pool for each power of two capacity, so that a request for a
+// large slice never takes and discards a small one, and small requests do
+// not hold on to large slices.
+var (
+	floatPools   [bits.UintSize]sync.Pool
+	complexPools [bits.UintSize]sync.Pool
+)
+
+// Returns the size class of a request for n elements: the smallest k with
+// 2^k >= n.
+func sizeClass(n int) int {
+	if n <= 1 {
+		return 0
+	}
+	return bits.Len(uint(n - 1))
+}
+
+// Returns the size class of a pooled slice with capacity c: the largest k
+// with 2^k <= c, so that every request of that class fits.
+func capClass(c int) int {
+	if c <= 1 {
+		return 0
+	}
+	return bits.Len(uint(c)) - 1
+}
+
+// Returns a zeroed scratch slice of length n from the pool, allocating one,
+// with capacity n rounded up to a power of two, if none is available. It
+// should be returned with putFloats once it is no longer used.
+func getFloats(n int) *[]float64 {
+	k := sizeClass(n)
+	if s, ok := floatPools[k].Get().(*[]float64); ok {
+		*s = (*s)[:n]
+		clear(*s)
+		return s
+	}
+	s := make([]float64, n, 1<<k)
+	return &s
+}
+
+// Returns a scratch slice obtained from getFloats to the pool.
+func putFloats(s *[]float64) {
+	floatPools[capClass(cap(*s))].Put(s)
+}
+
+// Returns a zeroed scratch slice of length n from the pool, allocating one,
+// with capacity n rounded up to a power of two, if none is available. It
+// should be returned with putComplex once it is no longer used.
+func getComplex(n int) *[]complex128 {
+	k := sizeClass(n)
+	if s, ok := complexPools[k].Get().(*[]complex128); ok {
+		*s = (*s)[:n]
+		clear(*s)
+		return s
+	}
+	s := make([]complex128, n, 1<<k)
+	return &s
+}
+
+// Returns a scratch slice obtained from getComplex to the pool.
+func putComplex(s *[]complex128) {
+	complexPools[capClass(cap(*s))].Put(s)
+}
@@ -0,0 +1,88 @@
+package poly
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Tests that scratch slices are zeroed when reused.
+func TestGetFloats(t *testing.T) {
+	s := getFloats(8)
+	for i := range *s {
+		(*s)[i] = float64(i + 1)
+	}
+	putFloats(s)
+	for _, n := range []int{4, 8, 16} {
+		s := getFloats(n)
+		if len(*s) != n {
+			t.Errorf("len(getFloats(%d)) == %d", n, len(*s))
+		}
+		for i, x := range *s {
+			if x != 0 {
+				t.Errorf("getFloats(%d)[%d] == %v, want 0", n, i, x)
+			}
+		}
+		putFloats(s)
+	}
+	c := getComplex(4)
+	(*c)[3] = 1i
+	putComplex(c)
+	c = getComplex(4)
+	if (*c)[3] != 0 {
+		t.Errorf("getComplex(4)[3] == %v, want 0", (*c)[3])
+	}
+}
+
+// Tests that scratch slices are pooled by size class, so that every slice
+// taken from a class fits its requests and small requests are not given
+// large slices.
+func TestPoolSizeClasses(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 1000, 1024, 1025} {
+		k := sizeClass(n)
+		if 1<<k < n || (k > 0 && 1<<(k-1) >= n) {
+			t.Errorf("sizeClass(%d) == %d", n, k)
+		}
+		if c := capClass(1 << k); c != k {
+			t.Errorf("capClass(%d) == %d, want %d", 1<<k, c, k)
+		}
+		s := getFloats(n)
+		if cap(*s) < n || capClass(cap(*s)) != k {
+			t.Errorf("cap(getFloats(%d)) == %d", n, cap(*s))
+		}
+		putFloats(s)
+	}
+	putFloats(getFloats(1000))
+	putComplex(getComplex(1000))
+	if s := getFloats(3); cap(*s) != 4 {
+		t.Errorf("cap(getFloats(3)) == %d, want 4", cap(*s))
+	}
+	if c := getComplex(3); cap(*c) != 4 {
+		t.Errorf("cap(getComplex(3)) == %d, want 4", cap(*c))
+	}
+}
+
+// Tests that Sub, Mod and Mul allocate only their results once the pools
+// are warm.
+func TestScratchAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector makes allocations")
+	}
+	rnd := rand.New(rand.NewSource(1))
+	p, q := randomPoly(rnd, 300), randomPoly(rnd, 200)
+	cases := []struct {
+		name string
+		f    func()
+		max  float64
+	}{
+		{"Sub", func() { p.Sub(q) }, 1},
+		{"Mod", func() { p.Mod(q) }, 1},
+		{"DivMod", func() { p.DivMod(q) }, 2},
+		{"Mul", func() { p.Mul(q) }, 1},
+	}
+	for _, c := range cases {
+		c.f()
+		if got := testing.AllocsPerRun(100, c.f); got > c.max {
+			t.Errorf("%s made %v allocations, want at most %v", c.name, got, c.max)
+		}
+	}
+}
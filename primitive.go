@@ -0,0 +1,143 @@
+package poly
+
+import (
+	"math/big"
+	"sort"
+)
+
+// Reports whether a polynomial over GF(2) is primitive, meaning that it is
+// irreducible and x generates the multiplicative group of the field it
+// defines. A primitive polynomial of degree n is the feedback polynomial of a
+// maximal length LFSR, with period 2^n - 1.
+// Panics if the degree is greater than 64.
+func (f GF2Poly) IsPrimitive() bool {
+	n := f.deg()
+	if n > 64 {
+		panic("poly: degree too large for primitivity test")
+	}
+	if !f.IsIrreducible() {
+		return false
+	}
+	// x has order 2^n - 1 exactly when x^((2^n - 1)/r) != 1 for each prime r
+	// dividing 2^n - 1.
+	order := ^uint64(0) >> (64 - n)
+	x := NewGF2(1)
+	one := NewGF2(0).Mod(f)
+	for _, r := range factorUint64(order) {
+		e := new(big.Int).SetUint64(order / r)
+		if x.PowMod(e, f).Equal(one) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the primitive polynomial of degree n over GF(2) with the fewest
+// terms, and among those the one whose coefficient bits form the smallest
+// integer. Such sparse polynomials are preferred for LFSRs and CRCs because
+// each term costs a tap.
+// Panics if n is not between 1 and 64.
+func PrimitiveGF2(n int) GF2Poly {
+	if n < 1 || n > 64 {
+		panic("poly: degree out of range")
+	}
+	if n == 1 {
+		return NewGF2(1, 0)
+	}
+	// A primitive polynomial has a constant term, and an odd number of terms
+	// since otherwise x + 1 divides it. Search trinomials, then pentanomials,
+	// and so on.
+	for terms := 3; terms <= n+1; terms += 2 {
+		if f, ok := primitiveWithTerms(n, terms-2); ok {
+			return f
+		}
+	}
+	panic("poly: no primitive polynomial found")
+}
+
+// Searches for a primitive polynomial x^n + ... + 1 with k > 0 middle terms,
+// visiting the middle exponents in colexicographic order so that the
+// polynomials increase numerically.
+func primitiveWithTerms(n, k int) (GF2Poly, bool) {
+	e := make([]int, k)
+	for i := range e {
+		e[i] = i + 1
+	}
+	for e[k-1] < n {
+		f := NewGF2(append([]int{n, 0}, e...)...)
+		if f.IsPrimitive() {
+			return f, true
+		}
+		// Advance to the next combination: increment the lowest exponent
+		// that can move up, and reset those below it.
+		i := 0
+		for i < k-1 && e[i]+1 == e[i+1] {
+			i++
+		}
+		e[i]++
+		for j := 0; j < i; j++ {
+			e[j] = j + 1
+		}
+	}
+	return GF2Poly{}, false
+}
+
+// Returns the distinct prime factors of n > 0 in increasing order, using
+// trial division for small factors and Pollard's rho method for the rest.
+func factorUint64(n uint64) []uint64 {
+	var r []uint64
+	for _, d := range []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37} {
+		if n%d == 0 {
+			r = append(r, d)
+			for n%d == 0 {
+				n /= d
+			}
+		}
+	}
+	var split func(m uint64)
+	split = func(m uint64) {
+		if m == 1 {
+			return
+		}
+		if new(big.Int).SetUint64(m).ProbablyPrime(0) {
+			for _, x := range r {
+				if x == m {
+					return
+				}
+			}
+			r = append(r, m)
+			return
+		}
+		d := pollardRho(m)
+		split(d)
+		split(m / d)
+	}
+	split(n)
+	sort.Slice(r, func(i, j int) bool { return r[i] < r[j] })
+	return r
+}
+
+// Finds a nontrivial factor of a composite n with no small factors using
+// Pollard's rho method with Floyd cycle detection.
+func pollardRho(n uint64) uint64 {
+	for c := uint64(1); ; c++ {
+		f := func(x uint64) uint64 { return addMod(mulMod(x, x, n), c, n) }
+		x, y, d := uint64(2), uint64(2), uint64(1)
+		for d == 1 {
+			x = f(x)
+			y = f(f(y))
+			d = gcdUint64(max(x, y)-min(x, y), n)
+		}
+		if d != n {
+			return d
+		}
+	}
+}
+
+// Returns the greatest common divisor of a and b.
+func gcdUint64(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
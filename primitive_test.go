@@ -0,0 +1,86 @@
+package poly
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Tests primitivity of polynomials over GF(2).
+func TestGF2PolyIsPrimitive(t *testing.T) {
+	cases := []struct {
+		f    GF2Poly
+		want bool
+	}{
+		{NewGF2(1, 0), true},
+		{NewGF2(2, 1, 0), true},
+		{NewGF2(4, 1, 0), true},
+		// x^4 + x^3 + x^2 + x + 1 is irreducible, but x has order 5.
+		{NewGF2(4, 3, 2, 1, 0), false},
+		// The AES polynomial is irreducible but not primitive.
+		{aesPoly, false},
+		{NewGF2(8, 4, 3, 2, 0), true},
+		{NewGF2(4, 2, 0), false},
+		{NewGF2(32, 22, 2, 1, 0), true},
+		{NewGF2(64, 4, 3, 1, 0), true},
+	}
+	for i, c := range cases {
+		if got := c.f.IsPrimitive(); got != c.want {
+			t.Errorf("case %d: IsPrimitive() on %q == %v, want %v", i, c.f, got, c.want)
+		}
+	}
+	// There are phi(2^8 - 1)/8 = 16 primitive polynomials of degree 8.
+	n := 0
+	for v := uint64(256); v < 512; v++ {
+		if GF2FromUint(v).IsPrimitive() {
+			n++
+		}
+	}
+	if n != 16 {
+		t.Errorf("found %d primitive polynomials of degree 8, want 16", n)
+	}
+}
+
+// Tests that primitive polynomials are found for each degree.
+func TestPrimitiveGF2(t *testing.T) {
+	cases := []struct {
+		n    int
+		want GF2Poly
+	}{
+		{1, NewGF2(1, 0)},
+		{2, NewGF2(2, 1, 0)},
+		{3, NewGF2(3, 1, 0)},
+		{8, NewGF2(8, 4, 3, 2, 0)},
+		{16, NewGF2(16, 5, 3, 2, 0)},
+		{31, NewGF2(31, 3, 0)},
+	}
+	for i, c := range cases {
+		if got := PrimitiveGF2(c.n); !got.Equal(c.want) {
+			t.Errorf("case %d: PrimitiveGF2(%d) == %q, want %q", i, c.n, got, c.want)
+		}
+	}
+	for n := 1; n <= 64; n++ {
+		if f := PrimitiveGF2(n); f.Deg() != n || !f.IsPrimitive() {
+			t.Errorf("PrimitiveGF2(%d) == %q", n, f)
+		}
+	}
+}
+
+// Tests that factorUint64 finds the prime factors of Mersenne numbers.
+func TestFactorUint64(t *testing.T) {
+	cases := []struct {
+		n    uint64
+		want string
+	}{
+		{1, "[]"},
+		{255, "[3 5 17]"},
+		{1<<32 - 1, "[3 5 17 257 65537]"},
+		{1<<61 - 1, "[2305843009213693951]"},
+		{1<<62 - 1, "[3 715827883 2147483647]"},
+		{1<<64 - 1, "[3 5 17 257 641 65537 6700417]"},
+	}
+	for i, c := range cases {
+		if got := fmt.Sprint(factorUint64(c.n)); got != c.want {
+			t.Errorf("case %d: factorUint64(%d) == %s, want %s", i, c.n, got, c.want)
+		}
+	}
+}
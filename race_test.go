@@ -0,0 +1,7 @@
+//go:build race
+
+package poly
+
+// Reports whether the race detector is enabled, which makes allocation
+// counts unreliable.
+const raceEnabled = true
@@ -0,0 +1,281 @@
+package poly
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// RatPoly represents a polynomial with exact rational coefficients.
+// Arithmetic on RatPoly is exact, so results such as quotients, remainders
+// and greatest common divisors are free of rounding error.
+// RatPoly values are immutable; no method modifies its receiver or arguments.
+// A zero valued RatPoly is equivalent to 0.
+type RatPoly struct {
+	coeff []*big.Rat
+}
+
+// Creates a new RatPoly.
+// The ith parameter represents the coefficient of x^i. The coefficients are
+// copied, and nil is treated as zero.
+func NewRat(c ...*big.Rat) RatPoly {
+	a := make([]*big.Rat, len(c))
+	for i, ci := range c {
+		a[i] = new(big.Rat)
+		if ci != nil {
+			a[i].Set(ci)
+		}
+	}
+	return normalizedRat(a)
+}
+
+// Converts a polynomial to one with exact rational coefficients.
+// Each float64 coefficient is converted exactly. Returns an error if any
+// coefficient is infinite or NaN.
+func (p Poly) Rat() (RatPoly, error) {
+	pco := p.co()
+	c := make([]*big.Rat, len(pco))
+	for i, pc := range pco {
+		c[i] = new(big.Rat).SetFloat64(pc)
+		if c[i] == nil {
+			return RatPoly{}, fmt.Errorf("poly: coefficient %v of x^%d is not finite", pc, i)
+		}
+	}
+	return normalizedRat(c), nil
+}
+
+// Converts a polynomial to one with float64 coefficients, rounding each
+// coefficient to the nearest float64.
+func (p RatPoly) Poly() Poly {
+	pco := p.co()
+	c := make([]float64, len(pco))
+	for i, pc := range pco {
+		c[i], _ = pc.Float64()
+	}
+	return normalized(c)
+}
+
+// Returns the coefficient array, which is {0} for the zero value.
+// The elements must not be modified.
+func (p RatPoly) co() []*big.Rat {
+	if len(p.coeff) == 0 {
+		return []*big.Rat{new(big.Rat)}
+	}
+	return p.coeff
+}
+
+// Returns a polynomial with the given coefficients, removing zero
+// coefficients of terms with degree greater than 0.
+func normalizedRat(c []*big.Rat) RatPoly {
+	i := len(c) - 1
+	for i > 0 && c[i].Sign() == 0 {
+		i--
+	}
+	if i < 0 {
+		return RatPoly{}
+	}
+	return RatPoly{c[0 : i+1]}
+}
+
+// Returns the highest degree of the polynomial's highest order term.
+func (p RatPoly) Deg() int {
+	return len(p.co()) - 1
+}
+
+// Returns a copy of the coefficient of the ith order term.
+func (p RatPoly) Coeff(i int) *big.Rat {
+	if i < 0 || i > p.Deg() {
+		return new(big.Rat)
+	}
+	return new(big.Rat).Set(p.co()[i])
+}
+
+// Reports whether the polynomial is identically zero.
+func (p RatPoly) isZero() bool {
+	return p.Deg() == 0 && p.co()[0].Sign() == 0
+}
+
+// Evaluates a polynomial at the given point x using Horner's method.
+func (p RatPoly) Eval(x *big.Rat) *big.Rat {
+	pco := p.co()
+	n := new(big.Rat)
+	for i := len(pco) - 1; i >= 0; i-- {
+		n.Mul(n, x)
+		n.Add(n, pco[i])
+	}
+	return n
+}
+
+// Adds a polynomial to another polynomial.
+// Returns p+q.
+func (p RatPoly) Add(q RatPoly) RatPoly {
+	pco, qco := p.co(), q.co()
+	if len(pco) < len(qco) {
+		pco, qco = qco, pco
+	}
+	c := make([]*big.Rat, len(pco))
+	for i, pc := range pco {
+		c[i] = new(big.Rat).Set(pc)
+		if i < len(qco) {
+			c[i].Add(c[i], qco[i])
+		}
+	}
+	return normalizedRat(c)
+}
+
+// Subtracts a polynomial from another polynomial.
+// Returns p-q.
+func (p RatPoly) Sub(q RatPoly) RatPoly {
+	return p.Add(q.Neg())
+}
+
+// Multiplies a polynomial by a scalar.
+// Returns k*p.
+func (p RatPoly) Scale(k *big.Rat) RatPoly {
+	pco := p.co()
+	c := make([]*big.Rat, len(pco))
+	for i, pc := range pco {
+		c[i] = new(big.Rat).Mul(k, pc)
+	}
+	return normalizedRat(c)
+}
+
+// Negates a polynomial.
+// Returns -p.
+func (p RatPoly) Neg() RatPoly {
+	pco := p.co()
+	c := make([]*big.Rat, len(pco))
+	for i, pc := range pco {
+		c[i] = new(big.Rat).Neg(pc)
+	}
+	return normalizedRat(c)
+}
+
+// Multiplies a polynomial by another polynomial.
+// Returns p*q.
+func (p RatPoly) Mul(q RatPoly) RatPoly {
+	pco, qco := p.co(), q.co()
+	c := make([]*big.Rat, len(pco)+len(qco)-1)
+	for i := range c {
+		c[i] = new(big.Rat)
+	}
+	var t big.Rat
+	for i, pc := range pco {
+		for j, qc := range qco {
+			c[i+j].Add(c[i+j], t.Mul(pc, qc))
+		}
+	}
+	return normalizedRat(c)
+}
+
+// Divides a polynomial by another polynomial using Euclidean long division.
+// Returns the quotient and remainder of p/q, such that p = quo*q + rem and the
+// degree of rem is less than the degree of q.
+// If q is zero, the quotient is zero and the remainder is p.
+func (p RatPoly) DivMod(q RatPoly) (quo, rem RatPoly) {
+	qco := q.co()
+	d := len(qco) - 1
+	lead := qco[d]
+	pco := p.co()
+	if lead.Sign() == 0 || len(pco) <= d {
+		return RatPoly{}, p
+	}
+	r := make([]*big.Rat, len(pco))
+	for i, pc := range pco {
+		r[i] = new(big.Rat).Set(pc)
+	}
+	c := make([]*big.Rat, len(pco)-d)
+	var t big.Rat
+	for i := len(r) - 1; i >= d; i-- {
+		k := new(big.Rat).Quo(r[i], lead)
+		c[i-d] = k
+		for j := 0; j < d; j++ {
+			r[i-d+j].Sub(r[i-d+j], t.Mul(k, qco[j]))
+		}
+	}
+	return normalizedRat(c), normalizedRat(r[:d])
+}
+
+// Divides a polynomial by another polynomial.
+// Returns the quotient of p/q, discarding any remainder.
+func (p RatPoly) Div(q RatPoly) RatPoly {
+	quo, _ := p.DivMod(q)
+	return quo
+}
+
+// Computes the remainder of dividing a polynomial by another polynomial.
+// Returns p mod q.
+func (p RatPoly) Mod(q RatPoly) RatPoly {
+	_, rem := p.DivMod(q)
+	return rem
+}
+
+// Computes the greatest common divisor of two polynomials using the Euclidean
+// algorithm. Since the arithmetic is exact, no tolerance is needed.
+// The result is monic, unless both p and q are zero, in which case it is zero.
+func (p RatPoly) GCD(q RatPoly) RatPoly {
+	a, b := p, q
+	for !b.isZero() {
+		a, b = b, a.Mod(b)
+	}
+	if a.isZero() {
+		return RatPoly{}
+	}
+	return a.Scale(new(big.Rat).Inv(a.co()[a.Deg()]))
+}
+
+// Computes the derivative of a polynomial.
+func (p RatPoly) Der() RatPoly {
+	pco := p.co()
+	c := make([]*big.Rat, len(pco)-1)
+	for i := range c {
+		c[i] = new(big.Rat).Mul(pco[i+1], big.NewRat(int64(i+1), 1))
+	}
+	return normalizedRat(c)
+}
+
+// Computes the definite integral of a polynomial.
+// The provided constant k will be used as the 0th order term of the result.
+func (p RatPoly) Int(k *big.Rat) RatPoly {
+	pco := p.co()
+	c := make([]*big.Rat, len(pco)+1)
+	c[0] = new(big.Rat).Set(k)
+	for i, pc := range pco {
+		c[i+1] = new(big.Rat).Quo(pc, big.NewRat(int64(i+1), 1))
+	}
+	return normalizedRat(c)
+}
+
+// Returns a printable string representing the polynomial, with coefficients
+// written as exact fractions such as "3/2x^2 - 1/3".
+func (p RatPoly) String() string {
+	var b strings.Builder
+	pco := p.co()
+	for i := len(pco) - 1; i >= 0; i-- {
+		if pco[i].Sign() == 0 && (i > 0 || b.Len() > 0) {
+			continue
+		}
+		s := pco[i].RatString()
+		switch {
+		case b.Len() == 0:
+		case strings.HasPrefix(s, "-"):
+			b.WriteString(" - ")
+			s = s[1:]
+		default:
+			b.WriteString(" + ")
+		}
+		if i > 0 && s == "1" {
+			s = ""
+		} else if i > 0 && s == "-1" {
+			s = "-"
+		}
+		b.WriteString(s)
+		switch {
+		case i == 1:
+			b.WriteString("x")
+		case i > 1:
+			fmt.Fprintf(&b, "x^%d", i)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,85 @@
+package poly
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// Creates a RatPoly from integer numerator and denominator pairs.
+func ratPoly(c ...[2]int64) RatPoly {
+	r := make([]*big.Rat, len(c))
+	for i, ci := range c {
+		r[i] = big.NewRat(ci[0], ci[1])
+	}
+	return NewRat(r...)
+}
+
+// Tests exact arithmetic on rational polynomials.
+func TestRatPoly(t *testing.T) {
+	p := ratPoly([2]int64{1, 3}, [2]int64{-1, 2}, [2]int64{2, 1})
+	q := ratPoly([2]int64{-1, 1}, [2]int64{1, 1})
+	cases := []struct {
+		name      string
+		got, want string
+	}{
+		{"p", p.String(), "2x^2 - 1/2x + 1/3"},
+		{"Add", p.Add(q).String(), "2x^2 + 1/2x - 2/3"},
+		{"Sub", p.Sub(p).String(), "0"},
+		{"Neg", q.Neg().String(), "-x + 1"},
+		{"Scale", p.Scale(big.NewRat(3, 2)).String(), "3x^2 - 3/4x + 1/2"},
+		{"Mul", p.Mul(q).String(), "2x^3 - 5/2x^2 + 5/6x - 1/3"},
+		{"Div", p.Div(q).String(), "2x + 3/2"},
+		{"Mod", p.Mod(q).String(), "11/6"},
+		{"Div by zero", p.Div(RatPoly{}).String(), "0"},
+		{"Der", p.Der().String(), "4x - 1/2"},
+		{"Int", p.Int(big.NewRat(1, 1)).String(), "2/3x^3 - 1/4x^2 + 1/3x + 1"},
+		{"Eval", p.Eval(big.NewRat(1, 2)).RatString(), "7/12"},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("%s == %q, want %q", c.name, c.got, c.want)
+		}
+	}
+}
+
+// Tests that greatest common divisors of rational polynomials are exact.
+func TestRatPolyGCD(t *testing.T) {
+	// (x - 1/3)^2 (x + 7) and (x - 1/3)(x^2 + 1/10).
+	a := ratPoly([2]int64{-1, 3}, [2]int64{1, 1})
+	p := a.Mul(a).Mul(ratPoly([2]int64{7, 1}, [2]int64{1, 1}))
+	q := a.Mul(ratPoly([2]int64{1, 10}, [2]int64{0, 1}, [2]int64{1, 1}))
+	cases := []struct {
+		p, q RatPoly
+		want string
+	}{
+		{RatPoly{}, RatPoly{}, "0"},
+		{p, q, "x - 1/3"},
+		{p, p.Der(), "x - 1/3"},
+		{q.Scale(big.NewRat(5, 1)), q, q.String()},
+		{a, ratPoly([2]int64{1, 1}, [2]int64{1, 1}), "1"},
+	}
+	for i, c := range cases {
+		if got := c.p.GCD(c.q); got.String() != c.want {
+			t.Errorf("case %d: GCD(%q) on %q == %q, want %q", i, c.q, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests conversion between Poly and RatPoly.
+func TestRatPolyConvert(t *testing.T) {
+	p := New(0.5, -0.25, 3)
+	r, err := p.Rat()
+	if err != nil {
+		t.Fatalf("Rat() on %q returned error %v", p, err)
+	}
+	if got, want := r.String(), "3x^2 - 1/4x + 1/2"; got != want {
+		t.Errorf("Rat() on %q == %q, want %q", p, got, want)
+	}
+	if got := r.Poly(); !equalCoeffs(got, p) {
+		t.Errorf("Poly() on %q == %q, want %q", r, got, p)
+	}
+	if _, err := New(1, math.Inf(1)).Rat(); err == nil {
+		t.Errorf("Rat() on infinite coefficient succeeded, want error")
+	}
+}
@@ -0,0 +1,50 @@
+package poly
+
+// Computes the generator polynomial of a Reed-Solomon code with nsym check
+// symbols over the field gf. The generator is the product of (x - alpha^i)
+// for i from fcr to fcr+nsym-1, where alpha is the primitive element of the
+// field and fcr is the first consecutive root, which is 0 or 1 for most
+// standard codes.
+// Panics if nsym is negative.
+func RSGenerator(gf *GF2m, nsym, fcr int) GF2mPoly {
+	if nsym < 0 {
+		panic("poly: negative number of check symbols")
+	}
+	g := NewGF2mPoly(gf, 1)
+	for i := 0; i < nsym; i++ {
+		// In characteristic 2, x - alpha^i is x + alpha^i.
+		g = g.Mul(NewGF2mPoly(gf, gf.Exp(fcr+i), 1))
+	}
+	return g
+}
+
+// Encodes a message polynomial systematically with a Reed-Solomon generator
+// polynomial g of degree n. The codeword is msg*x^n - r, where r is the
+// remainder of msg*x^n divided by g, so that the message appears unchanged in
+// the high order coefficients and the codeword is a multiple of g.
+func RSEncode(g, msg GF2mPoly) GF2mPoly {
+	n := g.Deg()
+	shifted := make([]uint16, n+len(msg.co()))
+	copy(shifted[n:], msg.co())
+	m := normalizedGF2m(msg.field(g), shifted)
+	return m.Add(m.Mod(g))
+}
+
+// Computes the Reed-Solomon check symbols of a message given as a slice of
+// symbols, with the first symbol the coefficient of the highest order term
+// as in most byte oriented codes. The returned slice holds the deg(g) check
+// symbols in the same order, to be appended to the message.
+// Panics if a symbol is not an element of the field of g.
+func RSCheckSymbols(g GF2mPoly, msg []uint16) []uint16 {
+	c := make([]uint16, len(msg))
+	for i, s := range msg {
+		c[len(msg)-1-i] = s
+	}
+	cw := RSEncode(g, NewGF2mPoly(g.gf, c...))
+	n := g.Deg()
+	check := make([]uint16, n)
+	for i := range check {
+		check[i] = cw.Coeff(n - 1 - i)
+	}
+	return check
+}
@@ -0,0 +1,50 @@
+package poly
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Tests Reed-Solomon generator polynomials.
+func TestRSGenerator(t *testing.T) {
+	gf, _ := NewGF2m(NewGF2(8, 4, 3, 2, 0))
+	g := RSGenerator(gf, 10, 0)
+	if g.Deg() != 10 {
+		t.Errorf("RSGenerator(10) has degree %d, want 10", g.Deg())
+	}
+	for i := 0; i < 10; i++ {
+		if v := g.Eval(gf.Exp(i)); v != 0 {
+			t.Errorf("generator at alpha^%d == %d, want 0", i, v)
+		}
+	}
+	// The QR code generator of degree 7 has coefficients alpha^0, 87, 229,
+	// 146, 149, 238, 102, 21, from the highest order term.
+	g = RSGenerator(gf, 7, 0)
+	exps := []int{0, 87, 229, 146, 149, 238, 102, 21}
+	for i, e := range exps {
+		if got, want := g.Coeff(7-i), gf.Exp(e); got != want {
+			t.Errorf("coefficient of x^%d == %d, want alpha^%d == %d", 7-i, got, e, want)
+		}
+	}
+}
+
+// Tests Reed-Solomon systematic encoding.
+func TestRSEncode(t *testing.T) {
+	gf, _ := NewGF2m(NewGF2(8, 4, 3, 2, 0))
+	g := RSGenerator(gf, 10, 0)
+	// The data codewords of "HELLO WORLD" in a version 1-M QR code.
+	msg := []uint16{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	got := RSCheckSymbols(g, msg)
+	want := []uint16{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("RSCheckSymbols() == %v, want %v", got, want)
+	}
+	m := NewGF2mPoly(gf, 1, 2, 3)
+	cw := RSEncode(g, m)
+	if !cw.Mod(g).isZero() {
+		t.Errorf("RSEncode(%q) == %q is not a multiple of the generator", m, cw)
+	}
+	if got := cw.Div(NewGF2mPoly(gf, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1)); got.String() != m.String() {
+		t.Errorf("message part of codeword == %q, want %q", got, m)
+	}
+}
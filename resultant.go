@@ -0,0 +1,89 @@
+package poly
+
+import "math"
+
+// Computes the resultant of two polynomials, which is the determinant of
+// their Sylvester matrix.
+// The resultant is zero exactly when p and q have a common root, or when
+// either is zero. It is computed with the Euclidean algorithm, using
+// Res(p, q) = (-1)^(mn) lead(q)^(m-k) Res(q, p mod q), where m, n and k are
+// the degrees of p, q and p mod q, which takes O(mn) operations rather than
+// the O((m+n)^3) of the determinant. No coefficient is treated as zero unless
+// it is exactly zero, so the resultant scales correctly however small the
+// coefficients or the distance between roots are. Rounding error means that
+// a common root usually gives a small resultant rather than exactly zero; use
+// ApproxGCD to test whether p and q nearly have a common factor.
+func Resultant(p, q Poly) float64 {
+	a, b := p, q
+	if a.isZero() || b.isZero() {
+		return 0
+	}
+	res := 1.0
+	for {
+		m, n := a.Deg(), b.Deg()
+		lead := b.Coeff(n)
+		if n == 0 {
+			return res * math.Pow(lead, float64(m))
+		}
+		r := a.Mod(b)
+		if r.isZero() {
+			return 0
+		}
+		k := r.Deg()
+		res *= math.Pow(lead, float64(m-k))
+		if m%2 == 1 && n%2 == 1 {
+			res = -res
+		}
+		a, b = b, r
+	}
+}
+
+// Returns the Sylvester matrix of two polynomials of degrees m and n, as a
+// slice of m+n rows.
+// The first n rows hold shifted copies of the coefficients of p and the last
+// m rows hold shifted copies of those of q, each with the highest order
+// coefficient first. Its determinant is the resultant of p and q, and the
+// dimension of its null space is the degree of their greatest common divisor,
+// so its smallest singular values measure how close p and q are to having a
+// common factor.
+func Sylvester(p, q Poly) [][]float64 {
+	pco, qco := p.co(), q.co()
+	m, n := p.Deg(), q.Deg()
+	s := make([][]float64, m+n)
+	for i := range s {
+		s[i] = make([]float64, m+n)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j <= m; j++ {
+			s[i][i+j] = pco[m-j]
+		}
+	}
+	for i := 0; i < m; i++ {
+		for j := 0; j <= n; j++ {
+			s[n+i][i+j] = qco[n-j]
+		}
+	}
+	return s
+}
+
+// Computes the discriminant of a polynomial of degree n with leading
+// coefficient a, which is (-1)^(n(n-1)/2) Res(p, p') / a.
+// The discriminant is zero exactly when p has a repeated root. For a
+// quadratic ax^2 + bx + c it is b^2 - 4ac, and for a real polynomial its sign
+// is (-1)^k, where k is the number of pairs of complex conjugate roots, so it
+// distinguishes, for example, a cubic with three distinct real roots from one
+// with a single real root. As for Resultant, nothing is rounded to zero, so
+// the discriminant of a polynomial with small coefficients or closely spaced
+// roots is small but has the correct sign, and a repeated root usually gives
+// a small value rather than exactly zero. Returns 0 for constant polynomials.
+func (p Poly) Discriminant() float64 {
+	n := p.Deg()
+	if n < 1 {
+		return 0
+	}
+	d := Resultant(p, p.Der()) / p.Coeff(n)
+	if n*(n-1)/2%2 == 1 {
+		d = -d
+	}
+	return d
+}
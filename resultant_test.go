@@ -0,0 +1,144 @@
+package poly
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// Reports whether got is within tol of want, relative to |want| unless want
+// is zero.
+func closeTo(got, want, tol float64) bool {
+	if want == 0 {
+		return math.Abs(got) <= tol
+	}
+	return math.Abs(got-want) <= tol*math.Abs(want)
+}
+
+// Tests resultants of polynomials.
+func TestResultant(t *testing.T) {
+	cases := []struct {
+		p, q Poly
+		want float64
+	}{
+		{New(-2, 1), New(-5, 1), -3},
+		{New(-5, 1), New(-2, 1), 3},
+		{Poly{}, New(1, 1), 0},
+		{New(1, 1), Poly{}, 0},
+		{New(3), New(4), 1},
+		{New(3), New(1, 2, 1), 9},
+		{New(1, 2, 1), New(3), 9},
+		// Common root at 1.
+		{FromRoots(1, 2), FromRoots(1, 3), 0},
+		{FromRoots(1, 2, 3), FromRoots(1.5, 4), 2.25},
+		{New(-2, 0, 1), New(-3, 0, 1), 1},
+		// Leading coefficients contribute lead(p)^n lead(q)^m.
+		{New(-2, 2), New(-15, 0, 3), -48},
+		{New(1, 0, 1), New(0, 1, 0, 1), 0},
+		// Res(x^2 + 1, x^2 - 1) = product over +-i of (i^2 - 1) = 4.
+		{New(1, 0, 1), New(-1, 0, 1), 4},
+		// Small resultants are not rounded to zero.
+		{New(0, 0, 1), New(-1e-5, 1), 1e-10},
+		{New(1e-12, 2e-12, 3e-12), New(5, 1), 6.6e-11},
+		{FromRoots(1, 2), FromRoots(1+1e-9, 3), -2e-9},
+		{FromRoots(1, 2).Scale(1e-50), FromRoots(3, 4).Scale(1e-50), 12e-200},
+	}
+	for i, c := range cases {
+		if got := Resultant(c.p, c.q); !closeTo(got, c.want, 0.00001) {
+			t.Errorf("case %d: Resultant(%q, %q) == %v, want %v", i, c.p, c.q, got, c.want)
+		}
+	}
+}
+
+// Tests that Resultant agrees with the exact integer resultant.
+func TestResultantInt(t *testing.T) {
+	cases := []struct{ p, q []int64 }{
+		{[]int64{1, -3, 0, 2}, []int64{-4, 1, 5}},
+		{[]int64{7, 0, -1, 0, 1}, []int64{2, 3, 0, -1}},
+		{[]int64{-1, 1, 1, 1, 1, 1}, []int64{1, 0, 2}},
+	}
+	for i, c := range cases {
+		p, q := NewInt(c.p...), NewInt(c.q...)
+		want, _ := new(big.Float).SetInt(p.Resultant(q)).Float64()
+		if got := Resultant(p.Poly(), q.Poly()); math.Abs(got-want) > 1e-9*math.Max(1, math.Abs(want)) {
+			t.Errorf("case %d: Resultant(%q, %q) == %v, want %v", i, p, q, got, want)
+		}
+	}
+}
+
+// Tests discriminants of polynomials.
+func TestDiscriminant(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		want float64
+	}{
+		{Poly{}, 0},
+		{New(5), 0},
+		{New(3, 2), 1},
+		{New(1, 2, 3), -8},
+		{New(-4, 0, 1), 16},
+		{New(1, 2, 1), 0},
+		// -4p^3 - 27q^2 for x^3 + px + q.
+		{New(1, -3, 0, 1), 108 - 27},
+		{New(2, 1, 0, 1), -4 - 108},
+		{FromRoots(1, 2, 3), 4},
+		{FromRoots(1, 1, 3), 0},
+		{New(1, 0, 0, 0, 1), 256},
+		{FromRoots(-2, -1, 1, 2).Scale(2), 64 * 5184},
+		// Small discriminants are not rounded to zero.
+		{New(-1e-10, 0, 1), 4e-10},
+		{New(1e-10, 0, 1), -4e-10},
+		{FromRoots(0.001, 0.002, 0.003), 4e-18},
+		// Roots 0.001 and 0.002 +- 0.001i.
+		{FromRoots(0.001).Mul(New(5e-6, -0.004, 1)), -1.6e-17},
+	}
+	for i, c := range cases {
+		if got := c.p.Discriminant(); !closeTo(got, c.want, 0.00001) {
+			t.Errorf("case %d: %q.Discriminant() == %v, want %v", i, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests construction of Sylvester matrices.
+func TestSylvester(t *testing.T) {
+	cases := []struct {
+		p, q Poly
+		want [][]float64
+	}{
+		{New(1, 2, 3), New(4, 5), [][]float64{
+			{3, 2, 1},
+			{5, 4, 0},
+			{0, 5, 4},
+		}},
+		{New(-1, 0, 1), New(2, 0, 0, 1), [][]float64{
+			{1, 0, -1, 0, 0},
+			{0, 1, 0, -1, 0},
+			{0, 0, 1, 0, -1},
+			{1, 0, 0, 2, 0},
+			{0, 1, 0, 0, 2},
+		}},
+		{New(3), New(1, 1), [][]float64{{3}}},
+		{New(3), New(7), [][]float64{}},
+	}
+	for i, c := range cases {
+		if got := Sylvester(c.p, c.q); !compareMatrix(got, c.want) {
+			t.Errorf("case %d: Sylvester(%q, %q) == %v, want %v", i, c.p, c.q, got, c.want)
+		}
+	}
+}
+
+// Tests that the determinant of the Sylvester matrix is the resultant.
+func TestSylvesterDet(t *testing.T) {
+	cases := []struct{ p, q Poly }{
+		{New(1, -3, 0, 2), New(-4, 1, 5)},
+		{FromRoots(1, 2, 3), FromRoots(1.5, 4)},
+		{FromRoots(1, 2), FromRoots(1, 3)},
+		{New(2, 0, 1, 1), New(1, 0, 0, 0, -1)},
+	}
+	for i, c := range cases {
+		det := ConstMatrix(Sylvester(c.p, c.q)).Det().Coeff(0)
+		if want := Resultant(c.p, c.q); math.Abs(det-want) > 0.00001*math.Max(1, math.Abs(want)) {
+			t.Errorf("case %d: det(Sylvester(%q, %q)) == %v, want %v", i, c.p, c.q, det, want)
+		}
+	}
+}
@@ -0,0 +1,1231 @@
+package poly
+
+import (
+	"math"
+	"math/big"
+	"math/cmplx"
+	"sort"
+)
+
+// Interval is a closed interval [Lo, Hi] of the real line.
+type Interval struct {
+	Lo, Hi float64
+}
+
+// Computes the distinct real roots of a polynomial, in ascending order.
+// The roots are isolated as by IsolateRoots, so a root of multiplicity k is
+// reported once. Each root is then refined with Newton's method, safeguarded
+// by bisection on the exact sign of the polynomial, until its bracket is a
+// few units in the last place wide or the polynomial is exactly zero.
+// The roots are counted exactly for the coefficients as given, so distinct
+// roots are reported separately however close together they are, with one
+// exception. Rounding error splits a root of multiplicity m into a cluster of
+// m roots, which may be real or complex, so a cluster whose radius relative
+// to its magnitude is at most about 1e-13^(1/m) is reported as one repeated
+// root. For a double root this is a relative radius of about 3e-7.
+// Constant polynomials, including zero, and polynomials with non-finite
+// coefficients have no reported roots.
+// The cost is usually a small multiple of that of Roots. Only when the roots
+// found by Roots cannot be certified, because they are clustered or too
+// ill-conditioned, is the Sturm sequence computed in exact arithmetic, which
+// takes time growing faster than the cube of the degree: tens of
+// milliseconds at degree 50 and seconds at degree 200.
+func (p Poly) RealRoots() []float64 {
+	if p.Deg() < 1 || !p.isFinite() {
+		return nil
+	}
+	z := p.Roots()
+	if ivs, sign, ok := p.isolateFast(z); ok {
+		df := p.Der()
+		var roots []float64
+		for _, iv := range ivs {
+			roots = append(roots, bracketRoot(p, df, sign, iv.Lo, iv.Hi, sign(iv.Lo)))
+		}
+		return roots
+	}
+	f, centers := p.mergeClusters(z)
+	s := newSturm(f)
+	df := f.Der()
+	b := f.isolationBound()
+	var roots []float64
+	for _, iv := range s.isolate(-b, b, false, false) {
+		roots = append(roots, refineRoot(f, df, s, centers, iv.Lo, iv.Hi))
+	}
+	return roots
+}
+
+// Isolates the distinct real roots of a polynomial in disjoint intervals, in
+// ascending order.
+// The complex roots are first found by Roots. If each can be enclosed in a
+// disk that is disjoint from the others, and those of the real roots are
+// confirmed by an exact change of sign, the intervals are those disks'
+// diameters. Otherwise clusters of roots split from a repeated root by
+// rounding error are merged, as described for RealRoots, and an interval
+// bounding every root is bisected, using a Sturm sequence computed in exact
+// arithmetic to count the roots in each part, until each part contains
+// exactly one root. Each interval contains exactly one root, counting a
+// merged cluster as one root located within it, unless roots closer together
+// than the resolution of a float64 share an interval. The endpoints of an
+// interval are never roots unless the interval is a single point, and
+// adjacent intervals may share an endpoint. Constant polynomials, including
+// zero, and polynomials with non-finite coefficients have no reported roots.
+// The cost is as described for RealRoots.
+func (p Poly) IsolateRoots() []Interval {
+	if p.Deg() < 1 || !p.isFinite() {
+		return nil
+	}
+	z := p.Roots()
+	if ivs, _, ok := p.isolateFast(z); ok {
+		return ivs
+	}
+	f, _ := p.mergeClusters(z)
+	b := f.isolationBound()
+	return newSturm(f).isolate(-b, b, false, false)
+}
+
+// Computes the distinct real roots of a polynomial in the closed interval
+// [a, b], in ascending order, found and refined as by RealRoots.
+// Either bound may be infinite.
+// Panics if a > b or either bound is NaN.
+func (p Poly) RootsIn(a, b float64) []float64 {
+	if !(a <= b) {
+		panic("poly: invalid interval")
+	}
+	if p.Deg() < 1 || !p.isFinite() {
+		return nil
+	}
+	z := p.Roots()
+	if ivs, sign, ok := p.isolateFast(z); ok {
+		df := p.Der()
+		var roots []float64
+		for _, iv := range clipRoots(ivs, sign, a, b) {
+			roots = append(roots, bracketRoot(p, df, sign, iv.Lo, iv.Hi, sign(iv.Lo)))
+		}
+		return roots
+	}
+	f, centers := p.mergeClusters(z)
+	s := newSturm(f)
+	df := f.Der()
+	lo, hi, aRoot, bRoot := p.clampInterval(f, s, a, b)
+	var roots []float64
+	if aRoot {
+		roots = append(roots, a)
+	}
+	if lo >= hi {
+		return roots
+	}
+	for _, iv := range s.isolate(lo, hi, aRoot, bRoot) {
+		roots = append(roots, refineRoot(f, df, s, centers, iv.Lo, iv.Hi))
+	}
+	return roots
+}
+
+// Counts the distinct real roots of a polynomial in the closed interval
+// [a, b], isolated as by IsolateRoots but without refining them. Roots are
+// counted as by RealRoots. Either bound may be infinite.
+// Panics if a > b or either bound is NaN.
+func (p Poly) CountRootsIn(a, b float64) int {
+	if !(a <= b) {
+		panic("poly: invalid interval")
+	}
+	if p.Deg() < 1 || !p.isFinite() {
+		return 0
+	}
+	z := p.Roots()
+	if ivs, sign, ok := p.isolateFast(z); ok {
+		return len(clipRoots(ivs, sign, a, b))
+	}
+	f, _ := p.mergeClusters(z)
+	s := newSturm(f)
+	lo, hi, aRoot, bRoot := p.clampInterval(f, s, a, b)
+	n := 0
+	if aRoot {
+		n++
+	}
+	if lo >= hi {
+		return n
+	}
+	return n + s.changesAfter(lo, aRoot) - s.changesAt(hi, bRoot)
+}
+
+// Clamps the interval [a, b] to the interval bounding the roots of f, the
+// polynomial p with its clusters merged, whose Sturm sequence is s, so that
+// bisection never meets infinite or overflowing bounds. Reports whether a
+// and b are roots of p; an end that was clamped is not a root.
+func (p Poly) clampInterval(f Poly, s sturm, a, b float64) (lo, hi float64, aRoot, bRoot bool) {
+	bound := f.isolationBound()
+	lo, hi = math.Max(a, -bound), math.Min(b, bound)
+	aRoot = a == lo && p.isRoot(s, a)
+	bRoot = b == hi && p.isRoot(s, b)
+	return lo, hi, aRoot, bRoot
+}
+
+// Reports whether every coefficient of the polynomial is finite.
+func (p Poly) isFinite() bool {
+	for _, c := range p.co() {
+		if math.IsNaN(c) || math.IsInf(c, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// Reports whether x is a root of p, whose clusters merged have the Sturm
+// sequence s. Both are checked, since merging moves the roots of a cluster.
+func (p Poly) isRoot(s sturm, x float64) bool {
+	return p.Eval(x) == 0 || s[0].signAt(x) == 0
+}
+
+// Isolates the distinct real roots of p, given its roots z found by Roots,
+// without computing a Sturm sequence. Each root is enclosed in a disk by
+// inclusionRadii. If the disks are disjoint, each contains exactly one root,
+// and one meeting the real axis contains a real root provided its reflection
+// meets no other disk. The diameters of those disks are returned as
+// intervals, along with the exact sign of p, which is checked to change over
+// each interval. Reports false, so that the caller must fall back to the
+// exact Sturm sequence, if any of this fails or if z has clusters to merge.
+func (p Poly) isolateFast(z []complex128) (ivs []Interval, sign func(float64) int, ok bool) {
+	if len(p.clusters(z)) > 0 {
+		return nil, nil, false
+	}
+	r := p.inclusionRadii(z)
+	apart := func(c complex128, rc float64, skip int) bool {
+		for j, zj := range z {
+			if j != skip && !(cmplx.Abs(c-zj) > rc+r[j]) {
+				return false
+			}
+		}
+		return true
+	}
+	sign = p.scaledInt().signAt
+	for i, zi := range z {
+		if !apart(zi, r[i], i) {
+			return nil, nil, false
+		}
+		if !(math.Abs(imag(zi)) <= r[i]) {
+			continue
+		}
+		if imag(zi) != 0 && !apart(cmplx.Conj(zi), r[i], i) {
+			return nil, nil, false
+		}
+		lo, hi := real(zi)-r[i], real(zi)+r[i]
+		slo, shi := sign(lo), sign(hi)
+		switch {
+		case lo == hi && slo == 0:
+		case slo == 0 || shi == 0 || slo == shi:
+			return nil, nil, false
+		}
+		ivs = append(ivs, Interval{lo, hi})
+	}
+	sort.Slice(ivs, func(i, j int) bool { return ivs[i].Lo < ivs[j].Lo })
+	return ivs, sign, true
+}
+
+// Returns the radii of disks about the approximate roots z of p, one for each
+// root, that are known to contain all the roots of p. They are Gerschgorin
+// disks of a matrix whose eigenvalues are the roots: the radius about z_i is
+// n|w_i|, where w_i = p(z_i) / (a_n prod_{j != i} (z_i - z_j)), with the
+// rounding error in evaluating p(z_i) added to its magnitude and a factor of
+// two for the rest. The disks need not be disjoint. A radius that cannot be
+// computed is infinite.
+func (p Poly) inclusionRadii(z []complex128) []float64 {
+	pco := p.co()
+	n := len(z)
+	r := make([]float64, n)
+	for i, zi := range z {
+		// The error in evaluating p(z_i) by Horner's method is bounded by
+		// a small multiple of n*epsilon times the sum of |a_k| |z_i|^k.
+		v := complex(pco[n], 0)
+		mu := math.Abs(pco[n])
+		az := cmplx.Abs(zi)
+		for k := n - 1; k >= 0; k-- {
+			v = v*zi + complex(pco[k], 0)
+			mu = mu*az + math.Abs(pco[k])
+		}
+		// The denominator is accumulated as a logarithm, since the product
+		// of the distances may overflow or underflow.
+		logd := math.Log(math.Abs(pco[n]))
+		for j, zj := range z {
+			if j != i {
+				logd += math.Log(cmplx.Abs(zi - zj))
+			}
+		}
+		num := 2 * float64(n) * (cmplx.Abs(v) + 8*float64(n)*epsilon*mu)
+		r[i] = math.Exp(math.Log(num) - logd)
+		if math.IsNaN(r[i]) {
+			r[i] = math.Inf(1)
+		}
+	}
+	return r
+}
+
+// Returns the intervals among ivs, which isolate the real roots of a
+// polynomial with exact sign function sign, whose roots lie in [a, b],
+// narrowed to lie within it. An interval whose root is at a or b is replaced
+// by that single point.
+func clipRoots(ivs []Interval, sign func(float64) int, a, b float64) []Interval {
+	var out []Interval
+	for _, iv := range ivs {
+		lo, hi := iv.Lo, iv.Hi
+		if lo == hi {
+			if a <= lo && lo <= b {
+				out = append(out, iv)
+			}
+			continue
+		}
+		if hi <= a || lo >= b {
+			continue
+		}
+		slo := sign(lo)
+		if lo < a {
+			switch sa := sign(a); {
+			case sa == 0:
+				out = append(out, Interval{a, a})
+				continue
+			case sa != slo:
+				continue
+			}
+			lo = a
+		}
+		if hi > b {
+			switch sb := sign(b); {
+			case sb == 0:
+				out = append(out, Interval{b, b})
+				continue
+			case sb == slo:
+				continue
+			}
+			hi = b
+		}
+		out = append(out, Interval{lo, hi})
+	}
+	return out
+}
+
+// Isolates the roots of the first member of the sequence in (lo, hi].
+// The flags loRoot and hiRoot record that the endpoints are known to be roots,
+// which rounding error in merging clusters might otherwise hide. An interval
+// containing a root at hi is the single point hi, and no interval has a root
+// at lo as an endpoint.
+func (s sturm) isolate(lo, hi float64, loRoot, hiRoot bool) []Interval {
+	var ivs []Interval
+	var bisect func(l, h float64, vl, vh int)
+	bisect = func(l, h float64, vl, vh int) {
+		n := vl - vh
+		if n <= 0 {
+			return
+		}
+		if n == 1 && h == hi && hiRoot {
+			ivs = append(ivs, Interval{h, h})
+			return
+		}
+		mid, ok := s.splitPoint(l, h)
+		if (n == 1 && !(l == lo && loRoot)) || !ok {
+			ivs = append(ivs, Interval{l, h})
+			return
+		}
+		vmid := s.signChanges(mid)
+		bisect(l, mid, vl, vmid)
+		bisect(mid, h, vmid, vh)
+	}
+	bisect(lo, hi, s.changesAfter(lo, loRoot), s.changesAt(hi, hiRoot))
+	return ivs
+}
+
+// Returns a point near the middle of (lo, hi) that is not a root of the first
+// member of the sequence, so that bisection never places a root on the
+// boundary between intervals. Reports false if the interval is too narrow to
+// split.
+func (s sturm) splitPoint(lo, hi float64) (float64, bool) {
+	// The ends are weighted separately, since hi-lo may overflow.
+	for _, t := range []float64{0.5, 0.45, 0.55, 0.4, 0.6} {
+		mid := lo*(1-t) + hi*t
+		if mid <= lo || mid >= hi {
+			return 0, false
+		}
+		if s[0].signAt(mid) != 0 {
+			return mid, true
+		}
+	}
+	return lo/2 + hi/2, true
+}
+
+// Computes all complex roots of a polynomial, repeated according to their
+// multiplicity, using the Aberth-Ehrlich iteration.
+// Roots at zero are split off exactly. The remaining roots are started on a
+// circle whose radius is the geometric mean of the root magnitudes, and are
+// iterated simultaneously until every Newton correction is within a few units
+// in the last place, or an iteration limit is reached. Multiple roots
+// converge only to about 1/m of the working precision. Roots whose imaginary
+// part is negligible are returned as real, and the result is sorted by real
+// then imaginary part. Constant polynomials, including zero, have no reported
+// roots.
+func (p Poly) Roots() []complex128 {
+	n := p.Deg()
+	if n < 1 {
+		return nil
+	}
+	k, q := p.splitZeroRoots()
+	roots := make([]complex128, k, n)
+	if q.Deg() > 0 {
+		roots = append(roots, q.aberth()...)
+	}
+	sortComplex(roots)
+	return roots
+}
+
+// Splits a nonzero polynomial into x^k * q, where q has a nonzero constant
+// term. Returns k and q.
+func (p Poly) splitZeroRoots() (int, Poly) {
+	pco := p.co()
+	k := 0
+	for pco[k] == 0 {
+		k++
+	}
+	return k, normalized(pco[k:])
+}
+
+// Sorts complex numbers by real then imaginary part.
+func sortComplex(z []complex128) {
+	sort.Slice(z, func(i, j int) bool {
+		if real(z[i]) != real(z[j]) {
+			return real(z[i]) < real(z[j])
+		}
+		return imag(z[i]) < imag(z[j])
+	})
+}
+
+// Sets the imaginary part of each complex number that is negligible relative
+// to its magnitude to zero.
+func snapReal(z []complex128) {
+	for k, zk := range z {
+		if math.Abs(imag(zk)) <= 1e-10*cmplx.Abs(zk) {
+			z[k] = complex(real(zk), 0)
+		}
+	}
+}
+
+// Applies the Aberth-Ehrlich iteration to a polynomial of degree at least 1
+// with a nonzero constant term.
+func (p Poly) aberth() []complex128 {
+	n := p.Deg()
+	dp := p.Der()
+	r := math.Pow(math.Abs(p.Coeff(0)/p.Coeff(n)), 1/float64(n))
+	z := make([]complex128, n)
+	for k := range z {
+		// The offset avoids starting symmetrically about the real axis.
+		theta := 2*math.Pi*float64(k)/float64(n) + 0.4
+		z[k] = cmplx.Rect(r, theta)
+	}
+	done := make([]bool, n)
+	for iter := 0; iter < 500; iter++ {
+		converged := true
+		for k := range z {
+			if done[k] {
+				continue
+			}
+			pz := p.EvalC(z[k])
+			if pz == 0 {
+				done[k] = true
+				continue
+			}
+			w := pz / dp.EvalC(z[k])
+			var s complex128
+			for j := range z {
+				if j != k {
+					s += 1 / (z[k] - z[j])
+				}
+			}
+			d := w / (1 - w*s)
+			if cmplx.IsNaN(d) || cmplx.IsInf(d) {
+				// Perturb away from a critical point.
+				d = complex(r*epsilon*1e3, r*epsilon*1e3)
+			}
+			z[k] -= d
+			if cmplx.Abs(d) <= 4*epsilon*cmplx.Abs(z[k]) {
+				done[k] = true
+			} else {
+				converged = false
+			}
+		}
+		if converged {
+			break
+		}
+	}
+	snapReal(z)
+	return z
+}
+
+// Refines approximate roots of a polynomial, such as those from another
+// root finder or from a previous, slightly different polynomial.
+// Each approximation is first improved with Halley's method on the
+// polynomial deflated by the roots already polished, which steers nearby
+// approximations towards distinct roots, and then polished with Halley's
+// method on the original polynomial so that deflation error does not
+// accumulate. The deflation is by synthetic division. Returns the polished
+// roots in the order of approx, along with the residual |p(z)| of each.
+func (p Poly) PolishRoots(approx []complex128) (roots []complex128, residuals []float64) {
+	pc := complexCoeffs(p)
+	w := complexCoeffs(p)
+	roots = make([]complex128, len(approx))
+	residuals = make([]float64, len(approx))
+	for i, z := range approx {
+		if len(w) > 1 {
+			z = halley(w, z)
+		}
+		z = halley(pc, z)
+		if len(w) > 1 {
+			w = deflate(w, z)
+		}
+		roots[i] = z
+		residuals[i] = cmplx.Abs(p.EvalC(z))
+	}
+	return roots, residuals
+}
+
+// Returns the coefficients of a polynomial as complex numbers.
+func complexCoeffs(p Poly) []complex128 {
+	pco := p.co()
+	c := make([]complex128, len(pco))
+	for i, pc := range pco {
+		c[i] = complex(pc, 0)
+	}
+	return c
+}
+
+// Applies Halley's method to the polynomial with coefficients c, starting
+// from z, until the step is negligible or an iteration limit is reached.
+func halley(c []complex128, z complex128) complex128 {
+	for iter := 0; iter < 50; iter++ {
+		var f, df, d2f complex128
+		for i := len(c) - 1; i >= 0; i-- {
+			d2f = d2f*z + 2*df
+			df = df*z + f
+			f = f*z + c[i]
+		}
+		if f == 0 {
+			break
+		}
+		d := 2 * f * df / (2*df*df - f*d2f)
+		if cmplx.IsNaN(d) || cmplx.IsInf(d) {
+			break
+		}
+		z -= d
+		if cmplx.Abs(d) <= 4*epsilon*cmplx.Abs(z) {
+			break
+		}
+	}
+	return z
+}
+
+// Divides the polynomial with coefficients c by (x - z) using synthetic
+// division, discarding the remainder.
+func deflate(c []complex128, z complex128) []complex128 {
+	n := len(c) - 1
+	q := make([]complex128, n)
+	var b complex128
+	for i := n; i >= 1; i-- {
+		b = b*z + c[i]
+		q[i-1] = b
+	}
+	return q
+}
+
+// Returns the number of sign changes in the sequence of coefficients,
+// ignoring zero coefficients.
+func (p Poly) SignChanges() int {
+	n := 0
+	var last float64
+	for _, c := range p.co() {
+		if c == 0 {
+			continue
+		}
+		if last != 0 && (c < 0) != (last < 0) {
+			n++
+		}
+		last = c
+	}
+	return n
+}
+
+// Computes upper bounds on the number of positive and negative real roots,
+// counted with multiplicity, using Descartes' rule of signs.
+// The number of positive roots is at most the number of sign changes in the
+// coefficients of p(x), and differs from it by an even number. The same holds
+// for negative roots and p(-x). Roots at zero are not counted by either bound.
+func (p Poly) DescartesBounds() (pos, neg int) {
+	return p.SignChanges(), p.ScaleVar(-1).SignChanges()
+}
+
+// Root is a complex root of a polynomial together with its multiplicity.
+type Root struct {
+	Z    complex128
+	Mult int
+}
+
+// Computes the distinct complex roots of a polynomial along with their
+// multiplicities, sorted by real then imaginary part.
+// The polynomial is first split into square-free factors f_1, f_2, ..., such
+// that p is a constant times f_1 * f_2^2 * f_3^3 ..., using Yun's algorithm.
+// The roots of each f_k are then found as by Roots and have multiplicity k.
+// Because each factor has only simple roots, repeated roots are found to full
+// precision instead of as a noisy cluster. Roots that agree to about seven
+// significant digits are treated as one repeated root. If rounding error keeps
+// the factors from multiplying back to p, every root is reported with
+// multiplicity 1, as a cluster for a repeated root. The multiplicities always
+// sum to the degree of p. Constant polynomials, including zero, have no
+// reported roots.
+func (p Poly) RootsWithMultiplicity() []Root {
+	if p.Deg() < 1 {
+		return nil
+	}
+	var roots []Root
+	for k, f := range p.yun() {
+		for _, z := range f.Roots() {
+			roots = append(roots, Root{z, k + 1})
+		}
+	}
+	sort.Slice(roots, func(i, j int) bool {
+		zi, zj := roots[i].Z, roots[j].Z
+		if real(zi) != real(zj) {
+			return real(zi) < real(zj)
+		}
+		return imag(zi) < imag(zj)
+	})
+	return roots
+}
+
+// Factors a polynomial over the reals into linear and irreducible quadratic
+// factors, as needed for partial fractions or for splitting a filter into
+// first and second order sections.
+// Returns the leading coefficient and monic factors, x - r for each real
+// root r and x^2 - 2Re(z)x + |z|^2 for each pair of complex conjugate roots z
+// and conj(z), repeated according to multiplicity, such that p is the
+// leading coefficient times their product. The roots are found as by
+// RootsWithMultiplicity, and the factors are ordered as their roots, with
+// each quadratic placed by its root with positive imaginary part. Each
+// quadratic is formed from the two computed roots of its pair, rather than
+// from one of them and its conjugate, so that the factors multiply back to p
+// even where repeated roots are found as clusters, though then only to about
+// half the working precision. Constant polynomials, including zero, have no
+// factors.
+func (p Poly) FactorReal() (lead float64, factors []Poly) {
+	lead = p.Coeff(p.Deg())
+	if p.Deg() < 1 {
+		return lead, nil
+	}
+	type factor struct {
+		z    complex128
+		f    Poly
+		mult int
+	}
+	var fs []factor
+	for k, f := range p.yun() {
+		reals, pairs := conjugatePairs(f.Roots())
+		for _, r := range reals {
+			fs = append(fs, factor{complex(r, 0), New(-r, 1), k + 1})
+		}
+		for _, zw := range pairs {
+			z, w := zw[0], zw[1]
+			fs = append(fs, factor{z, New(real(z*w), -real(z+w), 1), k + 1})
+		}
+	}
+	sort.SliceStable(fs, func(i, j int) bool {
+		zi, zj := fs[i].z, fs[j].z
+		if real(zi) != real(zj) {
+			return real(zi) < real(zj)
+		}
+		return imag(zi) < imag(zj)
+	})
+	for _, f := range fs {
+		for i := 0; i < f.mult; i++ {
+			factors = append(factors, f.f)
+		}
+	}
+	return lead, factors
+}
+
+// Splits the roots of a real polynomial into real roots and pairs of complex
+// conjugate roots, with the root of each pair in the upper half plane first.
+// Rounding error can leave the roots of a pair with imaginary parts of the
+// same sign, so roots are paired greedily, closest pairs first, by the
+// distance between a root in the upper half plane and the conjugate of one in
+// the lower half plane. Roots left without a partner are taken as real.
+func conjugatePairs(z []complex128) (reals []float64, pairs [][2]complex128) {
+	var upper, lower []complex128
+	for _, zk := range z {
+		switch {
+		case imag(zk) > 0:
+			upper = append(upper, zk)
+		case imag(zk) < 0:
+			lower = append(lower, zk)
+		default:
+			reals = append(reals, real(zk))
+		}
+	}
+	type candidate struct {
+		i, j int
+		d    float64
+	}
+	var cands []candidate
+	for i, u := range upper {
+		for j, l := range lower {
+			cands = append(cands, candidate{i, j, cmplx.Abs(u - cmplx.Conj(l))})
+		}
+	}
+	sort.Slice(cands, func(a, b int) bool { return cands[a].d < cands[b].d })
+	usedU, usedL := make([]bool, len(upper)), make([]bool, len(lower))
+	for _, c := range cands {
+		if !usedU[c.i] && !usedL[c.j] {
+			usedU[c.i], usedL[c.j] = true, true
+			pairs = append(pairs, [2]complex128{upper[c.i], lower[c.j]})
+		}
+	}
+	for i, u := range upper {
+		if !usedU[i] {
+			reals = append(reals, real(u))
+		}
+	}
+	for j, l := range lower {
+		if !usedL[j] {
+			reals = append(reals, real(l))
+		}
+	}
+	return reals, pairs
+}
+
+// Computes the square-free factorization of a polynomial using Yun's
+// algorithm.
+// Returns monic polynomials f_1, f_2, ..., f_m, each with only simple roots
+// and no two sharing a root, such that p is its leading coefficient times
+// f_1 * f_2^2 * ... * f_m^m. The roots of f_k are exactly the roots of p of
+// multiplicity k, and factors equal to 1 are included to keep the others at
+// their positions. Roots that agree to about seven significant digits are
+// merged. If rounding error keeps the factors from multiplying back to p, the
+// only factor is p made monic. Constant polynomials, including zero, have no
+// factors.
+func (p Poly) SquareFree() []Poly {
+	if p.Deg() < 1 {
+		return nil
+	}
+	return p.yun()
+}
+
+// Computes the square-free factorization of a non-constant polynomial using
+// Yun's algorithm. Returns monic square-free, pairwise coprime polynomials
+// f_1, f_2, ..., f_m such that p is a constant times f_1 * f_2^2 * ... * f_m^m.
+// Factors equal to 1 are included to keep their positions.
+// Roots at zero are split off exactly, and the variable is scaled so that the
+// remaining roots have a geometric mean magnitude of 1, which makes the
+// tolerance of the GCDs independent of the scale of the roots. The GCDs use
+// squareFreeTol, so roots closer together than about its square root,
+// relative to their magnitude, are merged. If rounding error keeps the
+// factors from multiplying back to p, every root is given multiplicity 1.
+func (p Poly) yun() []Poly {
+	k, q := p.splitZeroRoots()
+	var factors []Poly
+	if n := q.Deg(); n > 0 {
+		s := math.Pow(math.Abs(q.Coeff(0)/q.Coeff(n)), 1/float64(n))
+		if s == 0 || math.IsInf(s, 0) || math.IsNaN(s) {
+			s = 1
+		}
+		qs := q.ScaleVar(s)
+		factors = qs.yunScaled()
+		if !qs.isSquareFreeFactorization(factors) {
+			factors = []Poly{qs.monic()}
+		}
+		for i, f := range factors {
+			factors[i] = f.ScaleVar(1 / s).monic()
+		}
+	}
+	if k > 0 {
+		for len(factors) < k {
+			factors = append(factors, New(1))
+		}
+		factors[k-1] = factors[k-1].Mul(New(0, 1))
+	}
+	return factors
+}
+
+// Applies Yun's algorithm to a non-constant polynomial whose roots have
+// magnitudes near 1. If rounding error keeps the iteration from accounting
+// for every root, the remainder is given multiplicity 1.
+func (p Poly) yunScaled() []Poly {
+	dp := p.Der()
+	a := p.gcd(dp, squareFreeTol)
+	b := p.Div(a)
+	c := dp.Div(a)
+	d := c.Sub(b.Der())
+	var factors []Poly
+	for b.Deg() > 0 {
+		if len(factors) == p.Deg() {
+			factors[0] = factors[0].Mul(b.monic())
+			break
+		}
+		a = b.gcd(d, squareFreeTol)
+		if a.isZero() {
+			a = b.monic()
+		}
+		factors = append(factors, a)
+		b = b.Div(a)
+		c = d.Div(a)
+		d = c.Sub(b.Der())
+	}
+	return factors
+}
+
+// Relative error below which the product of a square-free factorization is
+// accepted as equal to the factored polynomial.
+const factorizationTol = 1e-8
+
+// Reports whether f_1, f_2, ..., f_m is a square-free factorization of p:
+// the sum of k times the degree of f_k is the degree of p, and the leading
+// coefficient of p times f_1 * f_2^2 * ... * f_m^m agrees with p to within
+// factorizationTol relative to its largest coefficient.
+func (p Poly) isSquareFreeFactorization(factors []Poly) bool {
+	n := 0
+	prod := New(p.Coeff(p.Deg()))
+	for k, f := range factors {
+		n += (k + 1) * f.Deg()
+		prod = prod.Mul(f.Pow(k + 1))
+	}
+	return n == p.Deg() && prod.Sub(p).maxAbs() <= factorizationTol*p.maxAbs()
+}
+
+// Relative tolerance used by the GCDs when finding repeated roots. It is much
+// tighter than the default for GCD, since a spurious common factor of p and p'
+// would merge distinct roots.
+const squareFreeTol = 1e-13
+
+// Returns a polynomial with the same distinct real roots as a non-constant
+// polynomial, but with clusters of roots that rounding error has split from a
+// repeated root merged.
+// The clusters are found by clusters. If there are none, or if the exact
+// Sturm sequence of p shows that they are exact repeated roots, p itself is
+// returned, since the sequence counts its distinct roots exactly. Otherwise p
+// is divided by (x - c)^(m-1) for each cluster of m roots with center c, or by
+// the corresponding real quadratic for a conjugate pair of clusters, and the
+// real centers are also returned. Since the center is the mean of the roots in
+// the cluster it is far more accurate than the simple root of the quotient.
+func (p Poly) mergeClusters(z []complex128) (Poly, []float64) {
+	cs := p.clusters(z)
+	if len(cs) == 0 {
+		return p, nil
+	}
+	// The first member of the Sturm sequence is p divided by the exact GCD
+	// of p and p', which has a root of multiplicity m-1 at each root of p of
+	// multiplicity m.
+	excess := 0
+	for _, c := range cs {
+		excess += c.Mult - 1
+	}
+	if p.Deg()-newSturm(p)[0].Deg() == excess {
+		return p, nil
+	}
+	f := p
+	var centers []float64
+	for _, c := range cs {
+		var d Poly
+		switch z := c.Z; {
+		case imag(z) == 0:
+			d = New(-real(z), 1)
+			centers = append(centers, p.polishCenter(real(z), c.Mult))
+		case imag(z) > 0:
+			d = New(real(z)*real(z)+imag(z)*imag(z), -2*real(z), 1)
+		default:
+			continue
+		}
+		f = f.Div(d.Pow(c.Mult - 1))
+	}
+	return f, centers
+}
+
+// Polishes the real center c of a cluster of m roots with a few Newton steps
+// on the (m-1)st derivative of p, which has a simple root there. A step that
+// would leave the cluster is not taken.
+func (p Poly) polishCenter(c float64, m int) float64 {
+	d := p
+	for i := 1; i < m; i++ {
+		d = d.Der()
+	}
+	dd := d.Der()
+	for i := 0; i < 4; i++ {
+		step := d.Eval(c) / dd.Eval(c)
+		if !(math.Abs(step) <= clusterGroupTol*math.Abs(c)) {
+			break
+		}
+		c -= step
+	}
+	return c
+}
+
+// Relative distance within which roots found by Roots are grouped as
+// candidate clusters.
+const clusterGroupTol = 1e-3
+
+// Finds the clusters of roots of a polynomial that rounding error has split
+// from repeated roots. Returns the center and number of roots of each.
+// The roots found by Roots are grouped with the roots within clusterGroupTol
+// of them relative to their magnitude, and a group of m > 1 roots with center
+// c is accepted if it passes isCluster. A group whose center is within the
+// same distance of the real axis is taken to be real, since the roots split
+// from a real repeated root need not be found symmetric about the axis, and
+// other groups come in conjugate pairs.
+func (p Poly) clusters(z []complex128) []Root {
+	used := make([]bool, len(z))
+	var cs []Root
+	for i, zi := range z {
+		if used[i] {
+			continue
+		}
+		sum, m := zi, 1
+		for j := i + 1; j < len(z); j++ {
+			if !used[j] && cmplx.Abs(z[j]-zi) <= clusterGroupTol*cmplx.Abs(zi) {
+				used[j] = true
+				sum += z[j]
+				m++
+			}
+		}
+		if m == 1 {
+			continue
+		}
+		c := sum / complex(float64(m), 0)
+		if math.Abs(imag(c)) <= clusterGroupTol*cmplx.Abs(c) {
+			c = complex(real(c), 0)
+		}
+		if p.isCluster(c, m) {
+			cs = append(cs, Root{c, m})
+		}
+	}
+	return cs
+}
+
+// Reports whether the roots of p near r form a cluster of m roots whose
+// radius relative to |r| is at most squareFreeTol^(1/m), as rounding error
+// splits a root of multiplicity m into. The radius is estimated from the
+// Taylor coefficients c_j of p(r + |r|t) as the largest |c_j/c_m|^(1/(m-j))
+// for j < m. A root at zero is exact, and always passes.
+func (p Poly) isCluster(r complex128, m int) bool {
+	if r == 0 {
+		return true
+	}
+	// Repeated synthetic division by x - r gives the Taylor coefficients in
+	// turn as the remainders.
+	b := complexCoeffs(p)
+	c := make([]float64, m+1)
+	scale := 1.0
+	for j := 0; j <= m && len(b) > 0; j++ {
+		var rem complex128
+		for i := len(b) - 1; i >= 0; i-- {
+			rem, b[i] = b[i]+rem*r, rem
+		}
+		b = b[:len(b)-1]
+		c[j] = cmplx.Abs(rem) * scale
+		scale *= cmplx.Abs(r)
+	}
+	if c[m] == 0 {
+		return false
+	}
+	for j := 0; j < m; j++ {
+		if math.Pow(c[j]/c[m], 1/float64(m-j)) > math.Pow(squareFreeTol, 1/float64(m)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns an upper bound on the magnitude of every complex root of a
+// polynomial, using Fujiwara's bound
+// 2*max(|a_{n-1}/a_n|, |a_{n-2}/a_n|^(1/2), ..., |a_0/(2a_n)|^(1/n)).
+// Every root z satisfies |z| <= RootBound(). Constant polynomials have no
+// roots and a bound of 0.
+func (p Poly) RootBound() float64 {
+	n := p.Deg()
+	if n < 1 {
+		return 0
+	}
+	lead := math.Abs(p.Coeff(n))
+	var m float64
+	for i := 0; i < n; i++ {
+		a := math.Abs(p.Coeff(i)) / lead
+		if i == 0 {
+			a /= 2
+		}
+		m = math.Max(m, math.Pow(a, 1/float64(n-i)))
+	}
+	return 2 * m
+}
+
+// Returns a lower bound on the magnitude of every nonzero complex root of a
+// polynomial.
+// The bound is the reciprocal of RootBound applied to the polynomial with its
+// coefficients reversed, whose roots are the reciprocals of the nonzero roots
+// of p. Every nonzero root z satisfies |z| >= MinRootBound(). If there are no
+// nonzero roots the bound is 0.
+func (p Poly) MinRootBound() float64 {
+	if p.Deg() < 1 {
+		return 0
+	}
+	_, q := p.splitZeroRoots()
+	qco := q.co()
+	c := make([]float64, len(qco))
+	for i, qc := range qco {
+		c[len(c)-1-i] = qc
+	}
+	b := normalized(c).RootBound()
+	if b == 0 {
+		return 0
+	}
+	return 1 / b
+}
+
+// Returns a bound strictly greater than the magnitude of every root of a
+// non-constant polynomial. It is the smaller of the Cauchy bound and twice
+// RootBound, which keeps the points at which the Sturm sequence is evaluated,
+// and so the cost of evaluating it exactly, small. It is at most the largest
+// finite float64.
+func (p Poly) isolationBound() float64 {
+	b := p.cauchyBound()
+	if r := 2 * p.RootBound(); r > 0 && r < b {
+		b = r
+	}
+	return math.Min(b, math.MaxFloat64)
+}
+
+// Returns the Cauchy bound 1 + max|a_i/a_n|, which is strictly greater than
+// the magnitude of every root of a non-constant polynomial.
+func (p Poly) cauchyBound() float64 {
+	n := p.Deg()
+	lead := math.Abs(p.Coeff(n))
+	var m float64
+	for i := 0; i < n; i++ {
+		m = math.Max(m, math.Abs(p.Coeff(i))/lead)
+	}
+	return 1 + m
+}
+
+// A Sturm sequence of a polynomial, with exact integer coefficients. The
+// number of distinct real roots in (a, b] is the number of sign changes in the
+// sequence evaluated at a minus the number at b.
+type sturm []IntPoly
+
+// Computes the Sturm sequence p, p', -rem(p, p'), ... of a non-constant
+// polynomial in exact arithmetic.
+// Every float64 is a dyadic rational, so p is scaled by a power of two to give
+// integer coefficients. Each remainder is then computed by pseudo-division
+// and divided by its content, which scale it by nonzero factors whose signs
+// are corrected for, leaving the root counts unchanged. As no rounding is
+// involved, the counts are exact for p as given, however close together its
+// roots. If p is not square-free the sequence ends with GCD(p, p'), and every
+// member is divided by it, which leaves the counts unchanged away from the
+// roots of the GCD, and makes the first member the square-free part of p, so
+// that the counts are also correct at its roots.
+func newSturm(p Poly) sturm {
+	a := p.scaledInt()
+	s := sturm{a, a.Der()}
+	for {
+		a, b := s[len(s)-2], s[len(s)-1]
+		_, r := a.PseudoDivMod(b)
+		if r.isZero() {
+			break
+		}
+		// The pseudo-remainder is lc(b)^(deg a - deg b + 1) times the
+		// remainder, and the Sturm sequence continues with its negation.
+		s = append(s, r.reduced(b.lead().Sign() > 0 || (a.Deg()-b.Deg())%2 == 1))
+	}
+	if g := s[len(s)-1]; g.Deg() > 0 {
+		for i, a := range s {
+			q, _ := a.PseudoDivMod(g)
+			s[i] = q.reduced(g.lead().Sign() < 0 && (a.Deg()-g.Deg())%2 == 0)
+		}
+	}
+	return s
+}
+
+// Returns the leading coefficient of the polynomial.
+func (p IntPoly) lead() *big.Int {
+	return p.co()[p.Deg()]
+}
+
+// Returns the polynomial divided by the absolute value of its content, and
+// negated if neg is true.
+func (p IntPoly) reduced(neg bool) IntPoly {
+	g := p.Content()
+	g.Abs(g)
+	if neg {
+		g.Neg(g)
+	}
+	c := make([]*big.Int, p.Deg()+1)
+	for i, pc := range p.co() {
+		c[i] = new(big.Int).Quo(pc, g)
+	}
+	return normalizedInt(c)
+}
+
+// Returns the polynomial scaled by a power of two so that its coefficients
+// are integers. The coefficients must be finite.
+func (p Poly) scaledInt() IntPoly {
+	pco := p.co()
+	shift := math.MinInt
+	for _, pc := range pco {
+		if pc != 0 {
+			_, e := math.Frexp(pc)
+			shift = max(shift, 53-e)
+		}
+	}
+	c := make([]*big.Int, len(pco))
+	var f big.Float
+	for i, pc := range pco {
+		f.SetFloat64(pc)
+		c[i], _ = f.SetMantExp(&f, shift).Int(nil)
+	}
+	return normalizedInt(c)
+}
+
+// Returns the sign of p(x), computed exactly.
+func (p IntPoly) signAt(x float64) int {
+	pco := p.co()
+	if x == 0 {
+		return pco[0].Sign()
+	}
+	// Write x = m*2^e with m an odd integer.
+	frac, e := math.Frexp(x)
+	m := big.NewInt(int64(frac * (1 << 53)))
+	e -= 53
+	tz := m.TrailingZeroBits()
+	m.Rsh(m, tz)
+	e += int(tz)
+	if e >= 0 {
+		return p.Eval(m.Lsh(m, uint(e))).Sign()
+	}
+	// Multiplying p(x) by 2^(-e*n) gives the sum of c_i m^i 2^(-e(n-i)),
+	// which is an integer, evaluated here by Horner's method.
+	n := len(pco) - 1
+	v := new(big.Int).Set(pco[n])
+	var t big.Int
+	for i := n - 1; i >= 0; i-- {
+		v.Mul(v, m)
+		v.Add(v, t.Lsh(pco[i], uint(-e*(n-i))))
+	}
+	return v.Sign()
+}
+
+// Returns the number of sign changes in the sequence evaluated at x, ignoring
+// zeros.
+func (s sturm) signChanges(x float64) int {
+	return s.signChangesWith(x, s[0].signAt(x))
+}
+
+// Returns the number of sign changes in the sequence evaluated at x, with the
+// sign v0 used for its first member.
+func (s sturm) signChangesWith(x float64, v0 int) int {
+	n := 0
+	prev := 0
+	for i, p := range s {
+		v := v0
+		if i > 0 {
+			v = p.signAt(x)
+		}
+		if v == 0 {
+			continue
+		}
+		if prev != 0 && v != prev {
+			n++
+		}
+		prev = v
+	}
+	return n
+}
+
+// Returns the number of distinct real roots in (a, b].
+func (s sturm) count(a, b float64) int {
+	return s.signChanges(a) - s.signChanges(b)
+}
+
+// Returns the number of sign changes at x, where root records that x is known
+// to be a root of the first member.
+func (s sturm) changesAt(x float64, root bool) int {
+	if root {
+		return s.signChangesWith(x, 0)
+	}
+	return s.signChanges(x)
+}
+
+// Returns the number of sign changes just to the right of x, where root
+// records that x is known to be a root of the first member. Just to the right
+// of a simple root the first member has the sign of the second, its
+// derivative.
+func (s sturm) changesAfter(x float64, root bool) int {
+	if root {
+		return s.signChangesWith(x, s[1].signAt(x))
+	}
+	return s.signChanges(x)
+}
+
+// Refines the only distinct root of f in (lo, hi] using Newton's method
+// safeguarded by bisection, where s is the Sturm sequence of f. The bracket
+// is maintained using the exact sign of the first member of s, the
+// square-free part of f, so rounding error in evaluating f only slows
+// convergence. If the first member does not change sign over the interval
+// its midpoint is returned. If the interval contains one of the centers of
+// merged clusters, the center is returned instead.
+func refineRoot(f, df Poly, s sturm, centers []float64, lo, hi float64) float64 {
+	for _, c := range centers {
+		if lo <= c && c <= hi {
+			return c
+		}
+	}
+	slo, shi := s[0].signAt(lo), s[0].signAt(hi)
+	if shi == 0 {
+		return hi
+	}
+	if slo == 0 {
+		// lo is a different simple root, so just to its right f has the
+		// sign of f'(lo).
+		slo = s[1].signAt(lo)
+	}
+	if slo == shi {
+		return lo/2 + hi/2
+	}
+	return bracketRoot(f, df, s[0].signAt, lo, hi, slo)
+}
+
+// Refines the only root of f in the interval [lo, hi] using Newton's method
+// safeguarded by bisection, where sign gives the exact sign of f, or of its
+// square-free part, and slo is its sign just to the right of lo. A root at
+// either end is returned exactly.
+func bracketRoot(f, df Poly, sign func(float64) int, lo, hi float64, slo int) float64 {
+	if slo == 0 || lo == hi {
+		return lo
+	}
+	if sign(hi) == 0 {
+		return hi
+	}
+	x := lo/2 + hi/2
+	for i := 0; i < 200; i++ {
+		sx := sign(x)
+		if sx == 0 {
+			return x
+		}
+		if sx == slo {
+			lo = x
+		} else {
+			hi = x
+		}
+		if hi-lo <= 4*epsilon*math.Max(math.Abs(lo), math.Abs(hi)) {
+			break
+		}
+		next := x - f.Eval(x)/df.Eval(x)
+		if !(next > lo && next < hi) {
+			next = lo/2 + hi/2
+		}
+		if next == x {
+			break
+		}
+		x = next
+	}
+	return x
+}
+
+// The difference between 1 and the next larger float64.
+const epsilon = 0x1p-52
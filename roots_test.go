@@ -0,0 +1,700 @@
+package poly
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func compareRoots(got, want []float64, tol float64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if math.Abs(got[i]-want[i]) > tol*math.Max(1, math.Abs(want[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// Tests that real roots are found.
+func TestRealRoots(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		want []float64
+	}{
+		{Poly{}, nil},
+		{New(3), nil},
+		{New(0, 1), []float64{0}},
+		{New(-2, 1), []float64{2}},
+		{New(-1, 0, 1), []float64{-1, 1}},
+		{New(1, 0, 1), nil},
+		{FromRoots(3, -2, 0.5), []float64{-2, 0.5, 3}},
+		{New(-2, 0, 1), []float64{-math.Sqrt2, math.Sqrt2}},
+		{FromRoots(1, 1, 2), []float64{1, 2}},
+		{FromRoots(-1, -1, -1, 4, 4), []float64{-1, 4}},
+		{FromRoots(0, 0, 1e-3), []float64{0, 1e-3}},
+		{FromRoots(1, 2, 3, 4, 5, 6, 7, 8, 9, 10), []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+		{FromRoots(100, -250, 0.001).Mul(New(1, 0, 1)), []float64{-250, 0.001, 100}},
+		{FromRoots(1, 1.001), []float64{1, 1.001}},
+		{FromRoots(0.1, 0.1, 0.3), []float64{0.1, 0.3}},
+		{FromRoots(1.1, 1.1, 3.3, 3.3, -2.2), []float64{-2.2, 1.1, 3.3}},
+		{FromRoots(-3.5, -1.1, -0.5, 0.55, 0.65, 0.8813, 0.8858, 1.2, 1.4, 2.7, 3.3, 3.6).Mul(New(1.5, 0, 1)),
+			[]float64{-3.5, -1.1, -0.5, 0.55, 0.65, 0.8813, 0.8858, 1.2, 1.4, 2.7, 3.3, 3.6}},
+		{New(-1, 0, 0, 0, 0, 1), []float64{1}},
+		{FromRoots(1e-8, 2e-8, 1), []float64{1e-8, 2e-8, 1}},
+		{FromRoots(0.1, 0.1000001), []float64{0.1, 0.1000001}},
+		{FromRoots(0.3, 0.3, 0.7, 0.7, 0.7), []float64{0.3, 0.7}},
+	}
+	for i, c := range cases {
+		if got := c.p.RealRoots(); !compareRoots(got, c.want, 1e-8) {
+			t.Errorf("case %d: RealRoots() on %q == %v, want %v", i, c.p, got, c.want)
+		}
+	}
+}
+
+// Returns the Wilkinson polynomial of degree n, with roots 1, 2, ..., n.
+func wilkinson(n int) Poly {
+	roots := make([]float64, n)
+	for i := range roots {
+		roots[i] = float64(i + 1)
+	}
+	return FromRoots(roots...)
+}
+
+// Tests that every root of an ill-conditioned polynomial is found.
+func TestRealRootsWilkinson(t *testing.T) {
+	for _, n := range []int{15, 20} {
+		got := wilkinson(n).RealRoots()
+		if len(got) != n {
+			t.Errorf("RealRoots() on Wilkinson %d found %d roots, want %d: %v", n, len(got), n, got)
+			continue
+		}
+		// The rounded coefficients move the largest roots noticeably.
+		for i, r := range got {
+			if want := float64(i + 1); math.Abs(r-want) > 1e-3*want {
+				t.Errorf("RealRoots() on Wilkinson %d: root %d == %g, want %g", n, i, r, want)
+			}
+		}
+	}
+}
+
+// Tests that the real roots agree with the real roots among Roots() for
+// polynomials whose real roots are well separated.
+func TestRealRootsRoots(t *testing.T) {
+	rnd := rand.New(rand.NewSource(4))
+	for i := 0; i < 200; i++ {
+		p := randomPoly(rnd, 1+rnd.Intn(20))
+		want := 0
+		for _, z := range p.Roots() {
+			if imag(z) == 0 {
+				want++
+			}
+		}
+		if got := p.RealRoots(); len(got) != want {
+			t.Errorf("case %d: RealRoots() on %q == %v, want %d roots", i, p, got, want)
+		}
+	}
+}
+
+// Tests that the intervals certified without a Sturm sequence agree with the
+// exact Sturm sequence, and that high degree polynomials, for which the
+// exact sequence would be slow, are isolated from them.
+func TestIsolateFast(t *testing.T) {
+	rnd := rand.New(rand.NewSource(11))
+	for i := 0; i < 20; i++ {
+		p := randomPoly(rnd, 20+rnd.Intn(40))
+		ivs, _, ok := p.isolateFast(p.Roots())
+		if !ok {
+			t.Errorf("case %d: isolateFast() on %q failed", i, p)
+			continue
+		}
+		s := newSturm(p)
+		b := p.isolationBound()
+		if n := s.count(-b, b); len(ivs) != n {
+			t.Errorf("case %d: isolateFast() on %q == %v, want %d intervals", i, p, ivs, n)
+		}
+		for _, iv := range ivs {
+			if n := s.count(iv.Lo, iv.Hi); n != 1 {
+				t.Errorf("case %d: interval %v contains %d roots, want 1", i, iv, n)
+			}
+		}
+		x, y := rnd.NormFloat64(), rnd.NormFloat64()
+		lo, hi := math.Min(x, y), math.Max(x, y)
+		if got, want := p.CountRootsIn(lo, hi), s.count(lo, hi); got != want {
+			t.Errorf("case %d: CountRootsIn(%g, %g) on %q == %d, want %d", i, lo, hi, p, got, want)
+		}
+	}
+	for i := 0; i < 5; i++ {
+		p := randomPoly(rnd, 200)
+		want := 0
+		for _, z := range p.Roots() {
+			if imag(z) == 0 {
+				want++
+			}
+		}
+		if got := p.RealRoots(); len(got) != want {
+			t.Errorf("case %d: RealRoots() on degree 200 == %v, want %d roots", i, got, want)
+		}
+	}
+}
+
+// Tests that a double or triple real root is found once.
+func TestRealRootsMultiple(t *testing.T) {
+	rnd := rand.New(rand.NewSource(5))
+	for i := 0; i < 200; i++ {
+		q := randomPoly(rnd, 1+rnd.Intn(15))
+		a := rnd.NormFloat64()
+		want := q.RealRoots()
+		near := false
+		for _, r := range want {
+			near = near || math.Abs(r-a) < 1e-3
+		}
+		if near {
+			continue
+		}
+		want = append(want, a)
+		sort.Float64s(want)
+		p := q.Mul(New(-a, 1).Pow(2 + i%2))
+		// A repeated root is found less accurately near another root.
+		if got := p.RealRoots(); !compareRoots(got, want, 1e-5) {
+			t.Errorf("case %d: RealRoots() on %q == %v, want %v", i, p, got, want)
+		}
+	}
+}
+
+// Tests that the Sturm sequence counts distinct roots in an interval.
+func TestSturmCount(t *testing.T) {
+	s := newSturm(FromRoots(-2, 0.5, 3))
+	cases := []struct {
+		a, b float64
+		want int
+	}{
+		{-10, 10, 3},
+		{-10, -3, 0},
+		{-3, 0, 1},
+		{0, 10, 2},
+		{0.5, 3, 1},
+		{0.4, 3, 2},
+	}
+	for i, c := range cases {
+		if got := s.count(c.a, c.b); got != c.want {
+			t.Errorf("case %d: count(%g, %g) == %d, want %d", i, c.a, c.b, got, c.want)
+		}
+	}
+}
+
+// Tests that all complex roots are found.
+func TestRoots(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		want []complex128
+		tol  float64
+	}{
+		{Poly{}, nil, 0},
+		{New(3), nil, 0},
+		{New(0, 1), []complex128{0}, 1e-12},
+		{New(0, 0, 2), []complex128{0, 0}, 1e-12},
+		{New(-2, 1), []complex128{2}, 1e-12},
+		{New(1, 0, 1), []complex128{-1i, 1i}, 1e-12},
+		{New(5, -2, 1), []complex128{1 - 2i, 1 + 2i}, 1e-12},
+		{FromRoots(3, -2, 0.5), []complex128{-2, 0.5, 3}, 1e-12},
+		{New(-1, 0, 0, 1), []complex128{
+			complex(-0.5, -math.Sqrt(3)/2), complex(-0.5, math.Sqrt(3)/2), 1}, 1e-12},
+		{New(0, 0, 1, 0, 1), []complex128{-1i, 0, 0, 1i}, 1e-12},
+		{FromRoots(1, 2, 3, 4, 5, 6, 7, 8, 9, 10), []complex128{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 1e-8},
+		// Multiple roots converge to about half the working precision.
+		{FromRoots(1, 1, 2), []complex128{1, 1, 2}, 1e-6},
+	}
+	for i, c := range cases {
+		got := c.p.Roots()
+		ok := len(got) == len(c.want)
+		for j := 0; ok && j < len(got); j++ {
+			ok = cmplx.Abs(got[j]-c.want[j]) <= c.tol*math.Max(1, cmplx.Abs(c.want[j]))
+		}
+		if !ok {
+			t.Errorf("case %d: Roots() on %q == %v, want %v", i, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests that every computed root is a root of a higher degree polynomial.
+func TestRootsResidual(t *testing.T) {
+	p := New(1, -3, 0.5, 2, -7, 0, 4, 1, -0.25, 3, 1, 0, 2, -1, 0.5, 1)
+	roots := p.Roots()
+	if len(roots) != p.Deg() {
+		t.Fatalf("Roots() returned %d roots, want %d", len(roots), p.Deg())
+	}
+	// Reconstruct the polynomial from its roots.
+	got := []complex128{1}
+	for _, r := range roots {
+		next := make([]complex128, len(got)+1)
+		for j, c := range got {
+			next[j+1] += c
+			next[j] -= r * c
+		}
+		got = next
+	}
+	lead := p.Coeff(p.Deg())
+	for i, c := range got {
+		if want := p.Coeff(i) / lead; cmplx.Abs(c-complex(want, 0)) > 1e-9 {
+			t.Errorf("coefficient %d from roots == %v, want %v", i, c, want)
+		}
+	}
+}
+
+// Tests that each isolating interval contains exactly one of the known roots.
+func TestIsolateRoots(t *testing.T) {
+	cases := []struct {
+		p     Poly
+		roots []float64
+	}{
+		{Poly{}, nil},
+		{New(3), nil},
+		{New(1, 0, 1), nil},
+		{New(0, 1), []float64{0}},
+		{FromRoots(3, -2, 0.5), []float64{-2, 0.5, 3}},
+		{FromRoots(1, 1, 2), []float64{1, 2}},
+		{FromRoots(0, 0, 1e-3, -1e-3), []float64{-1e-3, 0, 1e-3}},
+		{FromRoots(1, 2, 3, 4, 5, 6, 7, 8, 9, 10), []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+		{FromRoots(1, 1.000001, 5).Mul(New(2, 1, 1)), []float64{1, 1.000001, 5}},
+		{FromRoots(1e-8, 2e-8, 1), []float64{1e-8, 2e-8, 1}},
+		{FromRoots(0.1, 0.1000001), []float64{0.1, 0.1000001}},
+		{wilkinson(20), []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}},
+	}
+	for i, c := range cases {
+		got := c.p.IsolateRoots()
+		if len(got) != len(c.roots) {
+			t.Errorf("case %d: IsolateRoots() on %q == %v, want %d intervals", i, c.p, got, len(c.roots))
+			continue
+		}
+		for j, iv := range got {
+			if iv.Lo > iv.Hi || (j > 0 && iv.Lo < got[j-1].Hi) {
+				t.Errorf("case %d: IsolateRoots() on %q == %v, not disjoint and ascending", i, c.p, got)
+				break
+			}
+			n := 0
+			for _, r := range c.roots {
+				if iv.Lo <= r && r <= iv.Hi {
+					n++
+				}
+			}
+			if n != 1 {
+				t.Errorf("case %d: interval %v contains %d roots, want 1", i, iv, n)
+			}
+			if iv.Lo < iv.Hi && c.p.CountRootsIn(iv.Lo, iv.Hi) != 1 {
+				t.Errorf("case %d: interval %v counts %d roots, want 1", i, iv, c.p.CountRootsIn(iv.Lo, iv.Hi))
+			}
+		}
+	}
+}
+
+// Tests that roots are found and counted within a closed interval.
+func TestRootsIn(t *testing.T) {
+	p := FromRoots(-2, 0.5, 0.5, 3).Mul(New(1, 0, 1))
+	cases := []struct {
+		a, b float64
+		want []float64
+	}{
+		{-10, 10, []float64{-2, 0.5, 3}},
+		{-10, -3, nil},
+		{-2, -2, []float64{-2}},
+		{-2, 0.5, []float64{-2, 0.5}},
+		{-1.9, 0.5, []float64{0.5}},
+		{0.5, 3, []float64{0.5, 3}},
+		{0.6, 2.9, nil},
+		{0, 100, []float64{0.5, 3}},
+	}
+	for i, c := range cases {
+		if got := p.RootsIn(c.a, c.b); !compareRoots(got, c.want, 1e-8) {
+			t.Errorf("case %d: RootsIn(%g, %g) on %q == %v, want %v", i, c.a, c.b, p, got, c.want)
+		}
+		if got := p.CountRootsIn(c.a, c.b); got != len(c.want) {
+			t.Errorf("case %d: CountRootsIn(%g, %g) on %q == %d, want %d", i, c.a, c.b, p, got, len(c.want))
+		}
+	}
+	if got := New(5).CountRootsIn(-1, 1); got != 0 {
+		t.Errorf("CountRootsIn(-1, 1) on constant == %d, want 0", got)
+	}
+}
+
+// Tests that roots are found and counted within infinite and huge intervals,
+// and that NaN bounds are rejected.
+func TestRootsInBounds(t *testing.T) {
+	inf := math.Inf(1)
+	p := FromRoots(-2, -1, 1, 2, 3)
+	cases := []struct {
+		a, b float64
+		want []float64
+	}{
+		{-inf, inf, []float64{-2, -1, 1, 2, 3}},
+		{-inf, 0, []float64{-2, -1}},
+		{0, inf, []float64{1, 2, 3}},
+		{-inf, -2, []float64{-2}},
+		{3, inf, []float64{3}},
+		{-1e308, 1e308, []float64{-2, -1, 1, 2, 3}},
+		{-math.MaxFloat64, -1e300, nil},
+		{1e300, inf, nil},
+		{inf, inf, nil},
+		{-inf, -inf, nil},
+	}
+	for i, c := range cases {
+		if got := p.RootsIn(c.a, c.b); !compareRoots(got, c.want, 1e-8) {
+			t.Errorf("case %d: RootsIn(%g, %g) on %q == %v, want %v", i, c.a, c.b, p, got, c.want)
+		}
+		if got := p.CountRootsIn(c.a, c.b); got != len(c.want) {
+			t.Errorf("case %d: CountRootsIn(%g, %g) on %q == %d, want %d", i, c.a, c.b, p, got, len(c.want))
+		}
+	}
+	for i, c := range [][2]float64{{math.NaN(), 3}, {0, math.NaN()}, {math.NaN(), math.NaN()}, {1, -1}} {
+		for _, f := range []func(){
+			func() { p.RootsIn(c[0], c[1]) },
+			func() { p.CountRootsIn(c[0], c[1]) },
+		} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("case %d: interval [%g, %g] did not panic", i, c[0], c[1])
+					}
+				}()
+				f()
+			}()
+		}
+	}
+}
+
+// Tests that roots are found and counted within intervals of a polynomial
+// with many roots.
+func TestRootsInMany(t *testing.T) {
+	p := wilkinson(20)
+	cases := []struct {
+		a, b float64
+		want int
+	}{
+		// The rounded coefficients move the root at 10 slightly above it.
+		{-10, 10, 9},
+		{-10, 10.5, 10},
+		{0.5, 20.5, 20},
+		{4.5, 15.5, 11},
+		{10.5, 100, 10},
+		{-100, 0.5, 0},
+	}
+	for i, c := range cases {
+		got := p.RootsIn(c.a, c.b)
+		if len(got) != c.want {
+			t.Errorf("case %d: RootsIn(%g, %g) on Wilkinson 20 == %v, want %d roots", i, c.a, c.b, got, c.want)
+		}
+		for _, r := range got {
+			if r < c.a || r > c.b || math.Abs(r-math.Round(r)) > 1e-3*r {
+				t.Errorf("case %d: RootsIn(%g, %g) on Wilkinson 20 found root %g", i, c.a, c.b, r)
+			}
+		}
+		if got := p.CountRootsIn(c.a, c.b); got != c.want {
+			t.Errorf("case %d: CountRootsIn(%g, %g) on Wilkinson 20 == %d, want %d", i, c.a, c.b, got, c.want)
+		}
+	}
+}
+
+// Tests that root bounds contain every root.
+func TestRootBound(t *testing.T) {
+	cases := []struct {
+		p        Poly
+		wantUp   float64
+		wantDown float64
+	}{
+		{Poly{}, 0, 0},
+		{New(3), 0, 0},
+		{New(0, 0, 1), 0, 0},
+		{New(-2, 1), 2, 2},
+		{New(4, 0, 1), 2 * math.Sqrt2, math.Sqrt2},
+		{FromRoots(1, 2, 3), 12, 3.0 / 11},
+		{FromRoots(0, -0.5, 4), 7, 2.0 / 7},
+	}
+	for i, c := range cases {
+		if got := c.p.RootBound(); math.Abs(got-c.wantUp) > 1e-12 {
+			t.Errorf("case %d: RootBound() on %q == %g, want %g", i, c.p, got, c.wantUp)
+		}
+		if got := c.p.MinRootBound(); math.Abs(got-c.wantDown) > 1e-12 {
+			t.Errorf("case %d: MinRootBound() on %q == %g, want %g", i, c.p, got, c.wantDown)
+		}
+	}
+}
+
+// Tests that the bounds hold for the roots of a higher degree polynomial.
+func TestRootBoundContainsRoots(t *testing.T) {
+	cases := []Poly{
+		New(1, -3, 0.5, 2, -7, 0, 4, 1, -0.25, 3, 1, 0, 2, -1, 0.5, 1),
+		FromRoots(1e-3, -20, 5, 5).Mul(New(2, 1, 1)),
+		New(0, 0, 1e-6, 0, 0, 1),
+	}
+	for i, p := range cases {
+		up, down := p.RootBound(), p.MinRootBound()
+		for _, z := range p.Roots() {
+			if r := cmplx.Abs(z); r > up*(1+1e-12) || (r != 0 && r < down*(1-1e-12)) {
+				t.Errorf("case %d: root %v outside bounds [%g, %g]", i, z, down, up)
+			}
+		}
+	}
+}
+
+// Tests that roots are reported with their multiplicities.
+func TestRootsWithMultiplicity(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		want []Root
+	}{
+		{Poly{}, nil},
+		{New(3), nil},
+		{New(-2, 1), []Root{{2, 1}}},
+		{New(0, 0, 0, 2), []Root{{0, 3}}},
+		{FromRoots(1, 1, 2), []Root{{1, 2}, {2, 1}}},
+		{FromRoots(0.1, 0.1, 0.3), []Root{{0.1, 2}, {0.3, 1}}},
+		{FromRoots(-1, -1, -1, 4, 4), []Root{{-1, 3}, {4, 2}}},
+		{FromRoots(1.1, 1.1, 3.3, 3.3, -2.2), []Root{{-2.2, 1}, {1.1, 2}, {3.3, 2}}},
+		{FromRoots(0.5, 0.5, 0.5, 0.5), []Root{{0.5, 4}}},
+		{New(1, 0, 1).Pow(2).Mul(FromRoots(3)), []Root{{-1i, 2}, {1i, 2}, {3, 1}}},
+		{New(0, 0, 1, 0, 1), []Root{{-1i, 1}, {0, 2}, {1i, 1}}},
+		{FromRoots(1, 1.00001, 3), []Root{{1, 1}, {1.00001, 1}, {3, 1}}},
+		{FromRoots(0.001, 0.001, 0.003), []Root{{0.001, 2}, {0.003, 1}}},
+		{FromRoots(1e-8, 2e-8, 2e-8), []Root{{1e-8, 1}, {2e-8, 2}}},
+	}
+	for i, c := range cases {
+		got := c.p.RootsWithMultiplicity()
+		ok := len(got) == len(c.want)
+		for j := 0; ok && j < len(got); j++ {
+			ok = got[j].Mult == c.want[j].Mult && cmplx.Abs(got[j].Z-c.want[j].Z) <= 1e-9
+		}
+		if !ok {
+			t.Errorf("case %d: RootsWithMultiplicity() on %q == %v, want %v", i, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests that random polynomials, whose roots are almost surely simple, are
+// reported with simple roots whose multiplicities sum to the degree.
+func TestRootsWithMultiplicityRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		c := make([]float64, 2+rnd.Intn(25))
+		for j := range c {
+			c[j] = rnd.NormFloat64()
+		}
+		p := New(c...)
+		n := 0
+		for _, r := range p.RootsWithMultiplicity() {
+			if r.Mult != 1 {
+				t.Errorf("case %d: RootsWithMultiplicity() on %q found root %v of multiplicity %d", i, p, r.Z, r.Mult)
+			}
+			n += r.Mult
+		}
+		if n != p.Deg() {
+			t.Errorf("case %d: RootsWithMultiplicity() on %q has multiplicities summing to %d, want %d", i, p, n, p.Deg())
+		}
+	}
+}
+
+// Tests factorization over the reals.
+func TestFactorReal(t *testing.T) {
+	cases := []struct {
+		p       Poly
+		lead    float64
+		factors []Poly
+	}{
+		{Poly{}, 0, nil},
+		{New(3), 3, nil},
+		{New(-4, 2), 2, []Poly{New(-2, 1)}},
+		{FromRoots(3, 1, 1), 1, []Poly{New(-1, 1), New(-1, 1), New(-3, 1)}},
+		{New(1, 0, 1).Scale(-2), -2, []Poly{New(1, 0, 1)}},
+		{New(1, 0, 0, 0, 1), 1, []Poly{New(1, math.Sqrt2, 1), New(1, -math.Sqrt2, 1)}},
+		// (x^2 + 2x + 5)^2 (x - 1) x
+		{New(5, 2, 1).Pow(2).Mul(FromRoots(1, 0)).Scale(4), 4, []Poly{New(5, 2, 1), New(5, 2, 1), New(0, 1), New(-1, 1)}},
+		{New(-1, 0, 0, 1), 1, []Poly{New(1, 1, 1), New(-1, 1)}},
+	}
+	for i, c := range cases {
+		lead, factors := c.p.FactorReal()
+		ok := math.Abs(lead-c.lead) <= 1e-9 && len(factors) == len(c.factors)
+		for j := 0; ok && j < len(factors); j++ {
+			ok = comparePoly(factors[j], c.factors[j])
+		}
+		if !ok {
+			t.Errorf("case %d: FactorReal() on %q == %v, %q, want %v, %q", i, c.p, lead, factors, c.lead, c.factors)
+		}
+	}
+}
+
+// Tests that the factors over the reals of random polynomials, with and
+// without repeated factors, multiply back to the polynomial.
+func TestFactorRealProduct(t *testing.T) {
+	rnd := rand.New(rand.NewSource(3))
+	for i := 0; i < 500; i++ {
+		c := make([]float64, 2+rnd.Intn(25))
+		for j := range c {
+			c[j] = rnd.NormFloat64()
+		}
+		p := New(c...)
+		// Repeated factors that are found as clusters of simple roots
+		// are only accurate to about half the working precision.
+		tol := 1e-9
+		if i%2 == 1 {
+			p = p.Mul(New(rnd.NormFloat64(), rnd.NormFloat64(), 1).Pow(2))
+			tol = 1e-5
+		}
+		lead, factors := p.FactorReal()
+		prod := New(lead)
+		for _, f := range factors {
+			prod = prod.Mul(f)
+		}
+		if prod.Deg() != p.Deg() {
+			t.Errorf("case %d: FactorReal() on %q has factors of total degree %d, want %d", i, p, prod.Deg(), p.Deg())
+		} else if d := prod.Sub(p).maxAbs(); d > tol*p.maxAbs() {
+			t.Errorf("case %d: FactorReal() on %q has a product differing by %g", i, p, d)
+		}
+	}
+}
+
+// Tests square-free factorization.
+func TestSquareFree(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		want []Poly
+	}{
+		{Poly{}, nil},
+		{New(3), nil},
+		{New(-2, 1), []Poly{New(-2, 1)}},
+		{New(-4, 2), []Poly{New(-2, 1)}},
+		{New(0, 0, 0, 2), []Poly{New(1), New(1), New(0, 1)}},
+		{FromRoots(1, 1, 2), []Poly{FromRoots(2), FromRoots(1)}},
+		{FromRoots(-1, -1, -1, 4, 4, 5).Scale(3), []Poly{FromRoots(5), FromRoots(4), FromRoots(-1)}},
+		{FromRoots(0.5, 0.5, 0.5, 0.5), []Poly{New(1), New(1), New(1), FromRoots(0.5)}},
+		{New(1, 0, 1).Pow(2).Mul(FromRoots(3, 2)), []Poly{FromRoots(2, 3), New(1, 0, 1)}},
+		{FromRoots(0.001, 0.002, 0.003), []Poly{FromRoots(0.001, 0.002, 0.003)}},
+		{FromRoots(0.001, 0.001, 0.003), []Poly{FromRoots(0.003), FromRoots(0.001)}},
+		{FromRoots(1, 1.00001, 3), []Poly{FromRoots(1, 1.00001, 3)}},
+	}
+	for i, c := range cases {
+		got := c.p.SquareFree()
+		ok := len(got) == len(c.want)
+		for j := 0; ok && j < len(got); j++ {
+			ok = comparePoly(got[j], c.want[j])
+		}
+		if !ok {
+			t.Errorf("case %d: SquareFree() on %q == %q, want %q", i, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests that the square-free factors of random polynomials, with and without
+// repeated factors and at small scales, multiply back to the polynomial.
+func TestSquareFreeProduct(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	for i := 0; i < 500; i++ {
+		c := make([]float64, 2+rnd.Intn(25))
+		for j := range c {
+			c[j] = rnd.NormFloat64()
+		}
+		p := New(c...)
+		switch i % 4 {
+		case 1:
+			p = p.ScaleVar(1e3)
+		case 2:
+			p = p.Mul(New(rnd.NormFloat64(), 1).Pow(2))
+		case 3:
+			p = p.Mul(New(rnd.NormFloat64(), 1)).ScaleVar(1e4)
+		}
+		factors := p.SquareFree()
+		n := 0
+		prod := New(p.Coeff(p.Deg()))
+		for k, f := range factors {
+			n += (k + 1) * f.Deg()
+			prod = prod.Mul(f.Pow(k + 1))
+		}
+		if n != p.Deg() {
+			t.Errorf("case %d: SquareFree() on %q == %q, degrees sum to %d, want %d", i, p, factors, n, p.Deg())
+		} else if d := prod.Sub(p).maxAbs(); d > 1e-8*p.maxAbs() {
+			t.Errorf("case %d: SquareFree() on %q == %q, product differs by %g", i, p, factors, d)
+		}
+	}
+}
+
+// Tests that approximate roots are polished to nearby roots.
+func TestPolishRoots(t *testing.T) {
+	cases := []struct {
+		p      Poly
+		approx []complex128
+		want   []complex128
+	}{
+		{New(-2, 1), []complex128{1.9}, []complex128{2}},
+		{FromRoots(1, 2, 3), []complex128{1.1, 2.05, 2.9}, []complex128{1, 2, 3}},
+		{FromRoots(1, 2, 3), []complex128{3.2, 0.8}, []complex128{3, 1}},
+		// Both approximations are closest to 1, but deflation separates them.
+		{FromRoots(1, 2), []complex128{1.1, 1.2}, []complex128{1, 2}},
+		{New(1, 0, 1), []complex128{0.1 + 0.9i, -0.1 - 1.1i}, []complex128{1i, -1i}},
+		{New(-1, 0, 0, 0, 0, 1), []complex128{1.01, 0.3 + 0.95i, 0.3 - 0.95i}, []complex128{1, cmplx.Rect(1, 2*math.Pi/5), cmplx.Rect(1, -2*math.Pi/5)}},
+		{Poly{}, nil, []complex128{}},
+	}
+	for i, c := range cases {
+		got, res := c.p.PolishRoots(c.approx)
+		ok := len(got) == len(c.want)
+		for j := 0; ok && j < len(got); j++ {
+			ok = cmplx.Abs(got[j]-c.want[j]) <= 1e-12
+		}
+		if !ok {
+			t.Errorf("case %d: PolishRoots(%v) on %q == %v, want %v", i, c.approx, c.p, got, c.want)
+		}
+		for j, r := range res {
+			if r > 1e-12 {
+				t.Errorf("case %d: PolishRoots(%v) on %q residual %d == %g, want <= 1e-12", i, c.approx, c.p, j, r)
+			}
+		}
+	}
+}
+
+// Tests that sign changes in the coefficients are counted.
+func TestSignChanges(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		want int
+	}{
+		{Poly{}, 0},
+		{New(-3), 0},
+		{New(-1, 1), 1},
+		{New(1, 1), 0},
+		{New(1, 0, 0, -1), 1},
+		{New(-1, 0, 2, 0, -3), 2},
+		{FromRoots(1, 2, 3), 3},
+		{New(1, -1, 1, -1, 1), 4},
+	}
+	for i, c := range cases {
+		if got := c.p.SignChanges(); got != c.want {
+			t.Errorf("case %d: SignChanges() on %q == %d, want %d", i, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests that Descartes' rule of signs bounds the real root counts.
+func TestDescartesBounds(t *testing.T) {
+	cases := []struct {
+		p        Poly
+		pos, neg int
+	}{
+		{Poly{}, 0, 0},
+		{New(5), 0, 0},
+		{New(0, 1), 0, 0},
+		{FromRoots(1, 2, 3), 3, 0},
+		{FromRoots(-1, -2), 0, 2},
+		{FromRoots(-1, 0, 2), 1, 1},
+		{New(1, 0, 1), 0, 0},
+		// x^3 + x - 1 has one real root and a complex pair.
+		{New(-1, 1, 0, 1), 1, 0},
+		// x^4 - x^3 + x^2 - x + 1 has no real roots.
+		{New(1, -1, 1, -1, 1), 4, 0},
+	}
+	for i, c := range cases {
+		if pos, neg := c.p.DescartesBounds(); pos != c.pos || neg != c.neg {
+			t.Errorf("case %d: DescartesBounds() on %q == (%d, %d), want (%d, %d)", i, c.p, pos, neg, c.pos, c.neg)
+		}
+	}
+}
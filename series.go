@@ -0,0 +1,371 @@
+package poly
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Series represents a truncated power series c[0] + c[1]x + ... + O(x^n),
+// whose coefficients are known only below the order n. Operations on series
+// discard terms of degree n or more, so that, unlike products of Poly values,
+// repeated products and quotients do not grow in degree. The result of an
+// operation on two series has the lesser of their orders.
+// A zero valued Series is O(1), which has no known coefficients.
+type Series struct {
+	n     int
+	coeff []float64
+}
+
+// Creates a new power series of order n.
+// The ith parameter represents the coefficient of x^i. Coefficients of x^n
+// and higher are discarded. Panics if n is negative.
+func NewSeries(n int, c ...float64) Series {
+	if n < 0 {
+		panic("poly: negative series order")
+	}
+	a := make([]float64, n)
+	copy(a, c)
+	return Series{n, a}
+}
+
+// Converts a polynomial to a power series of order n, discarding the terms
+// of degree n or more.
+func (p Poly) Series(n int) Series {
+	return NewSeries(n, p.co()...)
+}
+
+// Returns the order n of the series, such that coefficients of x^n and
+// higher are unknown.
+func (s Series) Order() int {
+	return s.n
+}
+
+// Returns the coefficient of x^i, which is 0 for i outside the range 0 to
+// Order()-1.
+func (s Series) Coeff(i int) float64 {
+	if i < 0 || i >= s.n {
+		return 0
+	}
+	return s.coeff[i]
+}
+
+// Returns the known terms of the series as a polynomial.
+func (s Series) Poly() Poly {
+	return New(s.coeff...)
+}
+
+// Returns the series truncated to order n, which must not exceed the
+// current order.
+func (s Series) Truncate(n int) Series {
+	if n < 0 || n > s.n {
+		panic("poly: invalid series order")
+	}
+	return NewSeries(n, s.coeff[:n]...)
+}
+
+// Evaluates the known terms of the series at x.
+func (s Series) Eval(x float64) float64 {
+	var v float64
+	for i := s.n - 1; i >= 0; i-- {
+		v = v*x + s.coeff[i]
+	}
+	return v
+}
+
+// Adds a series to another series.
+// Returns s+t.
+func (s Series) Add(t Series) Series {
+	n := min(s.n, t.n)
+	c := make([]float64, n)
+	for i := range c {
+		c[i] = s.coeff[i] + t.coeff[i]
+	}
+	return Series{n, c}
+}
+
+// Subtracts a series from another series.
+// Returns s-t.
+func (s Series) Sub(t Series) Series {
+	return s.Add(t.Neg())
+}
+
+// Multiplies a series by a scalar.
+// Returns k*s.
+func (s Series) Scale(k float64) Series {
+	c := make([]float64, s.n)
+	for i, sc := range s.coeff {
+		c[i] = k * sc
+	}
+	return Series{s.n, c}
+}
+
+// Negates a series.
+// Returns -s.
+func (s Series) Neg() Series {
+	return s.Scale(-1)
+}
+
+// Multiplies a series by another series.
+// Returns s*t.
+func (s Series) Mul(t Series) Series {
+	n := min(s.n, t.n)
+	return Series{n, mulTrunc(s.coeff, t.coeff, n)}
+}
+
+// Returns the first n coefficients of the product of the series with
+// coefficients a and b.
+func mulTrunc(a, b []float64, n int) []float64 {
+	c := make([]float64, n)
+	for i := 0; i < min(len(a), n); i++ {
+		if a[i] == 0 {
+			continue
+		}
+		for j := 0; j < min(len(b), n-i); j++ {
+			c[i+j] += a[i] * b[j]
+		}
+	}
+	return c
+}
+
+// Computes the multiplicative inverse of a series using Newton iteration,
+// which doubles the number of correct terms at each step.
+// Returns 1/s. Panics if the constant term is zero, since the inverse then
+// has terms of negative degree.
+func (s Series) Inv() Series {
+	if s.n == 0 {
+		return s
+	}
+	if s.coeff[0] == 0 {
+		panic("poly: series inverse with zero constant term")
+	}
+	return Series{s.n, invTrunc(s.coeff, s.n)}
+}
+
+// Returns the first n coefficients of the inverse of the series with
+// coefficients a, where a[0] is nonzero.
+func invTrunc(a []float64, n int) []float64 {
+	if n == 0 {
+		return nil
+	}
+	g := []float64{1 / a[0]}
+	for k := 1; k < n; {
+		k = min(2*k, n)
+		// g = g*(2 - a*g) modulo x^k.
+		e := mulTrunc(a, g, k)
+		for i := range e {
+			e[i] = -e[i]
+		}
+		e[0] += 2
+		g = mulTrunc(g, e, k)
+	}
+	return g
+}
+
+// Divides a series by another series.
+// If both series are divisible by x^k, the common factor is cancelled first,
+// reducing the order of the result by k.
+// Returns s/t. Panics if t is zero or has more leading zero coefficients than
+// s, since the quotient then has terms of negative degree.
+func (s Series) Div(t Series) Series {
+	k := 0
+	for k < t.n && t.coeff[k] == 0 {
+		k++
+	}
+	if k == t.n {
+		panic("poly: series division by zero")
+	}
+	for i := 0; i < min(k, s.n); i++ {
+		if s.coeff[i] != 0 {
+			panic("poly: series division by zero")
+		}
+	}
+	n := max(min(s.n, t.n)-k, 0)
+	return Series{n, mulTrunc(s.coeff[min(k, s.n):], invTrunc(t.coeff[k:], n), n)}
+}
+
+// Computes the derivative of a series. The order is reduced by one, since
+// the coefficient of x^(n-1) in the derivative is unknown.
+func (s Series) Der() Series {
+	if s.n == 0 {
+		return s
+	}
+	c := make([]float64, s.n-1)
+	for i := range c {
+		c[i] = s.coeff[i+1] * float64(i+1)
+	}
+	return Series{s.n - 1, c}
+}
+
+// Computes the integral of a series with constant term k. The order is
+// increased by one.
+func (s Series) Int(k float64) Series {
+	c := make([]float64, s.n+1)
+	c[0] = k
+	for i, sc := range s.coeff {
+		c[i+1] = sc / float64(i+1)
+	}
+	return Series{s.n + 1, c}
+}
+
+// Returns a printable string representing the series lowest order first,
+// such as "1.000 + x + 0.500x^2 + O(x^3)".
+func (s Series) String() string {
+	var b strings.Builder
+	if s.n > 0 {
+		b.WriteString(termFormat{
+			coeff: func(c float64) string {
+				return fmt.Sprintf("%.3f", c)
+			},
+			min:       0.0001,
+			ascending: true,
+		}.format(s.Poly()))
+		b.WriteString(" + ")
+	}
+	switch s.n {
+	case 0:
+		b.WriteString("O(1)")
+	case 1:
+		b.WriteString("O(x)")
+	default:
+		fmt.Fprintf(&b, "O(x^%d)", s.n)
+	}
+	return b.String()
+}
+
+// Returns the series extended to order n >= s.Order() by padding with zero
+// coefficients. The padding is not known to be correct; it serves as the
+// starting point of a Newton step.
+func (s Series) extend(n int) Series {
+	return NewSeries(n, s.coeff...)
+}
+
+// Computes the exponential of a series using Newton iteration on
+// g = exp(s), solving log(g) = s and doubling the number of correct terms at
+// each step.
+// Returns exp(s).
+func (s Series) Exp() Series {
+	if s.n == 0 {
+		return s
+	}
+	// exp(s) = exp(c0) exp(s - c0), where s - c0 has no constant term.
+	f := s.Add(NewSeries(s.n, -s.coeff[0]))
+	g := NewSeries(1, 1)
+	for k := 1; k < s.n; {
+		k = min(2*k, s.n)
+		// g = g*(1 + f - log(g)) modulo x^k.
+		g = g.extend(k)
+		e := f.Truncate(k).Sub(g.Log())
+		e.coeff[0]++
+		g = g.Mul(e)
+	}
+	return g.Scale(math.Exp(s.coeff[0]))
+}
+
+// Computes the natural logarithm of a series as the integral of s'/s.
+// Returns log(s). Panics if the constant term is not positive.
+func (s Series) Log() Series {
+	if s.n == 0 {
+		return s
+	}
+	if !(s.coeff[0] > 0) {
+		panic("poly: series logarithm with nonpositive constant term")
+	}
+	if s.n == 1 {
+		return NewSeries(1, math.Log(s.coeff[0]))
+	}
+	return s.Der().Div(s.Truncate(s.n - 1)).Int(math.Log(s.coeff[0]))
+}
+
+// Computes the square root of a series using Newton iteration on
+// g = (g + s/g)/2, with a positive constant term.
+// If s is divisible by x^(2m), the square root is x^m times the square root
+// of s/x^(2m), and its order is reduced by m.
+// Returns sqrt(s). Panics if the lowest order nonzero term has an odd degree
+// or a negative coefficient, or if s is zero.
+func (s Series) Sqrt() Series {
+	if s.n == 0 {
+		return s
+	}
+	v := 0
+	for v < s.n && s.coeff[v] == 0 {
+		v++
+	}
+	if v == s.n || v%2 == 1 || s.coeff[v] < 0 {
+		panic("poly: series square root of invalid series")
+	}
+	f := NewSeries(s.n-v, s.coeff[v:]...)
+	g := NewSeries(1, math.Sqrt(f.coeff[0]))
+	for k := 1; k < f.n; {
+		k = min(2*k, f.n)
+		g = g.extend(k)
+		g = g.Add(f.Truncate(k).Div(g)).Scale(0.5)
+	}
+	m := v / 2
+	c := make([]float64, s.n-m)
+	copy(c[m:], g.coeff)
+	return Series{s.n - m, c}
+}
+
+// Raises a series to a real power a as exp(a*log(s)).
+// A non-negative integer power is computed by repeated squaring instead, so
+// that the constant term may be zero or negative.
+// Returns s^a. Panics if a is not a non-negative integer and the constant
+// term is not positive.
+func (s Series) Pow(a float64) Series {
+	if a >= 0 && a == math.Trunc(a) && a < 1<<31 {
+		r := NewSeries(s.n, 1)
+		b := s
+		for n := int(a); n > 0; n >>= 1 {
+			if n&1 == 1 {
+				r = r.Mul(b)
+			}
+			b = b.Mul(b)
+		}
+		return r
+	}
+	if s.n == 0 {
+		return s
+	}
+	if !(s.coeff[0] > 0) {
+		panic("poly: series power with nonpositive constant term")
+	}
+	return s.Log().Scale(a).Exp()
+}
+
+// Composes two series, substituting t for x in s, which requires t to have
+// no constant term so that each coefficient of the result is a finite sum.
+// Returns s(t(x)). Panics if the constant term of t is nonzero.
+func (s Series) Compose(t Series) Series {
+	if t.Coeff(0) != 0 {
+		panic("poly: series composition with nonzero constant term")
+	}
+	n := min(s.n, t.n)
+	r := NewSeries(n)
+	for i := n - 1; i >= 0; i-- {
+		r = r.Mul(t)
+		r.coeff[0] += s.coeff[i]
+	}
+	return r
+}
+
+// Computes the compositional inverse of a series using Lagrange inversion.
+// For s = c[1]x + c[2]x^2 + ... with c[1] nonzero, the reversion is the
+// series g with s(g(x)) = g(s(x)) = x, whose coefficients are
+// g[k] = [x^(k-1)] (x/s)^k / k. For example, reverting the series of sin(x)
+// gives the series of arcsin(x).
+// Returns the reversion. Panics unless the constant term is zero and the
+// linear term is nonzero.
+func (s Series) Revert() Series {
+	if s.Coeff(0) != 0 || s.Coeff(1) == 0 {
+		panic("poly: series reversion requires zero constant and nonzero linear term")
+	}
+	h := NewSeries(s.n, 0, 1).Div(s)
+	c := make([]float64, s.n)
+	p := NewSeries(s.n-1, 1)
+	for k := 1; k < s.n; k++ {
+		p = p.Mul(h)
+		c[k] = p.coeff[k-1] / float64(k)
+	}
+	return Series{s.n, c}
+}
@@ -0,0 +1,256 @@
+package poly
+
+import (
+	"math"
+	"testing"
+)
+
+// Compares the orders and coefficients of two series.
+func compareSeries(s, t Series) bool {
+	if s.Order() != t.Order() {
+		return false
+	}
+	for i := 0; i < s.Order(); i++ {
+		if math.Abs(s.Coeff(i)-t.Coeff(i)) > 0.00001 {
+			return false
+		}
+	}
+	return true
+}
+
+// Tests the string representation of series.
+func TestSeriesString(t *testing.T) {
+	cases := []struct {
+		s    Series
+		want string
+	}{
+		{Series{}, "O(1)"},
+		{NewSeries(1, 2), "2.000 + O(x)"},
+		{NewSeries(3, 1, 1, 0.5, 7), "1.000 + x + 0.500x^2 + O(x^3)"},
+		{NewSeries(4, 0, -1), "-x + O(x^4)"},
+		{NewSeries(2), "0.000 + O(x^2)"},
+	}
+	for i, c := range cases {
+		if got := c.s.String(); got != c.want {
+			t.Errorf("case %d: String() == %q, want %q", i, got, c.want)
+		}
+	}
+}
+
+// Tests arithmetic on truncated power series.
+func TestSeriesArith(t *testing.T) {
+	s := NewSeries(5, 1, 2, 3, 4, 5)
+	u := NewSeries(3, 1, -1)
+	cases := []struct {
+		name      string
+		got, want Series
+	}{
+		{"Add", s.Add(u), NewSeries(3, 2, 1, 3)},
+		{"Sub", s.Sub(s), NewSeries(5)},
+		{"Scale", u.Scale(2), NewSeries(3, 2, -2)},
+		{"Mul", s.Mul(u), NewSeries(3, 1, 1, 1)},
+		// The degree does not grow under repeated multiplication.
+		{"Mul self", s.Mul(s).Mul(s), NewSeries(5, 1, 6, 21, 56, 126)},
+		{"Truncate", s.Truncate(2), NewSeries(2, 1, 2)},
+		{"Poly", New(1, 2, 3).Series(2), NewSeries(2, 1, 2)},
+		{"Der", s.Der(), NewSeries(4, 2, 6, 12, 20)},
+		{"Int", u.Int(3), NewSeries(4, 3, 1, -0.5)},
+	}
+	for _, c := range cases {
+		if !compareSeries(c.got, c.want) {
+			t.Errorf("%s == %q, want %q", c.name, c.got, c.want)
+		}
+	}
+	if got, want := s.Poly(), New(1, 2, 3, 4, 5); !comparePoly(got, want) {
+		t.Errorf("Poly() == %q, want %q", got, want)
+	}
+}
+
+// Tests series inversion and division.
+func TestSeriesDiv(t *testing.T) {
+	cases := []struct {
+		name      string
+		got, want Series
+	}{
+		// 1/(1 - x) is the geometric series.
+		{"Inv geometric", NewSeries(6, 1, -1).Inv(), NewSeries(6, 1, 1, 1, 1, 1, 1)},
+		{"Inv", NewSeries(4, 2, 1).Inv(), NewSeries(4, 0.5, -0.25, 0.125, -0.0625)},
+		// x/(1 - x - x^2) generates the Fibonacci numbers.
+		{"Fibonacci", NewSeries(10, 0, 1).Div(NewSeries(10, 1, -1, -1)), NewSeries(10, 0, 1, 1, 2, 3, 5, 8, 13, 21, 34)},
+		// A common factor of x is cancelled, reducing the order.
+		{"cancel", NewSeries(5, 0, 1, 0, -1.0/6).Div(NewSeries(5, 0, 1)), NewSeries(4, 1, 0, -1.0/6)},
+		{"cancel all", NewSeries(2).Div(NewSeries(3, 0, 0, 1)), Series{}},
+	}
+	for _, c := range cases {
+		if !compareSeries(c.got, c.want) {
+			t.Errorf("%s == %q, want %q", c.name, c.got, c.want)
+		}
+	}
+	// s * (1/s) = 1 for a longer series.
+	s := NewSeries(20, 3, -1, 4, 1, -5, 9, 2, -6, 5, 3)
+	one := NewSeries(20, 1)
+	if got := s.Mul(s.Inv()); !compareSeries(got, one) {
+		t.Errorf("s * Inv(s) == %q, want %q", got, one)
+	}
+	for i, f := range []func(){
+		func() { NewSeries(3, 0, 1).Inv() },
+		func() { NewSeries(3, 1).Div(NewSeries(3, 0, 1)) },
+		func() { NewSeries(3, 1).Div(NewSeries(3)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("case %d did not panic", i)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// Returns the series of order n with coefficients f(i).
+func seriesOf(n int, f func(i int) float64) Series {
+	c := make([]float64, n)
+	for i := range c {
+		c[i] = f(i)
+	}
+	return NewSeries(n, c...)
+}
+
+// Tests the exponential, logarithm, square root and power of series.
+func TestSeriesElementary(t *testing.T) {
+	fact := func(i int) float64 { return math.Gamma(float64(i + 1)) }
+	x := NewSeries(12, 0, 1)
+	cases := []struct {
+		name      string
+		got, want Series
+	}{
+		{"Exp x", x.Exp(), seriesOf(12, func(i int) float64 { return 1 / fact(i) })},
+		{"Exp 1+x", x.Add(NewSeries(12, 1)).Exp(), seriesOf(12, func(i int) float64 { return math.E / fact(i) })},
+		// exp(x^2) has only even terms.
+		{"Exp x^2", x.Mul(x).Exp(), seriesOf(12, func(i int) float64 {
+			if i%2 == 1 {
+				return 0
+			}
+			return 1 / fact(i/2)
+		})},
+		{"Log 1+x", NewSeries(12, 1, 1).Log(), seriesOf(12, func(i int) float64 {
+			if i == 0 {
+				return 0
+			}
+			return math.Pow(-1, float64(i+1)) / float64(i)
+		})},
+		{"Log 2", NewSeries(3, 2).Log(), NewSeries(3, math.Ln2)},
+		{"Sqrt 1+x", NewSeries(6, 1, 1).Sqrt(), NewSeries(6, 1, 0.5, -0.125, 0.0625, -0.0390625, 0.02734375)},
+		{"Sqrt square", NewSeries(8, 4, 4, 1).Sqrt(), NewSeries(8, 2, 1)},
+		// sqrt(x^2 + x^3) = x sqrt(1 + x), known to one less term.
+		{"Sqrt x^2", NewSeries(6, 0, 0, 1, 1).Sqrt(), NewSeries(5, 0, 1, 0.5, -0.125, 0.0625)},
+		{"Pow 3", NewSeries(6, 1, 1).Pow(3), NewSeries(6, 1, 3, 3, 1)},
+		{"Pow 0", x.Pow(0), NewSeries(12, 1)},
+		{"Pow int zero constant", x.Pow(2), NewSeries(12, 0, 0, 1)},
+		{"Pow -1", NewSeries(6, 1, -1).Pow(-1), NewSeries(6, 1, 1, 1, 1, 1, 1)},
+		{"Pow 1/2", NewSeries(6, 1, 1).Pow(0.5), NewSeries(6, 1, 1).Sqrt()},
+	}
+	for _, c := range cases {
+		if !compareSeries(c.got, c.want) {
+			t.Errorf("%s == %q, want %q", c.name, c.got, c.want)
+		}
+	}
+	// Exp and Log are inverses.
+	s := NewSeries(15, 0.3, -1, 2, 0.5, -0.25, 1, 3)
+	if got := s.Exp().Log(); !compareSeries(got, s) {
+		t.Errorf("Log(Exp(s)) == %q, want %q", got, s)
+	}
+	if got := s.Exp().Sqrt(); !compareSeries(got, s.Scale(0.5).Exp()) {
+		t.Errorf("Sqrt(Exp(s)) == %q, want %q", got, s.Scale(0.5).Exp())
+	}
+	for i, f := range []func(){
+		func() { NewSeries(3, 0, 1).Log() },
+		func() { NewSeries(3, -1, 1).Log() },
+		func() { NewSeries(3, 0, 1).Sqrt() },
+		func() { NewSeries(3, -4, 1).Sqrt() },
+		func() { NewSeries(3, 0, 1).Pow(0.5) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("case %d did not panic", i)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// Tests composition of series.
+func TestSeriesCompose(t *testing.T) {
+	x := NewSeries(8, 0, 1)
+	cases := []struct {
+		name      string
+		got, want Series
+	}{
+		{"identity", NewSeries(8, 1, 2, 3).Compose(x), NewSeries(8, 1, 2, 3)},
+		// exp(x)^2 = exp(2x).
+		{"scale", x.Exp().Compose(x.Scale(2)), x.Scale(2).Exp()},
+		// 1/(1 - y) with y = x^2 is 1 + x^2 + x^4 + ...
+		{"geometric", NewSeries(8, 1, -1).Inv().Compose(x.Mul(x)), NewSeries(8, 1, 0, 1, 0, 1, 0, 1)},
+		{"order", NewSeries(8, 1, 1, 1).Compose(NewSeries(3, 0, 1, 1)), NewSeries(3, 1, 1, 2)},
+	}
+	for _, c := range cases {
+		if !compareSeries(c.got, c.want) {
+			t.Errorf("%s == %q, want %q", c.name, c.got, c.want)
+		}
+	}
+}
+
+// Tests series reversion.
+func TestSeriesRevert(t *testing.T) {
+	fact := func(i int) float64 { return math.Gamma(float64(i + 1)) }
+	// sin(x) reverts to arcsin(x) = x + x^3/6 + 3x^5/40 + 5x^7/112 + ...
+	sin := seriesOf(9, func(i int) float64 {
+		if i%2 == 0 {
+			return 0
+		}
+		return math.Pow(-1, float64(i/2)) / fact(i)
+	})
+	asin := NewSeries(9, 0, 1, 0, 1.0/6, 0, 3.0/40, 0, 5.0/112)
+	// exp(x) - 1 reverts to log(1 + x).
+	x := NewSeries(10, 0, 1)
+	expm1 := x.Exp().Sub(NewSeries(10, 1))
+	log1p := NewSeries(10, 1, 1).Log()
+	cases := []struct {
+		name      string
+		got, want Series
+	}{
+		{"asin", sin.Revert(), asin},
+		{"log1p", expm1.Revert(), log1p},
+		{"linear", NewSeries(4, 0, 2).Revert(), NewSeries(4, 0, 0.5)},
+		// y = x + x^2 reverts to the Catalan numbers with alternating signs.
+		{"catalan", NewSeries(7, 0, 1, 1).Revert(), NewSeries(7, 0, 1, -1, 2, -5, 14, -42)},
+	}
+	for _, c := range cases {
+		if !compareSeries(c.got, c.want) {
+			t.Errorf("%s == %q, want %q", c.name, c.got, c.want)
+		}
+	}
+	s := NewSeries(12, 0, 1.5, -2, 0.25, 3, -1, 0.5)
+	for _, got := range []Series{s.Compose(s.Revert()), s.Revert().Compose(s)} {
+		if want := NewSeries(12, 0, 1); !compareSeries(got, want) {
+			t.Errorf("composition with reversion == %q, want %q", got, want)
+		}
+	}
+	for i, f := range []func(){
+		func() { NewSeries(3, 1, 1).Revert() },
+		func() { NewSeries(3, 0, 0, 1).Revert() },
+		func() { NewSeries(3, 1).Compose(NewSeries(3, 1, 1)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("case %d did not panic", i)
+				}
+			}()
+			f()
+		}()
+	}
+}
@@ -0,0 +1,46 @@
+package poly
+
+// Creates the Bernoulli polynomial B_n, characterized by
+// B_n(x+1) - B_n(x) = n x^(n-1) and an integral of zero over [0, 1].
+// Its constant term is the Bernoulli number B_n, with B_1 = -1/2. Sums of
+// powers follow from the first property, since the sum of k^m for k from 0
+// to N-1 is (B_(m+1)(N) - B_(m+1)(0))/(m+1).
+// It is computed from the identity
+// sum of C(n+1,k) B_k(x) for k from 0 to n = (n+1) x^n.
+// Panics if n is negative.
+func Bernoulli(n int) Poly {
+	if n < 0 {
+		panic("poly: negative degree")
+	}
+	binom := pascal(n + 1)
+	b := make([]Poly, n+1)
+	for m := range b {
+		s := Monomial(float64(m+1), m)
+		for k := 0; k < m; k++ {
+			s = s.Sub(b[k].Scale(binom[m+1][k]))
+		}
+		b[m] = s.Scale(1 / float64(m+1))
+	}
+	return b[n]
+}
+
+// Creates the Euler polynomial E_n, characterized by
+// E_n(x+1) + E_n(x) = 2 x^n. The Euler numbers are 2^n E_n(1/2).
+// It is computed from the identity
+// 2 E_n(x) + sum of C(n,k) E_k(x) for k from 0 to n-1 = 2 x^n.
+// Panics if n is negative.
+func Euler(n int) Poly {
+	if n < 0 {
+		panic("poly: negative degree")
+	}
+	binom := pascal(n)
+	e := make([]Poly, n+1)
+	for m := range e {
+		s := Monomial(2, m)
+		for k := 0; k < m; k++ {
+			s = s.Sub(e[k].Scale(binom[m][k]))
+		}
+		e[m] = s.Scale(0.5)
+	}
+	return e[n]
+}
@@ -0,0 +1,100 @@
+package poly
+
+import (
+	"math"
+	"testing"
+)
+
+// Tests that Bernoulli polynomials are generated correctly.
+func TestBernoulli(t *testing.T) {
+	cases := []struct {
+		n    int
+		want Poly
+	}{
+		{0, New(1)},
+		{1, New(-0.5, 1)},
+		{2, New(1.0/6, -1, 1)},
+		{3, New(0, 0.5, -1.5, 1)},
+		{4, New(-1.0/30, 0, 1, -2, 1)},
+		{5, New(0, -1.0/6, 0, 5.0/3, -2.5, 1)},
+	}
+	for i, c := range cases {
+		if got := Bernoulli(c.n); !comparePoly(got, c.want) {
+			t.Errorf("case %d: Bernoulli(%d) == %q, want %q", i, c.n, got, c.want)
+		}
+	}
+}
+
+// Tests the difference equation of Bernoulli polynomials and the Bernoulli
+// numbers.
+func TestBernoulliProperties(t *testing.T) {
+	numbers := []float64{1, -0.5, 1.0 / 6, 0, -1.0 / 30, 0, 1.0 / 42, 0, -1.0 / 30, 0, 5.0 / 66}
+	for n, want := range numbers {
+		b := Bernoulli(n)
+		if got := b.Coeff(0); math.Abs(got-want) > 1e-9 {
+			t.Errorf("Bernoulli(%d).Coeff(0) == %g, want %g", n, got, want)
+		}
+		if n == 0 {
+			continue
+		}
+		if got, want := b.Shift(1).Sub(b), Monomial(float64(n), n-1); !comparePoly(got, want) {
+			t.Errorf("Bernoulli(%d)(x+1) - Bernoulli(%d)(x) == %q, want %q", n, n, got, want)
+		}
+	}
+	// The sum of k^3 for k from 0 to 9 is 2025.
+	b4 := Bernoulli(4)
+	if got := (b4.Eval(10) - b4.Eval(0)) / 4; math.Abs(got-2025) > 1e-6 {
+		t.Errorf("sum of cubes == %f, want 2025", got)
+	}
+}
+
+// Tests that Euler polynomials are generated correctly.
+func TestEuler(t *testing.T) {
+	cases := []struct {
+		n    int
+		want Poly
+	}{
+		{0, New(1)},
+		{1, New(-0.5, 1)},
+		{2, New(0, -1, 1)},
+		{3, New(0.25, 0, -1.5, 1)},
+		{4, New(0, 1, 0, -2, 1)},
+	}
+	for i, c := range cases {
+		if got := Euler(c.n); !comparePoly(got, c.want) {
+			t.Errorf("case %d: Euler(%d) == %q, want %q", i, c.n, got, c.want)
+		}
+	}
+}
+
+// Tests the functional equation of Euler polynomials and the Euler numbers.
+func TestEulerProperties(t *testing.T) {
+	numbers := []float64{1, 0, -1, 0, 5, 0, -61, 0, 1385}
+	for n, want := range numbers {
+		e := Euler(n)
+		if got := math.Pow(2, float64(n)) * e.Eval(0.5); math.Abs(got-want) > 1e-6 {
+			t.Errorf("2^%d Euler(%d).Eval(0.5) == %g, want %g", n, n, got, want)
+		}
+		if got, want := e.Shift(1).Add(e), Monomial(2, n); !comparePoly(got, want) {
+			t.Errorf("Euler(%d)(x+1) + Euler(%d)(x) == %q, want %q", n, n, got, want)
+		}
+	}
+}
+
+// Tests that Bernoulli and Euler panic on negative degrees.
+func TestSpecialPanic(t *testing.T) {
+	gens := map[string]func(int) Poly{
+		"Bernoulli": Bernoulli,
+		"Euler":     Euler,
+	}
+	for name, gen := range gens {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s(-1) did not panic", name)
+				}
+			}()
+			gen(-1)
+		}()
+	}
+}
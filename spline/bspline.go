@@ -0,0 +1,220 @@
+package spline
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	poly "github.com/alanwj/go-poly"
+)
+
+// BSpline is a spline function of a given degree represented by its
+// coefficients in the B-spline basis of a knot vector.
+// With n coefficients and degree k there are n+k+1 nondecreasing knots t, and
+// the spline is defined on [t[k], t[n]]. Beyond that interval the first or
+// last nonempty span is extended. A curve in several dimensions is
+// represented by one BSpline per coordinate over the same knots.
+type BSpline struct {
+	deg   int
+	knots []float64
+	coeff []float64
+}
+
+// Creates a B-spline from its knot vector, degree and coefficients, which
+// for a curve are the control points.
+// Returns an error if deg is negative, if there are fewer than deg+1
+// coefficients, if the number of knots is not len(coeff)+deg+1, if the knots
+// decrease, or if the domain [t[deg], t[len(coeff)]] is empty.
+func NewBSpline(knots []float64, deg int, coeff []float64) (*BSpline, error) {
+	if err := checkKnots(knots, deg); err != nil {
+		return nil, err
+	}
+	n := len(coeff)
+	if n < deg+1 {
+		return nil, fmt.Errorf("spline: %d coefficients for degree %d", n, deg)
+	}
+	if len(knots) != n+deg+1 {
+		return nil, fmt.Errorf("spline: %d knots for %d coefficients of degree %d, want %d", len(knots), n, deg, n+deg+1)
+	}
+	if !(knots[deg] < knots[n]) {
+		return nil, errors.New("spline: empty domain")
+	}
+	return &BSpline{
+		deg:   deg,
+		knots: append([]float64(nil), knots...),
+		coeff: append([]float64(nil), coeff...),
+	}, nil
+}
+
+// Checks that a knot vector is nondecreasing and the degree is valid.
+func checkKnots(knots []float64, deg int) error {
+	if deg < 0 {
+		return fmt.Errorf("spline: negative degree %d", deg)
+	}
+	for i := 1; i < len(knots); i++ {
+		if !(knots[i] >= knots[i-1]) {
+			return fmt.Errorf("spline: knots decrease at index %d", i)
+		}
+	}
+	return nil
+}
+
+// Evaluates the B-spline basis function N_(i,deg) of the given knot vector
+// at x, using the Cox-de Boor recursion.
+// The basis functions are right continuous, except at the last knot where the
+// last nonempty span is taken to be closed, so that on [t[deg], t[n]] the
+// basis functions sum to one. Returns 0 outside the support [t[i],
+// t[i+deg+1]]. Panics if the knots decrease, if deg is negative, or if i is
+// not the index of a basis function.
+func Basis(knots []float64, deg, i int, x float64) float64 {
+	if err := checkKnots(knots, deg); err != nil {
+		panic(err)
+	}
+	if i < 0 || i+deg+1 >= len(knots) {
+		panic("spline: basis function index out of range")
+	}
+	t := knots
+	last := t[len(t)-1]
+	n := make([]float64, deg+1)
+	for j := range n {
+		lo, hi := t[i+j], t[i+j+1]
+		if lo < hi && (lo <= x && x < hi || x == last && hi == last) {
+			n[j] = 1
+		}
+	}
+	for p := 1; p <= deg; p++ {
+		for j := 0; j+p <= deg; j++ {
+			k := i + j
+			var v float64
+			if d := t[k+p] - t[k]; d != 0 {
+				v += (x - t[k]) / d * n[j]
+			}
+			if d := t[k+p+1] - t[k+1]; d != 0 {
+				v += (t[k+p+1] - x) / d * n[j+1]
+			}
+			n[j] = v
+		}
+	}
+	return n[0]
+}
+
+// Returns the degree of the B-spline.
+func (b *BSpline) Degree() int {
+	return b.deg
+}
+
+// Returns the knot vector, which must not be modified.
+func (b *BSpline) Knots() []float64 {
+	return b.knots
+}
+
+// Returns the coefficients, which must not be modified.
+func (b *BSpline) Coefficients() []float64 {
+	return b.coeff
+}
+
+// Returns the index k of the nonempty span [t[k], t[k+1]) of the domain
+// that applies at x.
+func (b *BSpline) span(x float64) int {
+	t, lo, hi := b.knots, b.deg, len(b.coeff)
+	// The largest k in [lo, hi) with t[k] <= x, moved to a nonempty span,
+	// which only differs for x outside the domain.
+	k := sort.Search(hi-lo, func(j int) bool { return t[lo+j] > x }) + lo - 1
+	if k < lo {
+		k = lo
+	}
+	for k > lo && t[k] == t[k+1] {
+		k--
+	}
+	for t[k] == t[k+1] {
+		k++
+	}
+	return k
+}
+
+// Evaluates the B-spline at x using de Boor's algorithm.
+func (b *BSpline) Eval(x float64) float64 {
+	k := b.span(x)
+	t, p := b.knots, b.deg
+	d := make([]float64, p+1)
+	copy(d, b.coeff[k-p:k+1])
+	for r := 1; r <= p; r++ {
+		for j := p; j >= r; j-- {
+			i := j + k - p
+			a := (x - t[i]) / (t[i+p+1-r] - t[i])
+			d[j] = (1-a)*d[j-1] + a*d[j]
+		}
+	}
+	return d[p]
+}
+
+// Returns the polynomial in the local variable x - t[k] that agrees with the
+// B-spline on the nonempty span [t[k], t[k+1]], by running de Boor's
+// algorithm with polynomial coefficients.
+func (b *BSpline) spanPoly(k int) poly.Poly {
+	t, p := b.knots, b.deg
+	d := make([]poly.Poly, p+1)
+	for j := range d {
+		d[j] = poly.New(b.coeff[j+k-p])
+	}
+	for r := 1; r <= p; r++ {
+		for j := p; j >= r; j-- {
+			i := j + k - p
+			den := t[i+p+1-r] - t[i]
+			a := poly.New((t[k]-t[i])/den, 1/den)
+			d[j] = d[j-1].Sub(a.Mul(d[j-1])).Add(a.Mul(d[j]))
+		}
+	}
+	return d[p]
+}
+
+// Converts the B-spline to a piecewise polynomial with one piece for each
+// nonempty span of the domain. Each piece is in terms of x minus the knot at
+// the start of its span, as for poly.NewPiecewiseLocal, which keeps the
+// coefficients well conditioned far from the origin.
+func (b *BSpline) Piecewise() poly.Piecewise {
+	var breaks []float64
+	var pieces []poly.Poly
+	for k := b.deg; k < len(b.coeff); k++ {
+		if b.knots[k] == b.knots[k+1] {
+			continue
+		}
+		breaks = append(breaks, b.knots[k])
+		pieces = append(pieces, b.spanPoly(k))
+	}
+	breaks = append(breaks, b.knots[len(b.coeff)])
+	pw, err := poly.NewPiecewiseLocal(breaks, pieces)
+	if err != nil {
+		// The breakpoints are distinct knots in increasing order.
+		panic(err)
+	}
+	return pw
+}
+
+// Inserts a knot at x using Boehm's algorithm, returning a B-spline with one
+// more knot and coefficient that is the same function on the domain.
+// Panics if x is outside the domain.
+func (b *BSpline) InsertKnot(x float64) *BSpline {
+	t, p, n := b.knots, b.deg, len(b.coeff)
+	if x < t[p] || x > t[n] {
+		panic("spline: knot outside domain")
+	}
+	k := b.span(x)
+	c := make([]float64, n+1)
+	for i := range c {
+		switch {
+		case i <= k-p:
+			c[i] = b.coeff[i]
+		case i > k:
+			c[i] = b.coeff[i-1]
+		default:
+			a := (x - t[i]) / (t[i+p] - t[i])
+			c[i] = (1-a)*b.coeff[i-1] + a*b.coeff[i]
+		}
+	}
+	knots := make([]float64, 0, len(t)+1)
+	knots = append(knots, t[:k+1]...)
+	knots = append(knots, x)
+	knots = append(knots, t[k+1:]...)
+	return &BSpline{deg: p, knots: knots, coeff: c}
+}
@@ -0,0 +1,184 @@
+package spline
+
+import (
+	"math"
+	"testing"
+
+	poly "github.com/alanwj/go-poly"
+)
+
+// Tests that B-spline basis functions are evaluated correctly.
+func TestBasis(t *testing.T) {
+	uniform := []float64{0, 1, 2, 3, 4}
+	bezier := []float64{0, 0, 0, 0, 1, 1, 1, 1}
+	cases := []struct {
+		knots  []float64
+		deg, i int
+		x      float64
+		want   float64
+	}{
+		{uniform, 0, 1, 1.5, 1},
+		{uniform, 0, 1, 2, 0},
+		{uniform, 1, 0, 1, 1},
+		{uniform, 1, 0, 0.5, 0.5},
+		{uniform, 3, 0, 1, 1.0 / 6},
+		{uniform, 3, 0, 2, 2.0 / 3},
+		{uniform, 3, 0, 3, 1.0 / 6},
+		{uniform, 3, 0, -1, 0},
+		{uniform, 3, 0, 5, 0},
+		// With clamped knots the basis is the Bernstein basis.
+		{bezier, 3, 0, 0.25, 27.0 / 64},
+		{bezier, 3, 1, 0.25, 27.0 / 64},
+		{bezier, 3, 2, 0.25, 9.0 / 64},
+		{bezier, 3, 3, 0.25, 1.0 / 64},
+		{bezier, 3, 3, 1, 1},
+		{bezier, 3, 0, 0, 1},
+	}
+	for i, c := range cases {
+		if got := Basis(c.knots, c.deg, c.i, c.x); math.Abs(got-c.want) > 0.00001 {
+			t.Errorf("case %d: Basis(%v, %d, %d, %f) == %f, want %f", i, c.knots, c.deg, c.i, c.x, got, c.want)
+		}
+	}
+}
+
+// Tests that the basis functions sum to one on the domain.
+func TestBasisPartitionOfUnity(t *testing.T) {
+	knots := []float64{0, 0, 0, 1, 2, 2, 3.5, 5, 5, 5}
+	deg := 2
+	n := len(knots) - deg - 1
+	for x := knots[deg]; x <= knots[n]; x += 0.125 {
+		var sum float64
+		for i := 0; i < n; i++ {
+			sum += Basis(knots, deg, i, x)
+		}
+		if math.Abs(sum-1) > 0.00001 {
+			t.Errorf("sum of Basis(%v, %d, i, %f) == %f, want 1", knots, deg, x, sum)
+		}
+	}
+}
+
+// Tests that B-splines evaluate as the sum of their basis functions and
+// convert to matching piecewise polynomials.
+func TestBSpline(t *testing.T) {
+	cases := []struct {
+		knots []float64
+		deg   int
+		coeff []float64
+	}{
+		{[]float64{0, 1}, 0, []float64{3}},
+		{[]float64{0, 0, 1, 2, 2}, 1, []float64{1, -1, 2}},
+		{[]float64{0, 0, 0, 0, 1, 1, 1, 1}, 3, []float64{0, 1, 3, 2}},
+		{[]float64{0, 1, 2, 3, 4, 5, 6, 7}, 3, []float64{1, 2, -1, 0}},
+		{[]float64{0, 0, 0, 1, 2, 2, 3.5, 5, 5, 5}, 2, []float64{1, 0, -2, 3, 1, 0, 2}},
+	}
+	for i, c := range cases {
+		b, err := NewBSpline(c.knots, c.deg, c.coeff)
+		if err != nil {
+			t.Errorf("case %d: NewBSpline(%v, %d, %v) returned error %v", i, c.knots, c.deg, c.coeff, err)
+			continue
+		}
+		pw := b.Piecewise()
+		lo, hi := c.knots[c.deg], c.knots[len(c.coeff)]
+		for k := 0; k <= 40; k++ {
+			x := lo + (hi-lo)*float64(k)/40
+			var want float64
+			for j, cj := range c.coeff {
+				want += cj * Basis(c.knots, c.deg, j, x)
+			}
+			if got := b.Eval(x); math.Abs(got-want) > 0.00001 {
+				t.Errorf("case %d: Eval(%f) == %f, want %f", i, x, got, want)
+			}
+			if got := pw.Eval(x); math.Abs(got-want) > 0.00001 {
+				t.Errorf("case %d: Piecewise().Eval(%f) == %f, want %f", i, x, got, want)
+			}
+		}
+	}
+}
+
+// Tests that a clamped B-spline with a single span is a Bézier curve.
+func TestBSplineBezier(t *testing.T) {
+	ctrl := []float64{0, 1, 3, 2}
+	b, err := NewBSpline([]float64{1, 1, 1, 1, 3, 3, 3, 3}, 3, ctrl)
+	if err != nil {
+		t.Fatalf("NewBSpline() returned error %v", err)
+	}
+	pw := b.Piecewise()
+	if pw.Len() != 1 {
+		t.Fatalf("Piecewise().Len() == %d, want 1", pw.Len())
+	}
+	if got, want := pw.Piece(0), poly.FromBernstein(ctrl, 1, 3); !comparePoly(got, want) {
+		t.Errorf("Piecewise().Piece(0) == %q, want %q", got, want)
+	}
+}
+
+// Tests that the piecewise form of a B-spline is as accurate for knots far
+// from the origin as for the same knots shifted to it.
+func TestBSplinePiecewiseOffset(t *testing.T) {
+	knots := []float64{0, 0, 0, 0, 0.5, 1.5, 2, 2, 2, 2}
+	coeff := []float64{1, 2, -1, 0, 3, 1}
+	ref, err := NewBSpline(knots, 3, coeff)
+	if err != nil {
+		t.Fatalf("NewBSpline() returned error %v", err)
+	}
+	for _, x0 := range []float64{1e5, 1e6, -1e6} {
+		shifted := make([]float64, len(knots))
+		for i, k := range knots {
+			shifted[i] = k + x0
+		}
+		b, err := NewBSpline(shifted, 3, coeff)
+		if err != nil {
+			t.Fatalf("NewBSpline() returned error %v", err)
+		}
+		pw := b.Piecewise()
+		for k := 0; k <= 40; k++ {
+			x := 2 * float64(k) / 40
+			if got, want := pw.Eval(x+x0), ref.Eval(x); math.Abs(got-want) > 1e-9 {
+				t.Errorf("Piecewise() offset by %g: Eval(%f) == %.12f, want %.12f", x0, x+x0, got, want)
+			}
+		}
+	}
+}
+
+// Tests that knot insertion does not change the function.
+func TestInsertKnot(t *testing.T) {
+	knots := []float64{0, 0, 0, 0, 1, 2, 4, 4, 4, 4}
+	b, err := NewBSpline(knots, 3, []float64{1, 2, -1, 0, 3, 1})
+	if err != nil {
+		t.Fatalf("NewBSpline() returned error %v", err)
+	}
+	for _, x := range []float64{0, 0.5, 1, 1, 3, 4} {
+		nb := b.InsertKnot(x)
+		if len(nb.Knots()) != len(b.Knots())+1 || len(nb.Coefficients()) != len(b.Coefficients())+1 {
+			t.Errorf("InsertKnot(%f) has %d knots and %d coefficients, want %d and %d", x, len(nb.Knots()), len(nb.Coefficients()), len(b.Knots())+1, len(b.Coefficients())+1)
+		}
+		for k := 0; k <= 40; k++ {
+			y := 4 * float64(k) / 40
+			if got, want := nb.Eval(y), b.Eval(y); math.Abs(got-want) > 0.00001 {
+				t.Errorf("InsertKnot(%f).Eval(%f) == %f, want %f", x, y, got, want)
+			}
+		}
+		b = nb
+	}
+}
+
+// Tests that invalid B-splines are rejected.
+func TestNewBSplineError(t *testing.T) {
+	cases := []struct {
+		knots []float64
+		deg   int
+		coeff []float64
+	}{
+		{[]float64{0, 1}, -1, []float64{1, 2}},
+		{[]float64{0, 1, 2}, 2, []float64{1}},
+		{[]float64{0, 1, 2}, 1, []float64{1, 2}},
+		{[]float64{0, 2, 1, 3}, 1, []float64{1, 2}},
+		{[]float64{0, 1, 1, 2}, 2, []float64{1}},
+		{[]float64{0, 1, 1, 1, 2}, 2, []float64{1, 2}},
+		{[]float64{0, 1, 1, 1, 2}, 1, []float64{1, 2, 3}},
+	}
+	for i, c := range cases {
+		if _, err := NewBSpline(c.knots, c.deg, c.coeff); err == nil {
+			t.Errorf("case %d: NewBSpline(%v, %d, %v) succeeded, want error", i, c.knots, c.deg, c.coeff)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+// Package spline constructs cubic interpolating splines and B-splines.
+//
+// A spline is a piecewise polynomial function whose pieces join smoothly at
+// the knots. The pieces are available as poly.Poly values, so they can be
+// differentiated, integrated or searched for roots with package poly.
+package spline
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	poly "github.com/alanwj/go-poly"
+)
+
+// Spline is a piecewise polynomial function of one variable.
+// Piece i applies on [x[i], x[i+1]]; the first and last pieces are also used
+// to extrapolate below x[0] and above x[n].
+type Spline struct {
+	x []float64
+	// Piece i in terms of the local variable t = x - x[i], which keeps the
+	// coefficients well conditioned far from the origin.
+	seg []poly.Poly
+}
+
+// Creates the natural cubic spline through the points (xs[i], ys[i]), whose
+// second derivative vanishes at both ends.
+// The xs must be strictly increasing, and there must be at least two points.
+func Natural(xs, ys []float64) (*Spline, error) {
+	return cubic(xs, ys, natural, 0, 0)
+}
+
+// Creates the clamped cubic spline through the points (xs[i], ys[i]), whose
+// first derivative is d0 at xs[0] and dn at the last point.
+// The xs must be strictly increasing, and there must be at least two points.
+func Clamped(xs, ys []float64, d0, dn float64) (*Spline, error) {
+	return cubic(xs, ys, clamped, d0, dn)
+}
+
+// Creates the not-a-knot cubic spline through the points (xs[i], ys[i]),
+// whose third derivative is continuous at the second and second to last
+// points, so that the first two and last two pieces are each a single cubic.
+// With three points the result is the interpolating parabola, and with two
+// points the line through them.
+// The xs must be strictly increasing, and there must be at least two points.
+func NotAKnot(xs, ys []float64) (*Spline, error) {
+	return cubic(xs, ys, notAKnot, 0, 0)
+}
+
+// Boundary conditions for cubic splines.
+type boundary int
+
+const (
+	natural boundary = iota
+	clamped
+	notAKnot
+)
+
+// Creates a cubic spline by solving the tridiagonal system for the second
+// derivatives M[i] at the knots.
+func cubic(xs, ys []float64, bc boundary, d0, dn float64) (*Spline, error) {
+	if err := checkPoints(xs, ys); err != nil {
+		return nil, err
+	}
+	n := len(xs) - 1
+	h := make([]float64, n)
+	d := make([]float64, n)
+	for i := range h {
+		h[i] = xs[i+1] - xs[i]
+		d[i] = (ys[i+1] - ys[i]) / h[i]
+	}
+	sub := make([]float64, n+1)
+	diag := make([]float64, n+1)
+	sup := make([]float64, n+1)
+	rhs := make([]float64, n+1)
+	for i := 1; i < n; i++ {
+		sub[i], diag[i], sup[i] = h[i-1], 2*(h[i-1]+h[i]), h[i]
+		rhs[i] = 6 * (d[i] - d[i-1])
+	}
+	var m []float64
+	switch {
+	case bc == clamped:
+		diag[0], sup[0], rhs[0] = 2*h[0], h[0], 6*(d[0]-d0)
+		sub[n], diag[n], rhs[n] = h[n-1], 2*h[n-1], 6*(dn-d[n-1])
+		m = solveTridiag(sub, diag, sup, rhs)
+	case bc == notAKnot && n == 2:
+		// The parabola through three points has constant second derivative.
+		c := 2 * (d[1] - d[0]) / (h[0] + h[1])
+		m = []float64{c, c, c}
+	case bc == notAKnot && n > 2:
+		// The conditions h1*M0 - (h0+h1)*M1 + h0*M2 = 0 and its mirror at
+		// the other end are used to eliminate M0 and Mn from the first and
+		// last interior equations.
+		h0, h1 := h[0], h[1]
+		diag[1] = (h0 + h1) * (h0 + 2*h1) / h1
+		sup[1] = (h1 - h0) * (h1 + h0) / h1
+		g0, g1 := h[n-1], h[n-2]
+		diag[n-1] = (g0 + g1) * (g0 + 2*g1) / g1
+		sub[n-1] = (g1 - g0) * (g1 + g0) / g1
+		m = make([]float64, n+1)
+		copy(m[1:n], solveTridiag(sub[1:n], diag[1:n], sup[1:n], rhs[1:n]))
+		m[0] = ((h0+h1)*m[1] - h0*m[2]) / h1
+		m[n] = ((g0+g1)*m[n-1] - g0*m[n-2]) / g1
+	default:
+		// Natural, or not-a-knot through two points, which is the line.
+		diag[0], diag[n] = 1, 1
+		m = solveTridiag(sub, diag, sup, rhs)
+	}
+	s := &Spline{x: append([]float64(nil), xs...), seg: make([]poly.Poly, n)}
+	for i := range s.seg {
+		s.seg[i] = poly.New(
+			ys[i],
+			d[i]-h[i]*(2*m[i]+m[i+1])/6,
+			m[i]/2,
+			(m[i+1]-m[i])/(6*h[i]))
+	}
+	return s, nil
+}
+
+// Checks that xs and ys describe at least two points with strictly
+// increasing xs.
+func checkPoints(xs, ys []float64) error {
+	if len(xs) != len(ys) {
+		return fmt.Errorf("spline: %d x values but %d y values", len(xs), len(ys))
+	}
+	if len(xs) < 2 {
+		return errors.New("spline: need at least two points")
+	}
+	for i := 1; i < len(xs); i++ {
+		if !(xs[i] > xs[i-1]) {
+			return fmt.Errorf("spline: x values not strictly increasing at index %d", i)
+		}
+	}
+	for _, y := range ys {
+		if math.IsNaN(y) || math.IsInf(y, 0) {
+			return fmt.Errorf("spline: invalid y value %g", y)
+		}
+	}
+	return nil
+}
+
+// Solves a tridiagonal system by the Thomas algorithm, where row i is
+// sub[i]*x[i-1] + diag[i]*x[i] + sup[i]*x[i+1] = rhs[i]. The systems solved
+// here are diagonally dominant, so no pivoting is needed. The inputs are not
+// modified.
+func solveTridiag(sub, diag, sup, rhs []float64) []float64 {
+	n := len(diag)
+	c := make([]float64, n)
+	x := make([]float64, n)
+	c[0] = sup[0] / diag[0]
+	x[0] = rhs[0] / diag[0]
+	for i := 1; i < n; i++ {
+		w := diag[i] - sub[i]*c[i-1]
+		c[i] = sup[i] / w
+		x[i] = (rhs[i] - sub[i]*x[i-1]) / w
+	}
+	for i := n - 2; i >= 0; i-- {
+		x[i] -= c[i] * x[i+1]
+	}
+	return x
+}
+
+// Returns the knots of the spline, which must not be modified.
+func (s *Spline) Knots() []float64 {
+	return s.x
+}
+
+// Returns the number of pieces of the spline.
+func (s *Spline) Len() int {
+	return len(s.seg)
+}
+
+// Returns the index of the piece that applies at x.
+func (s *Spline) find(x float64) int {
+	i := sort.SearchFloat64s(s.x, x) - 1
+	if i < 0 {
+		return 0
+	}
+	if i >= len(s.seg) {
+		return len(s.seg) - 1
+	}
+	return i
+}
+
+// Evaluates the spline at x.
+// Outside the knots the first or last piece is extended.
+func (s *Spline) Eval(x float64) float64 {
+	i := s.find(x)
+	return s.seg[i].Eval(x - s.x[i])
+}
+
+// Computes the derivative of the spline, a spline with the same knots whose
+// pieces are the derivatives of the pieces of s.
+func (s *Spline) Der() *Spline {
+	ds := &Spline{x: s.x, seg: make([]poly.Poly, len(s.seg))}
+	for i, p := range s.seg {
+		ds.seg[i] = p.Der()
+	}
+	return ds
+}
+
+// Returns piece i of the spline as a polynomial in x, which agrees with the
+// spline on [Knots()[i], Knots()[i+1]].
+func (s *Spline) Segment(i int) poly.Poly {
+	return s.seg[i].Shift(-s.x[i])
+}
+
+// Returns the string representation of the spline, listing each interval
+// and its piece.
+func (s *Spline) String() string {
+	str := ""
+	for i := range s.seg {
+		if i > 0 {
+			str += "; "
+		}
+		str += fmt.Sprintf("[%g, %g]: %v", s.x[i], s.x[i+1], s.Segment(i))
+	}
+	return str
+}
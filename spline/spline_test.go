@@ -0,0 +1,167 @@
+package spline
+
+import (
+	"math"
+	"testing"
+
+	poly "github.com/alanwj/go-poly"
+)
+
+// Compares two polynomials coefficient by coefficient.
+func comparePoly(p, q poly.Poly) bool {
+	if p.Deg() != q.Deg() {
+		return false
+	}
+	for i := 0; i <= p.Deg(); i++ {
+		if math.Abs(p.Coeff(i)-q.Coeff(i)) > 0.00001 {
+			return false
+		}
+	}
+	return true
+}
+
+// Tests that the pieces of natural splines are computed correctly.
+func TestNatural(t *testing.T) {
+	cases := []struct {
+		xs, ys []float64
+		want   []poly.Poly
+	}{
+		{[]float64{0, 1}, []float64{1, 3}, []poly.Poly{poly.New(1, 2)}},
+		{[]float64{0, 1, 3}, []float64{1, 2, 4}, []poly.Poly{poly.New(1, 1), poly.New(1, 1)}},
+		{[]float64{0, 1, 2}, []float64{0, 1, 0}, []poly.Poly{
+			poly.New(0, 1.5, 0, -0.5),
+			poly.New(-1, 4.5, -3, 0.5),
+		}},
+	}
+	for i, c := range cases {
+		s, err := Natural(c.xs, c.ys)
+		if err != nil {
+			t.Errorf("case %d: Natural(%v, %v) returned error %v", i, c.xs, c.ys, err)
+			continue
+		}
+		if s.Len() != len(c.want) {
+			t.Errorf("case %d: Natural(%v, %v).Len() == %d, want %d", i, c.xs, c.ys, s.Len(), len(c.want))
+			continue
+		}
+		for j, want := range c.want {
+			if got := s.Segment(j); !comparePoly(got, want) {
+				t.Errorf("case %d: Natural(%v, %v).Segment(%d) == %q, want %q", i, c.xs, c.ys, j, got, want)
+			}
+		}
+	}
+}
+
+// Tests that clamped and not-a-knot splines reproduce cubics exactly.
+func TestReproduceCubic(t *testing.T) {
+	p := poly.New(1, -2, 0.5, 0.25)
+	dp := p.Der()
+	xs := []float64{-2, -1.5, 0, 0.25, 1, 3}
+	ys := p.EvalAll(xs)
+	clamped, err := Clamped(xs, ys, dp.Eval(xs[0]), dp.Eval(xs[len(xs)-1]))
+	if err != nil {
+		t.Fatalf("Clamped() returned error %v", err)
+	}
+	nak, err := NotAKnot(xs, ys)
+	if err != nil {
+		t.Fatalf("NotAKnot() returned error %v", err)
+	}
+	for i := 0; i < len(xs)-1; i++ {
+		if got := clamped.Segment(i); !comparePoly(got, p) {
+			t.Errorf("Clamped().Segment(%d) == %q, want %q", i, got, p)
+		}
+		if got := nak.Segment(i); !comparePoly(got, p) {
+			t.Errorf("NotAKnot().Segment(%d) == %q, want %q", i, got, p)
+		}
+	}
+}
+
+// Tests that not-a-knot splines through few points are the interpolating
+// polynomial.
+func TestNotAKnotFewPoints(t *testing.T) {
+	cases := []struct {
+		xs, ys []float64
+		want   poly.Poly
+	}{
+		{[]float64{1, 2}, []float64{3, 5}, poly.New(1, 2)},
+		{[]float64{-1, 0, 2}, []float64{1, 0, 4}, poly.New(0, 0, 1)},
+		{[]float64{0, 1, 2, 4}, []float64{0, 1, 8, 64}, poly.New(0, 0, 0, 1)},
+	}
+	for i, c := range cases {
+		s, err := NotAKnot(c.xs, c.ys)
+		if err != nil {
+			t.Errorf("case %d: NotAKnot(%v, %v) returned error %v", i, c.xs, c.ys, err)
+			continue
+		}
+		for j := 0; j < s.Len(); j++ {
+			if got := s.Segment(j); !comparePoly(got, c.want) {
+				t.Errorf("case %d: NotAKnot(%v, %v).Segment(%d) == %q, want %q", i, c.xs, c.ys, j, got, c.want)
+			}
+		}
+	}
+}
+
+// Tests that splines interpolate the data with continuous first and second
+// derivatives, and that Eval and Der agree with the pieces.
+func TestSmoothness(t *testing.T) {
+	xs := []float64{0, 0.5, 1.5, 2, 3.5, 4, 6}
+	ys := []float64{1, -1, 2, 0.5, 0, 3, 1}
+	ctors := map[string]func() (*Spline, error){
+		"Natural":  func() (*Spline, error) { return Natural(xs, ys) },
+		"Clamped":  func() (*Spline, error) { return Clamped(xs, ys, 1, -2) },
+		"NotAKnot": func() (*Spline, error) { return NotAKnot(xs, ys) },
+	}
+	for name, ctor := range ctors {
+		s, err := ctor()
+		if err != nil {
+			t.Errorf("%s() returned error %v", name, err)
+			continue
+		}
+		d, d2 := s.Der(), s.Der().Der()
+		for i, x := range xs {
+			if got := s.Eval(x); math.Abs(got-ys[i]) > 0.00001 {
+				t.Errorf("%s().Eval(%f) == %f, want %f", name, x, got, ys[i])
+			}
+			if i == 0 || i == len(xs)-1 {
+				continue
+			}
+			for _, f := range []*Spline{s, d, d2} {
+				l, r := f.Segment(i-1).Eval(x), f.Segment(i).Eval(x)
+				if math.Abs(l-r) > 0.00001 {
+					t.Errorf("%s(): pieces %d and %d differ at %f: %f != %f", name, i-1, i, x, l, r)
+				}
+			}
+		}
+		for _, x := range []float64{-1, 0.25, 1.7, 5, 7} {
+			i := s.find(x)
+			if got, want := d.Eval(x), s.Segment(i).Der().Eval(x); math.Abs(got-want) > 0.00001 {
+				t.Errorf("%s().Der().Eval(%f) == %f, want %f", name, x, got, want)
+			}
+		}
+	}
+}
+
+// Tests that invalid data is rejected.
+func TestError(t *testing.T) {
+	cases := []struct {
+		xs, ys []float64
+	}{
+		{nil, nil},
+		{[]float64{1}, []float64{1}},
+		{[]float64{1, 2}, []float64{1}},
+		{[]float64{1, 1}, []float64{1, 2}},
+		{[]float64{2, 1}, []float64{1, 2}},
+		{[]float64{1, math.NaN()}, []float64{1, 2}},
+		{[]float64{1, 2}, []float64{1, math.Inf(1)}},
+	}
+	for i, c := range cases {
+		if _, err := Natural(c.xs, c.ys); err == nil {
+			t.Errorf("case %d: Natural(%v, %v) succeeded, want error", i, c.xs, c.ys)
+		}
+		if _, err := NotAKnot(c.xs, c.ys); err == nil {
+			t.Errorf("case %d: NotAKnot(%v, %v) succeeded, want error", i, c.xs, c.ys)
+		}
+		if _, err := Clamped(c.xs, c.ys, 0, 0); err == nil {
+			t.Errorf("case %d: Clamped(%v, %v, 0, 0) succeeded, want error", i, c.xs, c.ys)
+		}
+	}
+}
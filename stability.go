@@ -0,0 +1,111 @@
+package poly
+
+import "math"
+
+// Relative tolerance below which an entry of the Routh array is treated as
+// zero, and the relative size of the value substituted for a zero pivot.
+const routhTol = 1e-12
+
+// Computes the Routh array of a polynomial, as used by the Routh-Hurwitz
+// stability criterion.
+// Row i corresponds to the power x^(n-i), where n is the degree of p. The
+// first two rows hold the alternate coefficients of p starting from the
+// leading coefficient, and each later row is computed from the two above it.
+// Shorter rows are padded with zeros.
+// A row that vanishes entirely is replaced by the coefficients of the
+// derivative of the auxiliary polynomial formed from the row above it. A zero
+// in the first column of an otherwise nonzero row is replaced by a small
+// positive value. Entries that cancel to within rounding error are zero.
+func RouthArray(p Poly) [][]float64 {
+	rows, _ := routh(p)
+	return rows
+}
+
+// Reports whether a polynomial is Hurwitz stable, that is, whether all of its
+// roots lie strictly in the left half of the complex plane.
+// By the Routh-Hurwitz criterion this is the case when the first column of
+// the Routh array is nonzero and does not change sign. Polynomials with roots
+// on the imaginary axis, including roots at zero, are not stable. Nonzero
+// constants have no roots and are stable; the zero polynomial is not.
+func IsHurwitzStable(p Poly) bool {
+	if p.isZero() {
+		return false
+	}
+	rows, regular := routh(p)
+	if !regular {
+		return false
+	}
+	for _, r := range rows {
+		if r[0] == 0 || (r[0] < 0) != (rows[0][0] < 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// Computes the Routh array, also reporting whether it was computed without
+// replacing a zero row or a zero pivot.
+func routh(p Poly) (rows [][]float64, regular bool) {
+	pco := p.co()
+	n := len(pco) - 1
+	m := n/2 + 1
+	rows = make([][]float64, n+1)
+	for i := range rows {
+		rows[i] = make([]float64, m)
+	}
+	for j := 0; 2*j <= n; j++ {
+		rows[0][j] = pco[n-2*j]
+	}
+	for j := 0; 2*j+1 <= n; j++ {
+		rows[1][j] = pco[n-2*j-1]
+	}
+	regular = true
+	for i := 2; i <= n; i++ {
+		a, b := rows[i-2], rows[i-1]
+		if isZeroRow(b) {
+			// The row above is an auxiliary polynomial in x^(n-i+2), x^(n-i),
+			// ... that divides p.
+			regular = false
+			k := n - i + 2
+			for j := range b {
+				b[j] = a[j] * float64(k-2*j)
+			}
+		}
+		if b[0] == 0 {
+			regular = false
+			b[0] = routhTol * maxAbsRow(b)
+		}
+		for j := 0; j+1 < m; j++ {
+			x, y := b[0]*a[j+1], a[0]*b[j+1]
+			if v := x - y; math.Abs(v) > routhTol*(math.Abs(x)+math.Abs(y)) {
+				rows[i][j] = v / b[0]
+			}
+		}
+	}
+	if rows[n][0] == 0 {
+		regular = false
+	}
+	return rows, regular
+}
+
+// Reports whether every entry of a row is zero.
+func isZeroRow(r []float64) bool {
+	for _, v := range r {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the largest absolute value in a row, or 1 if the row is zero.
+func maxAbsRow(r []float64) float64 {
+	var m float64
+	for _, v := range r {
+		m = math.Max(m, math.Abs(v))
+	}
+	if m == 0 {
+		return 1
+	}
+	return m
+}
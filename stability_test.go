@@ -0,0 +1,67 @@
+package poly
+
+import (
+	"math"
+	"testing"
+)
+
+// Tests that Routh arrays are computed correctly.
+func TestRouthArray(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		want [][]float64
+	}{
+		{New(3), [][]float64{{3}}},
+		{New(1, 2), [][]float64{{2}, {1}}},
+		{New(3, 2, 1), [][]float64{{1, 3}, {2, 0}, {3, 0}}},
+		{New(5, 4, 3, 2, 1), [][]float64{{1, 3, 5}, {2, 4, 0}, {1, 5, 0}, {-6, 0, 0}, {5, 0, 0}}},
+		{New(6, 11, 6, 1), [][]float64{{1, 11}, {6, 6}, {10, 0}, {6, 0}}},
+		// (x+1)(x^2+1) produces a zero row, replaced using the derivative of
+		// the auxiliary polynomial x^2 + 1.
+		{New(1, 1, 1, 1), [][]float64{{1, 1}, {1, 1}, {2, 0}, {1, 0}}},
+	}
+	for i, c := range cases {
+		got := RouthArray(c.p)
+		ok := len(got) == len(c.want)
+		for j := 0; ok && j < len(got); j++ {
+			ok = len(got[j]) == len(c.want[j])
+			for k := 0; ok && k < len(got[j]); k++ {
+				ok = math.Abs(got[j][k]-c.want[j][k]) <= 0.00001
+			}
+		}
+		if !ok {
+			t.Errorf("case %d: RouthArray(%q) == %v, want %v", i, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests that Hurwitz stability is determined correctly.
+func TestIsHurwitzStable(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		want bool
+	}{
+		{Poly{}, false},
+		{New(2), true},
+		{New(1, 1), true},
+		{New(-1, 1), false},
+		{New(0, 1), false},
+		{New(-1, -1), true},
+		{New(1, 2, 1), true},
+		{New(1, 0, 1), false},
+		{FromRoots(-1, -2, -3), true},
+		{FromRoots(-1, -2, 3), false},
+		{New(5, 4, 3, 2, 1), false},
+		{New(8, 2, 1, 1), false},
+		{New(1, 1, 1, 1), false},
+		{New(3, 2, 0, 1), false},
+		// (x^2 + 0.2x + 1)(x + 0.5)(x^2 + x + 4) has lightly damped roots.
+		{New(1, 0.2, 1).Mul(New(0.5, 1)).Mul(New(4, 1, 1)), true},
+		{New(1, -0.2, 1).Mul(New(0.5, 1)).Mul(New(4, 1, 1)), false},
+	}
+	for i, c := range cases {
+		if got := IsHurwitzStable(c.p); got != c.want {
+			t.Errorf("case %d: IsHurwitzStable(%q) == %t, want %t", i, c.p, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+//go:build go1.23
+
+package poly
+
+import "iter"
+
+// Returns an iterator over the terms of a polynomial, yielding the exponent
+// and coefficient of each term from the constant term up to the highest
+// order term, including terms with zero coefficients.
+// Example:
+//
+//	for n, c := range p.Terms() {
+//	    fmt.Printf("%g x^%d\n", c, n)
+//	}
+//
+// This method is only available with Go 1.23 or later, which support range
+// over functions.
+func (p Poly) Terms() iter.Seq2[int, float64] {
+	return func(yield func(int, float64) bool) {
+		for i, c := range p.co() {
+			if !yield(i, c) {
+				return
+			}
+		}
+	}
+}
+
+// Returns an iterator over the terms of a polynomial with nonzero
+// coefficients, yielding the exponent and coefficient of each from the lowest
+// order term up. The zero polynomial has no such terms.
+//
+// This method is only available with Go 1.23 or later, which support range
+// over functions.
+func (p Poly) NonzeroTerms() iter.Seq2[int, float64] {
+	return func(yield func(int, float64) bool) {
+		for i, c := range p.co() {
+			if c != 0 && !yield(i, c) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+//go:build go1.23
+
+package poly
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Tests iteration over the terms of polynomials.
+func TestTerms(t *testing.T) {
+	cases := []struct {
+		p              Poly
+		terms, nonzero string
+	}{
+		{Poly{}, "0:0", ""},
+		{New(5), "0:5", "0:5"},
+		{New(1, 0, -2, 0, 3), "0:1 1:0 2:-2 3:0 4:3", "0:1 2:-2 4:3"},
+		{New(0, 0, 1), "0:0 1:0 2:1", "2:1"},
+	}
+	for i, c := range cases {
+		var terms, nonzero string
+		for n, a := range c.p.Terms() {
+			terms += fmt.Sprintf(" %d:%g", n, a)
+		}
+		for n, a := range c.p.NonzeroTerms() {
+			nonzero += fmt.Sprintf(" %d:%g", n, a)
+		}
+		if got := strings.TrimSpace(terms); got != c.terms {
+			t.Errorf("case %d: Terms() on %q yielded %q, want %q", i, c.p, got, c.terms)
+		}
+		if got := strings.TrimSpace(nonzero); got != c.nonzero {
+			t.Errorf("case %d: NonzeroTerms() on %q yielded %q, want %q", i, c.p, got, c.nonzero)
+		}
+	}
+}
+
+// Tests that iteration stops early when the loop is broken.
+func TestTermsBreak(t *testing.T) {
+	p := New(1, 2, 0, 4, 5)
+	var got []int
+	for n := range p.Terms() {
+		if n == 2 {
+			break
+		}
+		got = append(got, n)
+	}
+	if fmt.Sprint(got) != "[0 1]" {
+		t.Errorf("Terms() with break yielded %v, want [0 1]", got)
+	}
+	got = nil
+	for n := range p.NonzeroTerms() {
+		if n == 3 {
+			break
+		}
+		got = append(got, n)
+	}
+	if fmt.Sprint(got) != "[0 1]" {
+		t.Errorf("NonzeroTerms() with break yielded %v, want [0 1]", got)
+	}
+}
@@ -0,0 +1,112 @@
+package poly
+
+import "fmt"
+
+// TransferFunction is a rational function N(s)/D(s) describing a continuous
+// time linear time-invariant system in the Laplace domain.
+// The denominator is kept monic. Common factors of the numerator and
+// denominator are not cancelled, so composed systems keep every pole and
+// zero of their parts.
+// A zero valued TransferFunction is equivalent to 0.
+type TransferFunction struct {
+	num Poly
+	den Poly
+}
+
+// Creates the transfer function num/den, scaling both so that the
+// denominator is monic.
+// Returns an error if den is zero.
+func NewTransferFunction(num, den Poly) (TransferFunction, error) {
+	if den.isZero() {
+		return TransferFunction{}, fmt.Errorf("poly: transfer function has zero denominator")
+	}
+	k := 1 / den.Coeff(den.Deg())
+	return TransferFunction{num.Scale(k), den.Scale(k)}, nil
+}
+
+// Returns the numerator polynomial.
+func (tf TransferFunction) Num() Poly {
+	return tf.num
+}
+
+// Returns the denominator polynomial, which is monic.
+func (tf TransferFunction) Den() Poly {
+	if tf.den.isZero() {
+		return New(1)
+	}
+	return tf.den
+}
+
+// Evaluates the transfer function at the complex frequency s. The frequency
+// response at angular frequency w is Eval(complex(0, w)).
+func (tf TransferFunction) Eval(s complex128) complex128 {
+	return tf.num.EvalC(s) / tf.Den().EvalC(s)
+}
+
+// Returns the poles, which are the roots of the denominator.
+func (tf TransferFunction) Poles() []complex128 {
+	return tf.Den().Roots()
+}
+
+// Returns the zeros, which are the roots of the numerator.
+func (tf TransferFunction) Zeros() []complex128 {
+	return tf.num.Roots()
+}
+
+// Returns the gain k in the zero-pole-gain form
+// k*(s-z[0])*...*(s-z[m-1]) / ((s-p[0])*...*(s-p[n-1])), which is the
+// leading coefficient of the numerator.
+func (tf TransferFunction) Gain() float64 {
+	return tf.num.Coeff(tf.num.Deg())
+}
+
+// Returns the steady state gain N(0)/D(0), the response to a unit step after
+// transients have decayed. It is infinite if there is a pole at 0.
+func (tf TransferFunction) DCGain() float64 {
+	return tf.num.Eval(0) / tf.Den().Eval(0)
+}
+
+// Reports whether the transfer function is proper, meaning that the degree of
+// the numerator does not exceed that of the denominator, so that the gain
+// stays bounded at high frequencies.
+func (tf TransferFunction) IsProper() bool {
+	return tf.num.isZero() || tf.num.Deg() <= tf.Den().Deg()
+}
+
+// Reports whether the transfer function is strictly proper, meaning that the
+// degree of the numerator is less than that of the denominator.
+func (tf TransferFunction) IsStrictlyProper() bool {
+	return tf.num.isZero() || tf.num.Deg() < tf.Den().Deg()
+}
+
+// Connects two systems in series, so that the output of tf drives h.
+// Returns tf*h.
+func (tf TransferFunction) Series(h TransferFunction) TransferFunction {
+	return TransferFunction{tf.num.Mul(h.num), tf.Den().Mul(h.Den())}
+}
+
+// Connects two systems in parallel, summing their outputs.
+// Returns tf+h.
+func (tf TransferFunction) Parallel(h TransferFunction) TransferFunction {
+	num := tf.num.Mul(h.Den()).Add(h.num.Mul(tf.Den()))
+	return TransferFunction{num, tf.Den().Mul(h.Den())}
+}
+
+// Closes a negative feedback loop around tf, with h in the feedback path.
+// Returns tf/(1 + tf*h). For unity feedback, h is the constant 1.
+// Panics if 1 + tf*h is identically zero.
+func (tf TransferFunction) Feedback(h TransferFunction) TransferFunction {
+	num := tf.num.Mul(h.Den())
+	den := tf.Den().Mul(h.Den()).Add(tf.num.Mul(h.num))
+	r, err := NewTransferFunction(num, den)
+	if err != nil {
+		panic("poly: feedback loop has zero denominator")
+	}
+	return r
+}
+
+// Returns a printable string representing the transfer function as
+// "(num) / (den)".
+func (tf TransferFunction) String() string {
+	return fmt.Sprintf("(%v) / (%v)", tf.num, tf.Den())
+}
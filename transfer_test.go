@@ -0,0 +1,112 @@
+package poly
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// Compares two sorted lists of complex roots.
+func compareComplexRoots(got, want []complex128) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if cmplx.Abs(got[i]-want[i]) > 0.00001 {
+			return false
+		}
+	}
+	return true
+}
+
+// Creates a transfer function, failing the test on error.
+func newTF(t *testing.T, num, den Poly) TransferFunction {
+	t.Helper()
+	tf, err := NewTransferFunction(num, den)
+	if err != nil {
+		t.Fatalf("NewTransferFunction(%q, %q) returned error %v", num, den, err)
+	}
+	return tf
+}
+
+// Tests poles, zeros and gains of transfer functions.
+func TestTransferFunction(t *testing.T) {
+	// 4(s + 1) / (2(s + 2)(s + 3)).
+	tf := newTF(t, New(4, 4), New(12, 10, 2))
+	if got, want := tf.String(), "(2.000x + 2.000) / (x^2 + 5.000x + 6.000)"; got != want {
+		t.Errorf("String() == %q, want %q", got, want)
+	}
+	if got, want := tf.Poles(), []complex128{-3, -2}; !compareComplexRoots(got, want) {
+		t.Errorf("Poles() == %v, want %v", got, want)
+	}
+	if got, want := tf.Zeros(), []complex128{-1}; !compareComplexRoots(got, want) {
+		t.Errorf("Zeros() == %v, want %v", got, want)
+	}
+	if got := tf.Gain(); got != 2 {
+		t.Errorf("Gain() == %v, want 2", got)
+	}
+	if got := tf.DCGain(); math.Abs(got-1.0/3) > 0.00001 {
+		t.Errorf("DCGain() == %v, want 1/3", got)
+	}
+	if got, want := tf.Eval(1i), (2+2i)/(5+5i); cmplx.Abs(got-want) > 0.00001 {
+		t.Errorf("Eval(i) == %v, want %v", got, want)
+	}
+	// An integrator has infinite DC gain.
+	if got := newTF(t, New(1), New(0, 1)).DCGain(); !math.IsInf(got, 1) {
+		t.Errorf("DCGain() of 1/s == %v, want +Inf", got)
+	}
+	if _, err := NewTransferFunction(New(1), Poly{}); err == nil {
+		t.Errorf("NewTransferFunction() with zero denominator succeeded, want error")
+	}
+}
+
+// Tests properness of transfer functions.
+func TestTransferFunctionIsProper(t *testing.T) {
+	cases := []struct {
+		num, den       Poly
+		proper, strict bool
+	}{
+		{Poly{}, New(1), true, true},
+		{New(1), New(1, 1), true, true},
+		{New(0, 1), New(1, 1), true, false},
+		{New(0, 0, 1), New(1, 1), false, false},
+	}
+	for i, c := range cases {
+		tf := newTF(t, c.num, c.den)
+		if got := tf.IsProper(); got != c.proper {
+			t.Errorf("case %d: IsProper() on %q == %v, want %v", i, tf, got, c.proper)
+		}
+		if got := tf.IsStrictlyProper(); got != c.strict {
+			t.Errorf("case %d: IsStrictlyProper() on %q == %v, want %v", i, tf, got, c.strict)
+		}
+	}
+}
+
+// Tests series, parallel and feedback composition.
+func TestTransferFunctionCompose(t *testing.T) {
+	g := newTF(t, New(1), New(1, 1))
+	h := newTF(t, New(2), New(3, 1))
+	cases := []struct {
+		name     string
+		got      TransferFunction
+		num, den Poly
+	}{
+		{"Series", g.Series(h), New(2), New(3, 4, 1)},
+		{"Parallel", g.Parallel(h), New(5, 3), New(3, 4, 1)},
+		{"Feedback", g.Feedback(h), New(3, 1), New(5, 4, 1)},
+		{"unity Feedback", g.Feedback(newTF(t, New(1), New(1))), New(1), New(2, 1)},
+		{"zero value", TransferFunction{}.Parallel(g), New(1), New(1, 1)},
+	}
+	for _, c := range cases {
+		if !comparePoly(c.got.Num(), c.num) || !comparePoly(c.got.Den(), c.den) {
+			t.Errorf("%s == %q, want (%v) / (%v)", c.name, c.got, c.num, c.den)
+		}
+	}
+	// Closing the loop of 1/(s(s+1)) with gain 4 gives poles at
+	// -1/2 +- i*sqrt(15)/2.
+	k := newTF(t, New(4), New(0, 1, 1)).Feedback(newTF(t, New(1), New(1)))
+	s := math.Sqrt(15) / 2
+	if got, want := k.Poles(), []complex128{complex(-0.5, -s), complex(-0.5, s)}; !compareComplexRoots(got, want) {
+		t.Errorf("closed loop Poles() == %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,33 @@
+package poly
+
+// Computes the Taylor shift of a polynomial.
+// Returns the polynomial q such that q(x) = p(x+a).
+// The coefficients are computed by repeated synthetic division, which avoids
+// the binomial coefficients and cancellation of expanding each (x+a)^i.
+func (p Poly) Shift(a float64) Poly {
+	pco := p.co()
+	n := len(pco) - 1
+	c := make([]float64, len(pco))
+	copy(c, pco)
+	for i := 0; i < n; i++ {
+		for j := n - 1; j >= i; j-- {
+			c[j] += a * c[j+1]
+		}
+	}
+	return normalized(c)
+}
+
+// Scales the variable of a polynomial.
+// Returns the polynomial q such that q(x) = p(a*x).
+// Combined with Shift this gives an arbitrary affine change of variable, e.g.
+// p.Shift(b).ScaleVar(a) is p(a*x + b).
+func (p Poly) ScaleVar(a float64) Poly {
+	pco := p.co()
+	c := make([]float64, len(pco))
+	k := 1.0
+	for i, pc := range pco {
+		c[i] = pc * k
+		k *= a
+	}
+	return normalized(c)
+}
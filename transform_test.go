@@ -0,0 +1,77 @@
+package poly
+
+import (
+	"math"
+	"testing"
+)
+
+// Tests that Taylor shifts are computed correctly.
+func TestShift(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		a    float64
+		want Poly
+	}{
+		{Poly{}, 2, Poly{}},
+		{New(3), 2, New(3)},
+		{New(1, 2), 0, New(1, 2)},
+		{New(0, 1), 2, New(2, 1)},
+		{New(0, 0, 1), 1, New(1, 2, 1)},
+		{New(0, 0, 0, 1), -1, New(-1, 3, -3, 1)},
+		{New(1, -2, 0.5, 3), 1.5, New(1+-2*1.5+0.5*2.25+3*3.375, -2+1.5+3*3*2.25, 0.5+3*3*1.5, 3)},
+	}
+	for i, c := range cases {
+		if got := c.p.Shift(c.a); !comparePoly(got, c.want) {
+			t.Errorf("case %d: Shift(%.3f) on %q == %q, want %q", i, c.a, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests that a shifted polynomial evaluates as the original at x+a.
+func TestShiftEval(t *testing.T) {
+	p := New(-3, 1, 4, -1, 5, 9, -2)
+	for _, a := range []float64{-2, -0.5, 0.25, 3} {
+		q := p.Shift(a)
+		for _, x := range []float64{-1, 0, 0.5, 2} {
+			got, want := q.Eval(x), p.Eval(x+a)
+			if math.Abs(got-want) > 0.00001*math.Max(1, math.Abs(want)) {
+				t.Errorf("Shift(%.3f).Eval(%.3f) == %f, want %f", a, x, got, want)
+			}
+		}
+	}
+}
+
+// Tests that the variable of a polynomial is scaled correctly.
+func TestScaleVar(t *testing.T) {
+	cases := []struct {
+		p    Poly
+		a    float64
+		want Poly
+	}{
+		{Poly{}, 2, Poly{}},
+		{New(3), 2, New(3)},
+		{New(1, 2, 3), 1, New(1, 2, 3)},
+		{New(1, 2, 3), 0, New(1)},
+		{New(1, 2, 3), 2, New(1, 4, 12)},
+		{New(1, 1, 1, 1), -1, New(1, -1, 1, -1)},
+	}
+	for i, c := range cases {
+		if got := c.p.ScaleVar(c.a); !comparePoly(got, c.want) {
+			t.Errorf("case %d: ScaleVar(%.3f) on %q == %q, want %q", i, c.a, c.p, got, c.want)
+		}
+	}
+}
+
+// Tests that Shift and ScaleVar compose into an affine change of variable,
+// mapping a polynomial on [-1, 1] onto [2, 6].
+func TestAffineChange(t *testing.T) {
+	p := New(0.5, -1, 2, 0.25)
+	// x in [2, 6] maps to (x-4)/2 in [-1, 1].
+	q := p.Shift(-2).ScaleVar(0.5)
+	for _, x := range []float64{2, 3, 4.5, 6} {
+		got, want := q.Eval(x), p.Eval((x-4)/2)
+		if math.Abs(got-want) > 0.00001 {
+			t.Errorf("Eval(%.3f) == %f, want %f", x, got, want)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package poly
+
+import (
+	"bytes"
+	"math"
+)
+
+// VecPoly represents a vector valued polynomial of arbitrary degree.
+// Each coefficient is a vector of the same fixed dimension, so a VecPoly
+// describes a curve t -> R^n.
+// A zero valued VecPoly has dimension 0.
+type VecPoly struct {
+	comp []Poly
+}
+
+// Creates a new VecPoly of dimension n.
+// The ith parameter represents the coefficient vector of t^i, and must have
+// length n.
+// Example:
+//
+//	v := poly.NewVec(2, []float64{1, 0}, []float64{0, 1})
+//
+//	This represents the line (1, t).
+func NewVec(n int, c ...[]float64) VecPoly {
+	comp := make([]Poly, n)
+	for j := range comp {
+		a := make([]float64, len(c))
+		for i, ci := range c {
+			if len(ci) != n {
+				panic("poly: coefficient vector has wrong dimension")
+			}
+			a[i] = ci[j]
+		}
+		comp[j] = New(a...)
+	}
+	return VecPoly{comp}
+}
+
+// Creates a new VecPoly from its component polynomials.
+// The jth parameter becomes the jth component of the curve.
+func FromComponents(p ...Poly) VecPoly {
+	comp := make([]Poly, len(p))
+	copy(comp, p)
+	return VecPoly{comp}
+}
+
+// Returns the dimension of the vector space the curve lies in.
+func (v VecPoly) Dim() int {
+	return len(v.comp)
+}
+
+// Returns the degree of the highest order term of any component.
+func (v VecPoly) Deg() int {
+	d := 0
+	for _, p := range v.comp {
+		if p.Deg() > d {
+			d = p.Deg()
+		}
+	}
+	return d
+}
+
+// Returns the coefficient vector of the ith order term.
+func (v VecPoly) Coeff(i int) []float64 {
+	c := make([]float64, len(v.comp))
+	for j, p := range v.comp {
+		c[j] = p.Coeff(i)
+	}
+	return c
+}
+
+// Returns the jth component of the curve.
+func (v VecPoly) Component(j int) Poly {
+	return v.comp[j]
+}
+
+// Returns the component polynomials of the curve.
+func (v VecPoly) Components() []Poly {
+	comp := make([]Poly, len(v.comp))
+	copy(comp, v.comp)
+	return comp
+}
+
+// Evaluates the curve at the given parameter t.
+func (v VecPoly) Eval(t float64) []float64 {
+	x := make([]float64, len(v.comp))
+	for j, p := range v.comp {
+		x[j] = p.Eval(t)
+	}
+	return x
+}
+
+// Adds a curve to another curve of the same dimension.
+// Returns v+w.
+func (v VecPoly) Add(w VecPoly) VecPoly {
+	if v.Dim() != w.Dim() {
+		panic("poly: curves have different dimensions")
+	}
+	comp := make([]Poly, len(v.comp))
+	for j, p := range v.comp {
+		comp[j] = p.Add(w.comp[j])
+	}
+	return VecPoly{comp}
+}
+
+// Computes the derivative of a curve componentwise.
+func (v VecPoly) Der() VecPoly {
+	comp := make([]Poly, len(v.comp))
+	for j, p := range v.comp {
+		comp[j] = p.Der()
+	}
+	return VecPoly{comp}
+}
+
+// Computes the definite integral of a curve componentwise.
+// The provided constant vector k will be used as the 0th order term of the
+// result, and must have the same dimension as the curve.
+func (v VecPoly) Int(k []float64) VecPoly {
+	if len(k) != len(v.comp) {
+		panic("poly: constant vector has wrong dimension")
+	}
+	comp := make([]Poly, len(v.comp))
+	for j, p := range v.comp {
+		comp[j] = p.Int(k[j])
+	}
+	return VecPoly{comp}
+}
+
+// Computes the length of the curve for parameter values between a and b.
+// The speed |v'(t)| is integrated numerically with adaptive Gauss-Legendre
+// quadrature.
+func (v VecPoly) ArcLength(a, b float64) float64 {
+	// The squared speed is itself a polynomial, so build it once.
+	var s Poly
+	for _, p := range v.comp {
+		d := p.Der()
+		s = s.Add(d.Mul(d))
+	}
+	speed := func(t float64) float64 {
+		return math.Sqrt(math.Max(s.Eval(t), 0))
+	}
+	return integrate(speed, a, b)
+}
+
+// Returns a printable string representing the curve as a tuple of its
+// components.
+func (v VecPoly) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString("(")
+	for j, p := range v.comp {
+		if j > 0 {
+			buffer.WriteString(", ")
+		}
+		buffer.WriteString(p.String())
+	}
+	buffer.WriteString(")")
+	return buffer.String()
+}
+
+// Nodes and weights for 5 point Gauss-Legendre quadrature on [-1, 1].
+var (
+	glNodes = []float64{
+		-0.9061798459386640,
+		-0.5384693101056831,
+		0,
+		0.5384693101056831,
+		0.9061798459386640,
+	}
+	glWeights = []float64{
+		0.2369268850561891,
+		0.4786286704993665,
+		0.5688888888888889,
+		0.4786286704993665,
+		0.2369268850561891,
+	}
+)
+
+// Applies 5 point Gauss-Legendre quadrature to f on [a, b].
+func gaussLegendre(f func(float64) float64, a, b float64) float64 {
+	m := (a + b) / 2
+	h := (b - a) / 2
+	var s float64
+	for i, x := range glNodes {
+		s += glWeights[i] * f(m+h*x)
+	}
+	return s * h
+}
+
+// Numerically integrates f over [a, b].
+// The interval is bisected until the estimate on each half agrees with the
+// estimate on the whole.
+func integrate(f func(float64) float64, a, b float64) float64 {
+	return integrateAdaptive(f, a, b, gaussLegendre(f, a, b), 1e-12, 50)
+}
+
+func integrateAdaptive(f func(float64) float64, a, b, whole, tol float64, depth int) float64 {
+	m := (a + b) / 2
+	left := gaussLegendre(f, a, m)
+	right := gaussLegendre(f, m, b)
+	if depth <= 0 || math.Abs(left+right-whole) <= tol*math.Max(1, math.Abs(whole)) {
+		return left + right
+	}
+	return integrateAdaptive(f, a, m, left, tol/2, depth-1) +
+		integrateAdaptive(f, m, b, right, tol/2, depth-1)
+}
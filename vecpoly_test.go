@@ -0,0 +1,124 @@
+package poly
+
+import (
+	"math"
+	"testing"
+)
+
+func compareVec(x, y []float64) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if math.Abs(x[i]-y[i]) > 0.00001 {
+			return false
+		}
+	}
+	return true
+}
+
+func compareVecPoly(v, w VecPoly) bool {
+	if v.Dim() != w.Dim() {
+		return false
+	}
+	for j := 0; j < v.Dim(); j++ {
+		if !comparePoly(v.Component(j), w.Component(j)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Tests that vector coefficients are split into the expected components.
+func TestNewVec(t *testing.T) {
+	v := NewVec(3, []float64{1, 2, 3}, []float64{4, 5, 6})
+	want := []Poly{New(1, 4), New(2, 5), New(3, 6)}
+	if got := v.Dim(); got != len(want) {
+		t.Fatalf("Dim() == %d, want %d", got, len(want))
+	}
+	for j, w := range want {
+		if got := v.Component(j); !comparePoly(got, w) {
+			t.Errorf("Component(%d) == %q, want %q", j, got, w)
+		}
+	}
+	if got, want := v.Coeff(1), []float64{4, 5, 6}; !compareVec(got, want) {
+		t.Errorf("Coeff(1) == %v, want %v", got, want)
+	}
+}
+
+// Tests the degree of various curves.
+func TestVecDeg(t *testing.T) {
+	cases := []struct {
+		v    VecPoly
+		want int
+	}{
+		{VecPoly{}, 0},
+		{FromComponents(New(1), New(2)), 0},
+		{FromComponents(New(1, 2), New(2)), 1},
+		{FromComponents(New(1), New(1, 2, 3)), 2},
+	}
+	for i, c := range cases {
+		if got := c.v.Deg(); got != c.want {
+			t.Errorf("case %d: Deg() on %q == %d, want %d", i, c.v, got, c.want)
+		}
+	}
+}
+
+// Tests that curves evaluate to the expected points.
+func TestVecEval(t *testing.T) {
+	cases := []struct {
+		v    VecPoly
+		t    float64
+		want []float64
+	}{
+		{VecPoly{}, 1, []float64{}},
+		{FromComponents(New(0, 1), New(0, 0, 1)), 2, []float64{2, 4}},
+		{FromComponents(New(1, 2), New(-1), New(0, 0, 3)), -1, []float64{-1, -1, 3}},
+	}
+	for i, c := range cases {
+		if got := c.v.Eval(c.t); !compareVec(got, c.want) {
+			t.Errorf("case %d: Eval(%f) on %q == %v, want %v", i, c.t, c.v, got, c.want)
+		}
+	}
+}
+
+// Tests that curve derivatives and integrals are computed componentwise.
+func TestVecDerInt(t *testing.T) {
+	v := FromComponents(New(1, 2, 3), New(4, 5))
+	if got, want := v.Der(), FromComponents(New(2, 6), New(5)); !compareVecPoly(got, want) {
+		t.Errorf("Der() on %q == %q, want %q", v, got, want)
+	}
+	k := []float64{1, 4}
+	if got := v.Der().Int(k); !compareVecPoly(got, v) {
+		t.Errorf("Der().Int(%v) on %q == %q, want %q", k, v, got, v)
+	}
+}
+
+// Tests that arc lengths are computed correctly.
+func TestArcLength(t *testing.T) {
+	cases := []struct {
+		v    VecPoly
+		a, b float64
+		want float64
+	}{
+		{VecPoly{}, 0, 1, 0},
+		{FromComponents(New(0, 3), New(0, 4)), 0, 1, 5},
+		{FromComponents(New(0, 3), New(0, 4)), -1, 1, 10},
+		{FromComponents(New(0, 1), New(0, 0, 1)), 0, 1, (2*math.Sqrt(5) + math.Asinh(2)) / 4},
+		{FromComponents(New(0, 0, 1), New(0, 0, 0, 1)), -1, 1, 2 * (13*math.Sqrt(13) - 8) / 27},
+	}
+	for i, c := range cases {
+		if got := c.v.ArcLength(c.a, c.b); math.Abs(got-c.want) > 0.00001 {
+			t.Errorf("case %d: ArcLength(%f, %f) on %q == %f, want %f", i, c.a, c.b, c.v, got, c.want)
+		}
+	}
+}
+
+// Tests the string representation of a curve.
+func TestVecString(t *testing.T) {
+	v := FromComponents(New(0, 1), New(1, 0, -1))
+	want := "(x, -x^2 + 1.000)"
+	if got := v.String(); got != want {
+		t.Errorf("String() == %q, want %q", got, want)
+	}
+}